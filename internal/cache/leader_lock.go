@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// renewLockScript extends the lease when it is still held by the caller
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lease only when it is still held by the caller
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// LeaderLock is a Redis lease that at most one replica holds at a time. It is used
+// to elect a single replica for background jobs. Without Redis every caller is the leader.
+type LeaderLock struct {
+	client *redis.Client
+	key    string
+	owner  string
+	ttl    time.Duration
+}
+
+// NewLeaderLock creates a lock on key whose lease expires after ttl unless renewed
+func NewLeaderLock(client *redis.Client, key string, ttl time.Duration) *LeaderLock {
+	return &LeaderLock{
+		client: client,
+		key:    "lock:" + key,
+		owner:  newLockOwner(),
+		ttl:    ttl,
+	}
+}
+
+// NewLeaderLock creates a leader lock on the cache manager's Redis connection
+func (cm *CacheManager) NewLeaderLock(key string, ttl time.Duration) *LeaderLock {
+	return NewLeaderLock(cm.Fast.client, key, ttl)
+}
+
+// Acquire takes the lease, or renews it when this instance already holds it.
+// It reports whether this instance is the leader.
+func (l *LeaderLock) Acquire(ctx context.Context) (bool, error) {
+	if l.client == nil {
+		return true, nil
+	}
+
+	renewed, err := renewLockScript.Run(ctx, l.client, []string{l.key}, l.owner, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to renew lock %s: %w", l.key, err)
+	}
+	if renewed == 1 {
+		return true, nil
+	}
+
+	acquired, err := l.client.SetNX(ctx, l.key, l.owner, l.ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", l.key, err)
+	}
+	return acquired, nil
+}
+
+// Release gives up the lease if this instance holds it
+func (l *LeaderLock) Release(ctx context.Context) error {
+	if l.client == nil {
+		return nil
+	}
+
+	if err := releaseLockScript.Run(ctx, l.client, []string{l.key}, l.owner).Err(); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", l.key, err)
+	}
+	return nil
+}
+
+// newLockOwner identifies this process, random bytes keep restarted pods with the same hostname apart
+func newLockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(buf))
+}
@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis speaks just enough RESP for LeaderLock: SET NX with a ttl, GET and the two lock scripts,
+// which it recognizes by their body instead of running Lua
+type fakeRedis struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newFakeRedis(t *testing.T) (*fakeRedis, *redis.Client) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	server := &fakeRedis{listener: listener, values: map[string]string{}, expires: map[string]time.Time{}}
+	go server.serve()
+
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), Protocol: 2, DisableIdentity: true})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+	return server, client
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	// Arguments are bulk strings, read by length since scripts span lines
+	args := make([]string, count)
+	for i := range args {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "$")))
+		if err != nil {
+			return nil, err
+		}
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:length])
+	}
+	return args, nil
+}
+
+func (f *fakeRedis) execute(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		value, ok := f.get(args[1])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET": // SET key value [EX s | PX ms] [NX]
+		ttl, nx := time.Duration(0), false
+		for i := 3; i < len(args); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "NX":
+				nx = true
+			case "EX":
+				seconds, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(seconds) * time.Second
+				i++
+			case "PX":
+				ms, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(ms) * time.Millisecond
+				i++
+			}
+		}
+		if _, exists := f.get(args[1]); exists && nx {
+			return "$-1\r\n"
+		}
+		f.set(args[1], args[2], ttl)
+		return "+OK\r\n"
+	case "EVALSHA":
+		return "-NOSCRIPT No matching script\r\n"
+	case "EVAL": // EVAL script 1 key owner [ttl]
+		value, ok := f.get(args[3])
+		if !ok || value != args[4] {
+			return ":0\r\n"
+		}
+		if strings.Contains(args[1], "PEXPIRE") {
+			ms, _ := strconv.Atoi(args[5])
+			f.set(args[3], value, time.Duration(ms)*time.Millisecond)
+		} else {
+			delete(f.values, args[3])
+		}
+		return ":1\r\n"
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func (f *fakeRedis) get(key string) (string, bool) {
+	if expires, ok := f.expires[key]; ok && time.Now().After(expires) {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	value, ok := f.values[key]
+	return value, ok
+}
+
+func (f *fakeRedis) set(key, value string, ttl time.Duration) {
+	f.values[key] = value
+	delete(f.expires, key)
+	if ttl > 0 {
+		f.expires[key] = time.Now().Add(ttl)
+	}
+}
+
+func (f *fakeRedis) ttl(key string) time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return time.Until(f.expires[key])
+}
+
+func mustAcquire(t *testing.T, lock *LeaderLock, want bool) {
+	t.Helper()
+	leader, err := lock.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if leader != want {
+		t.Fatalf("Acquire() = %v, want %v", leader, want)
+	}
+}
+
+func TestLeaderLock_SingleLeader(t *testing.T) {
+	_, client := newFakeRedis(t)
+	first := NewLeaderLock(client, "job", time.Minute)
+	second := NewLeaderLock(client, "job", time.Minute)
+
+	mustAcquire(t, first, true)
+	mustAcquire(t, second, false)
+	// The holder keeps the lease on the next tick
+	mustAcquire(t, first, true)
+	mustAcquire(t, second, false)
+}
+
+func TestLeaderLock_Renew(t *testing.T) {
+	server, client := newFakeRedis(t)
+	lock := NewLeaderLock(client, "job", time.Minute)
+
+	mustAcquire(t, lock, true)
+	time.Sleep(20 * time.Millisecond)
+	mustAcquire(t, lock, true)
+
+	if ttl := server.ttl("lock:job"); ttl < time.Minute-10*time.Millisecond {
+		t.Errorf("lease ttl after renew = %v, want about %v", ttl, time.Minute)
+	}
+}
+
+func TestLeaderLock_Expiry(t *testing.T) {
+	_, client := newFakeRedis(t)
+	first := NewLeaderLock(client, "job", 20*time.Millisecond)
+	second := NewLeaderLock(client, "job", 20*time.Millisecond)
+
+	mustAcquire(t, first, true)
+	time.Sleep(40 * time.Millisecond)
+	// A crashed leader's lease runs out and another replica takes over
+	mustAcquire(t, second, true)
+	mustAcquire(t, first, false)
+}
+
+func TestLeaderLock_Release(t *testing.T) {
+	_, client := newFakeRedis(t)
+	first := NewLeaderLock(client, "job", time.Minute)
+	second := NewLeaderLock(client, "job", time.Minute)
+
+	mustAcquire(t, first, true)
+
+	// Releasing a lease held by someone else leaves it alone
+	if err := second.Release(context.Background()); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	mustAcquire(t, second, false)
+
+	if err := first.Release(context.Background()); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	mustAcquire(t, second, true)
+}
+
+func TestLeaderLock_WithoutRedis(t *testing.T) {
+	lock := NewLeaderLock(nil, "job", time.Minute)
+
+	mustAcquire(t, lock, true)
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("Release() error = %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills the bucket for the time elapsed since the last call and takes one
+// token when available. Redis TIME is used so replicas with skewed clocks share one bucket.
+// Returns {allowed, tokens left}; tokens are returned as a string to keep the fraction.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+const rateLimitPrefix = "ratelimit:"
+
+// localBucketSweepInterval is how often idle in-memory buckets are dropped
+const localBucketSweepInterval = time.Minute
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int           // bucket capacity
+	Remaining  int           // whole tokens left
+	RetryAfter time.Duration // until the next token, zero when allowed
+	ResetAfter time.Duration // until the bucket is full again
+}
+
+// RateLimiter is a token bucket limiter shared by all replicas through Redis. Without Redis,
+// or while Redis is unreachable, buckets are kept in process memory.
+type RateLimiter struct {
+	client *redis.Client
+
+	mu        sync.Mutex
+	buckets   map[string]*localBucket
+	lastSweep time.Time
+}
+
+type localBucket struct {
+	tokens   float64
+	updated  time.Time
+	fullTime time.Duration // time to refill from empty, used to expire idle buckets
+}
+
+// NewRateLimiter creates a rate limiter on client, which may be nil
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{
+		client:    client,
+		buckets:   make(map[string]*localBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// NewRateLimiter creates a rate limiter on the cache manager's Redis connection
+func (cm *CacheManager) NewRateLimiter() *RateLimiter {
+	return NewRateLimiter(cm.Fast.client)
+}
+
+// Allow takes a token from the bucket identified by key. The bucket holds up to burst tokens and
+// refills at requestsPerMinute. When Redis fails the local bucket decides and the error is returned
+// alongside its result so the caller can log it.
+func (l *RateLimiter) Allow(ctx context.Context, key string, requestsPerMinute, burst int) (RateLimitResult, error) {
+	if requestsPerMinute <= 0 {
+		return RateLimitResult{Allowed: true, Limit: burst, Remaining: burst}, nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	rate := float64(requestsPerMinute) / float64(time.Minute.Milliseconds()) // tokens per millisecond
+
+	if l.client == nil {
+		return l.allowLocal(key, rate, burst), nil
+	}
+
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{rateLimitPrefix + key}, rate, burst).Slice()
+	if err != nil {
+		return l.allowLocal(key, rate, burst), fmt.Errorf("failed to check rate limit %s: %w", key, err)
+	}
+	if len(values) != 2 {
+		return l.allowLocal(key, rate, burst), fmt.Errorf("unexpected rate limit reply for %s: %v", key, values)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokensText, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return l.allowLocal(key, rate, burst), fmt.Errorf("unexpected rate limit reply for %s: %w", key, err)
+	}
+
+	return newRateLimitResult(allowed == 1, tokens, rate, burst), nil
+}
+
+func (l *RateLimiter) allowLocal(key string, rate float64, burst int) RateLimitResult {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > localBucketSweepInterval {
+		for k, bucket := range l.buckets {
+			if now.Sub(bucket.updated) > bucket.fullTime {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &localBucket{
+			tokens:   float64(burst),
+			updated:  now,
+			fullTime: time.Duration(float64(burst)/rate) * time.Millisecond,
+		}
+		l.buckets[key] = bucket
+	}
+
+	elapsed := float64(now.Sub(bucket.updated)) / float64(time.Millisecond)
+	bucket.tokens = math.Min(float64(burst), bucket.tokens+elapsed*rate)
+	bucket.updated = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	return newRateLimitResult(allowed, bucket.tokens, rate, burst)
+}
+
+func newRateLimitResult(allowed bool, tokens, rate float64, burst int) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:    allowed,
+		Limit:      burst,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration((float64(burst)-tokens)/rate) * time.Millisecond,
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1-tokens)/rate) * time.Millisecond
+	}
+	return result
+}
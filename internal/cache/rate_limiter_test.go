@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_AllowLocal(t *testing.T) {
+	limiter := NewRateLimiter(nil)
+	ctx := context.Background()
+
+	// 60 per minute is one token a second, with room for a burst of 3
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Allow(ctx, "burst", 60, 3)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("request %d: Allowed = %v, Remaining = %d, want true, %d", i+1, result.Allowed, result.Remaining, 2-i)
+		}
+	}
+
+	result, _ := limiter.Allow(ctx, "burst", 60, 3)
+	if result.Allowed {
+		t.Fatal("request over the burst was allowed")
+	}
+	if result.RetryAfter <= 0 || result.RetryAfter > time.Second {
+		t.Errorf("RetryAfter = %v, want up to 1s", result.RetryAfter)
+	}
+
+	// Other keys have their own bucket
+	if result, _ := limiter.Allow(ctx, "other", 60, 3); !result.Allowed {
+		t.Error("separate bucket was limited")
+	}
+}
+
+func TestRateLimiter_AllowLocalRefill(t *testing.T) {
+	rate := 60.0 / float64(time.Minute.Milliseconds())
+
+	tests := []struct {
+		name          string
+		idle          time.Duration
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{"no time passed", 0, false, 0},
+		{"one token refilled", 1500 * time.Millisecond, true, 0},
+		{"two tokens refilled", 2 * time.Second, true, 1},
+		{"refill is capped at the burst", time.Hour, true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(nil)
+			limiter.buckets["key"] = &localBucket{tokens: 0, updated: time.Now().Add(-tt.idle), fullTime: 3 * time.Second}
+
+			result := limiter.allowLocal("key", rate, 3)
+			if result.Allowed != tt.wantAllowed || result.Remaining != tt.wantRemaining {
+				t.Errorf("allowLocal() = Allowed %v, Remaining %d, want %v, %d", result.Allowed, result.Remaining, tt.wantAllowed, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_AllowLocalSweep(t *testing.T) {
+	rate := 60.0 / float64(time.Minute.Milliseconds())
+	limiter := NewRateLimiter(nil)
+	now := time.Now()
+	limiter.buckets["idle"] = &localBucket{tokens: 1, updated: now.Add(-time.Hour), fullTime: 3 * time.Second}
+	limiter.buckets["busy"] = &localBucket{tokens: 1, updated: now, fullTime: 3 * time.Second}
+
+	// Not due for a sweep yet
+	limiter.allowLocal("key", rate, 3)
+	if _, ok := limiter.buckets["idle"]; !ok {
+		t.Fatal("idle bucket dropped before the sweep interval")
+	}
+
+	limiter.lastSweep = now.Add(-2 * localBucketSweepInterval)
+	limiter.allowLocal("key", rate, 3)
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("idle bucket was not swept")
+	}
+	if _, ok := limiter.buckets["busy"]; !ok {
+		t.Error("bucket refilling was swept")
+	}
+}
+
+func TestNewRateLimitResult(t *testing.T) {
+	rate := 60.0 / float64(time.Minute.Milliseconds())
+
+	tests := []struct {
+		name    string
+		allowed bool
+		tokens  float64
+		want    RateLimitResult
+	}{
+		{"full bucket", true, 5, RateLimitResult{Allowed: true, Limit: 5, Remaining: 5}},
+		{"partial bucket", true, 2.5, RateLimitResult{Allowed: true, Limit: 5, Remaining: 2, ResetAfter: 2500 * time.Millisecond}},
+		{"empty bucket", false, 0.25, RateLimitResult{Allowed: false, Limit: 5, Remaining: 0, RetryAfter: 750 * time.Millisecond, ResetAfter: 4750 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newRateLimitResult(tt.allowed, tt.tokens, rate, 5); got != tt.want {
+				t.Errorf("newRateLimitResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+)
+
+// StorageConfig holds configuration for uploaded file storage
+type StorageConfig struct {
+	Backend   string `env:"STORAGE_BACKEND" envDefault:"local"` // local or s3
+	LocalDir  string `env:"STORAGE_LOCAL_DIR" envDefault:"./uploads"`
+	PublicURL string `env:"STORAGE_PUBLIC_URL" envDefault:"/uploads"` // Path the local files are served under
+
+	// S3-compatible backend (AWS S3, MinIO)
+	S3Endpoint  string `env:"S3_ENDPOINT" envDefault:"localhost:9000"`
+	S3Region    string `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket    string `env:"S3_BUCKET" envDefault:"assessment-attachments"`
+	S3AccessKey string `env:"S3_ACCESS_KEY"`
+	S3SecretKey string `env:"S3_SECRET_KEY"`
+	S3UseSSL    bool   `env:"S3_USE_SSL" envDefault:"false"`
+	S3PublicURL string `env:"S3_PUBLIC_URL"` // CDN or proxy in front of the bucket
+}
+
+// ServesLocalFiles reports whether the application itself has to serve stored files
+func (c *StorageConfig) ServesLocalFiles() bool {
+	return c.Backend == "local"
+}
+
+// CreateStorage creates a file storage backend based on configuration
+func (c *StorageConfig) CreateStorage(logger *slog.Logger) (storage.Storage, error) {
+	switch c.Backend {
+	case "local":
+		logger.Info("Using local file storage", "dir", c.LocalDir, "public_url", c.PublicURL)
+		return storage.NewLocalStorage(c.LocalDir, c.PublicURL)
+	case "s3":
+		logger.Info("Using S3 file storage", "endpoint", c.S3Endpoint, "bucket", c.S3Bucket)
+
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:  c.S3Endpoint,
+			Region:    c.S3Region,
+			Bucket:    c.S3Bucket,
+			AccessKey: c.S3AccessKey,
+			SecretKey: c.S3SecretKey,
+			UseSSL:    c.S3UseSSL,
+			PublicURL: c.S3PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", c.Backend)
+	}
+}
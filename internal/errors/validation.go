@@ -94,7 +94,7 @@ func getErrorMessage(err validator.FieldError) string {
 
 	// Custom validators
 	case "question_type":
-		return "must be a valid question type (multiple_choice, true_false, essay, fill_blank, matching, ordering, short_answer)"
+		return "must be a valid question type (multiple_choice, true_false, essay, fill_blank, matching, ordering, short_answer, numeric, formula)"
 	case "difficulty_level":
 		return "must be Easy, Medium, or Hard"
 	case "user_role":
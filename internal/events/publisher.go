@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill-kafka/v2/pkg/kafka"
@@ -97,6 +98,7 @@ func (p *KafkaEventPublisher) Close() error {
 type MockEventPublisher struct {
 	Events []NotificationEvent
 	Logger *slog.Logger
+	mu     sync.Mutex
 }
 
 // NewMockEventPublisher creates a new mock event publisher
@@ -109,7 +111,9 @@ func NewMockEventPublisher(logger *slog.Logger) *MockEventPublisher {
 
 // PublishNotificationEvent stores the event in memory (for testing)
 func (m *MockEventPublisher) PublishNotificationEvent(ctx context.Context, event *NotificationEvent) error {
+	m.mu.Lock()
 	m.Events = append(m.Events, *event)
+	m.mu.Unlock()
 	m.Logger.Info("Mock: Published notification event",
 		"event_id", event.ID,
 		"event_type", event.Type)
@@ -123,10 +127,14 @@ func (m *MockEventPublisher) Close() error {
 
 // GetPublishedEvents returns all published events (for testing)
 func (m *MockEventPublisher) GetPublishedEvents() []NotificationEvent {
-	return m.Events
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]NotificationEvent(nil), m.Events...)
 }
 
 // ClearEvents clears all published events (for testing)
 func (m *MockEventPublisher) ClearEvents() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Events = make([]NotificationEvent, 0)
 }
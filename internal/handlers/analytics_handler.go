@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	BaseHandler
+	analyticsService services.AnalyticsService
+}
+
+func NewAnalyticsHandler(
+	analyticsService services.AnalyticsService,
+	logger utils.Logger,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		analyticsService: analyticsService,
+	}
+}
+
+// GetAssessmentAnalytics returns the materialized analytics of an assessment
+// @Summary Get assessment analytics
+// @Description Returns score, time and pass/fail statistics of an assessment together with the item analysis of its questions. Calculated on first access.
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentAnalyticsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/analytics [get]
+func (h *AnalyticsHandler) GetAssessmentAnalytics(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment analytics", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetAssessmentAnalytics(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// RecalculateAssessmentAnalytics recalculates the analytics of an assessment
+// @Summary Recalculate assessment analytics
+// @Description Recalculates the analytics of an assessment and all of its questions from the current attempts
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentAnalyticsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/analytics/recalculate [post]
+func (h *AnalyticsHandler) RecalculateAssessmentAnalytics(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Recalculating assessment analytics", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.RefreshAssessmentAnalytics(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetQuestionAnalytics returns the item analysis of a question
+// @Summary Get question analytics
+// @Description Returns difficulty, discrimination and option selection statistics of a question. Calculated on first access.
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {object} models.QuestionAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/analytics [get]
+func (h *AnalyticsHandler) GetQuestionAnalytics(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting question analytics", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetQuestionAnalytics(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// RecalculateQuestionAnalytics recalculates the item analysis of a question
+// @Summary Recalculate question analytics
+// @Description Recalculates the item analysis of a question from all graded attempts that contain it
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {object} models.QuestionAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/analytics/recalculate [post]
+func (h *AnalyticsHandler) RecalculateQuestionAnalytics(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Recalculating question analytics", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.RefreshQuestionAnalytics(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *AnalyticsHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *AnalyticsHandler) handleServiceError(c *gin.Context, err error) {
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -681,6 +681,122 @@ func (h *AssessmentHandler) GetCreatorStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// ListVersions lists the published versions of an assessment
+// @Summary List assessment versions
+// @Description Lists the immutable version snapshots of an assessment, newest first
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=[]models.AssessmentVersion}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/versions [get]
+func (h *AssessmentHandler) ListVersions(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing assessment versions", "assessment_id", id)
+
+	versions, err := h.assessmentService.ListVersions(c.Request.Context(), id, h.getUserID(c))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Assessment versions retrieved successfully",
+		Data:    versions,
+	})
+}
+
+// GetVersion retrieves a single version snapshot of an assessment
+// @Summary Get assessment version
+// @Description Retrieves an immutable version snapshot of an assessment
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} SuccessResponse{data=models.AssessmentVersion}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/versions/{version} [get]
+func (h *AssessmentHandler) GetVersion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	version := h.parseIDParam(c, "version")
+	if version == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment version", "assessment_id", id, "version", version)
+
+	assessmentVersion, err := h.assessmentService.GetVersion(c.Request.Context(), id, int(version), h.getUserID(c))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Assessment version retrieved successfully",
+		Data:    assessmentVersion,
+	})
+}
+
+// DiffVersions compares two version snapshots of an assessment
+// @Summary Diff assessment versions
+// @Description Lists the settings and questions that changed between two versions of an assessment
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Success 200 {object} SuccessResponse{data=services.AssessmentVersionDiff}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/versions/diff [get]
+func (h *AssessmentHandler) DiffVersions(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	fromVersion := h.parseIntQuery(c, "from", 0)
+	toVersion := h.parseIntQuery(c, "to", 0)
+	if fromVersion <= 0 || toVersion <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid version range",
+			Details: "from and to must be positive version numbers",
+		})
+		return
+	}
+
+	h.LogRequest(c, "Diffing assessment versions", "assessment_id", id, "from", fromVersion, "to", toVersion)
+
+	diff, err := h.assessmentService.DiffVersions(c.Request.Context(), id, fromVersion, toVersion, h.getUserID(c))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Assessment versions compared successfully",
+		Data:    diff,
+	})
+}
+
 // Helper methods
 
 func (h *AssessmentHandler) getUserID(c *gin.Context) string {
@@ -1091,6 +1207,10 @@ func (h *AssessmentHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusForbidden, ErrorResponse{
 			Message: "Access denied to assessment",
 		})
+	case errors.Is(err, services.ErrAssessmentVersionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment version not found",
+		})
 	case errors.Is(err, services.ErrAssessmentNotEditable):
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Message: "Assessment cannot be edited in current status",
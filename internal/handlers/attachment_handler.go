@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAttachmentRequestSize bounds the multipart request body; the service enforces the per-file limit
+const maxAttachmentRequestSize = 32 << 20 // 32 MB
+
+type AttachmentHandler struct {
+	BaseHandler
+	attachmentService services.AttachmentService
+}
+
+func NewAttachmentHandler(
+	attachmentService services.AttachmentService,
+	logger utils.Logger,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		BaseHandler:       NewBaseHandler(logger),
+		attachmentService: attachmentService,
+	}
+}
+
+// UploadAttachment uploads a file and attaches it to a question
+// @Summary Upload question attachment
+// @Description Uploads an image, PDF, audio or video file for a question. The type is detected from the file content; images get a thumbnail.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param file formData file true "Attachment file"
+// @Param alt formData string false "Alternative text for images"
+// @Param caption formData string false "Caption"
+// @Success 201 {object} models.QuestionAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Uploading question attachment", "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAttachmentRequestSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Message: "File too large",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: "file is required",
+		})
+		return
+	}
+
+	var req services.AttachmentMetadataRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.LogError(c, err, "Failed to open uploaded file")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.Request.Context(), questionID, file, fileHeader.Filename, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// ListAttachments lists the attachments of a question
+// @Summary List question attachments
+// @Tags attachments
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {array} models.QuestionAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing question attachments", "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachments, err := h.attachmentService.List(c.Request.Context(), questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// UpdateAttachment updates the alt text or caption of an attachment
+// @Summary Update question attachment
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param attachment_id path uint true "Attachment ID"
+// @Param attachment body services.AttachmentMetadataRequest true "Attachment metadata"
+// @Success 200 {object} models.QuestionAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments/{attachment_id} [put]
+func (h *AttachmentHandler) UpdateAttachment(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+	attachmentID := h.parseIDParam(c, "attachment_id")
+	if attachmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating question attachment", "question_id", questionID, "attachment_id", attachmentID)
+
+	var req services.AttachmentMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachment, err := h.attachmentService.Update(c.Request.Context(), questionID, attachmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+// DeleteAttachment removes an attachment and its files
+// @Summary Delete question attachment
+// @Tags attachments
+// @Param id path uint true "Question ID"
+// @Param attachment_id path uint true "Attachment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+	attachmentID := h.parseIDParam(c, "attachment_id")
+	if attachmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting question attachment", "question_id", questionID, "attachment_id", attachmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.attachmentService.Delete(c.Request.Context(), questionID, attachmentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderAttachments sets the display order of a question's attachments
+// @Summary Reorder question attachments
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param order body services.ReorderAttachmentsRequest true "Attachment order"
+// @Success 200 {array} models.QuestionAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments/order [put]
+func (h *AttachmentHandler) ReorderAttachments(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Reordering question attachments", "question_id", questionID)
+
+	var req services.ReorderAttachmentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachments, err := h.attachmentService.Reorder(c.Request.Context(), questionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *AttachmentHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *AttachmentHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Attachment not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Message: "File too large",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrAttachmentTypeNotAllowed):
+		c.JSON(http.StatusUnsupportedMediaType, ErrorResponse{
+			Message: "File type not allowed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrAttachmentLimitReached):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Attachment limit reached",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrValidationFailed):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -550,6 +550,305 @@ func (h *AttemptHandler) ExtendTime(c *gin.Context) {
 	})
 }
 
+// Heartbeat records that the student is still working on an attempt
+// @Summary Attempt heartbeat
+// @Description Reports the client device of an in-progress attempt and returns the remaining time. A changed device fingerprint or IP address flags the attempt for proctor review.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param heartbeat body services.AttemptHeartbeatRequest true "Client session info"
+// @Success 200 {object} SuccessResponse{data=services.AttemptHeartbeatResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/heartbeat [post]
+func (h *AttemptHandler) Heartbeat(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	var req services.AttemptHeartbeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	response, err := h.attemptService.Heartbeat(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Heartbeat recorded",
+		Data:    response,
+	})
+}
+
+// FlagQuestion flags or unflags a question for review
+// @Summary Flag question
+// @Description Marks a question of an in-progress attempt for review, or clears the mark
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param flag body services.FlagQuestionRequest true "Flag data"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/flags [put]
+func (h *AttemptHandler) FlagQuestion(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	var req services.FlagQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Flagging question", "attempt_id", attemptID, "question_id", req.QuestionID, "flagged", req.Flagged)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	err := h.attemptService.FlagQuestion(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	message := "Question flagged for review"
+	if !req.Flagged {
+		message = "Question unflagged"
+	}
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+	})
+}
+
+// EnterReview switches an attempt to review mode
+// @Summary Enter review mode
+// @Description Switches an in-progress attempt to the pre-submit review screen and returns its summary
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptReviewSummary}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/review [post]
+func (h *AttemptHandler) EnterReview(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Entering review mode", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	summary, err := h.attemptService.EnterReview(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Review mode entered",
+		Data:    summary,
+	})
+}
+
+// GetReviewSummary returns the pre-submit summary of an attempt
+// @Summary Get review summary
+// @Description Lists answered, unanswered and flagged questions of an in-progress attempt in display order
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptReviewSummary}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/review [get]
+func (h *AttemptHandler) GetReviewSummary(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting review summary", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	summary, err := h.attemptService.GetReviewSummary(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Review summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
+// NavigateToQuestion moves an attempt to a question
+// @Summary Navigate to question
+// @Description Sets the current question of an in-progress attempt, leaving review mode
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param navigation body services.NavigateAttemptRequest true "Question index"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/navigate [put]
+func (h *AttemptHandler) NavigateToQuestion(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	var req services.NavigateAttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Navigating attempt", "attempt_id", attemptID, "question_index", req.QuestionIndex)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	err := h.attemptService.NavigateToQuestion(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Current question updated",
+	})
+}
+
+// GetAnswerHistory returns the change log of an answer
+// @Summary Get answer history
+// @Description Lists every change of a student's answer with its time offset and client info (teachers and admins)
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param answer_id path uint true "Answer ID"
+// @Success 200 {object} SuccessResponse{data=[]repositories.AnswerHistoryEntry}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/answers/{answer_id}/history [get]
+func (h *AttemptHandler) GetAnswerHistory(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	answerID := h.parseIDParam(c, "answer_id")
+	if answerID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer history", "attempt_id", id, "answer_id", answerID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	history, err := h.attemptService.GetAnswerHistory(c.Request.Context(), id, answerID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Answer history retrieved successfully",
+		Data:    history,
+	})
+}
+
 // HandleTimeout handles attempt timeout
 // @Summary Handle attempt timeout
 // @Description Handles timeout for an attempt (system endpoint)
@@ -783,6 +1082,10 @@ func (h *AttemptHandler) parseAttemptFilters(c *gin.Context) repositories.Attemp
 		filters.UserID = &studentIDStr
 	}
 
+	if flagged, err := strconv.ParseBool(c.Query("session_flagged")); err == nil {
+		filters.SessionFlagged = &flagged
+	}
+
 	return filters
 }
 
@@ -856,6 +1159,14 @@ func (h *AttemptHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Message: "Cannot start new attempt",
 		})
+	case errors.Is(err, services.ErrAnswerNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Answer not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found in attempt",
+		})
 	// Assessment related errors
 	case errors.Is(err, services.ErrAssessmentNotFound):
 		c.JSON(http.StatusNotFound, ErrorResponse{
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	BaseHandler
+	auditService services.AuditService
+}
+
+func NewAuditHandler(
+	auditService services.AuditService,
+	logger utils.Logger,
+) *AuditHandler {
+	return &AuditHandler{
+		BaseHandler:  NewBaseHandler(logger),
+		auditService: auditService,
+	}
+}
+
+// ListAuditLogs returns audit log entries
+// @Summary List audit logs
+// @Description Returns audit log entries filtered by actor, target, event type and date range. Admin only.
+// @Tags admin
+// @Produce json
+// @Param user_id query string false "Actor user ID"
+// @Param target_type query string false "Target type (assessment, question, answer, ...)"
+// @Param target_id query uint false "Target ID"
+// @Param event_type query string false "Audit event type"
+// @Param date_from query string false "Start of date range (RFC3339)"
+// @Param date_to query string false "End of date range (RFC3339)"
+// @Param sort_order query string false "Sort order by creation time (asc, desc)" default(desc)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.AuditLogListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	h.LogRequest(c, "Listing audit logs")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	filters, err := h.parseAuditLogFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.auditService.List(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAuditLog returns a single audit log entry
+// @Summary Get audit log
+// @Description Returns a single audit log entry including its before/after changes. Admin only.
+// @Tags admin
+// @Produce json
+// @Param id path uint true "Audit log ID"
+// @Success 200 {object} models.AuditLog
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/audit-logs/{id} [get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting audit log", "audit_log_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	log, err := h.auditService.GetByID(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *AuditHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *AuditHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parseAuditLogFilters rejects malformed dates and IDs instead of silently widening the query
+func (h *AuditHandler) parseAuditLogFilters(c *gin.Context) (repositories.AuditLogFilters, error) {
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	filters := repositories.AuditLogFilters{
+		Limit:     size,
+		Offset:    (page - 1) * size,
+		SortOrder: c.DefaultQuery("sort_order", "desc"),
+	}
+
+	if userID := c.Query("user_id"); userID != "" {
+		filters.UserID = &userID
+	}
+
+	if targetType := c.Query("target_type"); targetType != "" {
+		filters.TargetType = &targetType
+	}
+
+	if targetIDStr := c.Query("target_id"); targetIDStr != "" {
+		targetID, err := strconv.ParseUint(targetIDStr, 10, 32)
+		if err != nil {
+			return filters, errors.New("invalid target_id")
+		}
+		id := uint(targetID)
+		filters.TargetID = &id
+	}
+
+	if eventType := c.Query("event_type"); eventType != "" {
+		t := models.AuditEventType(eventType)
+		filters.EventType = &t
+	}
+
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		t, err := time.Parse(time.RFC3339, dateFrom)
+		if err != nil {
+			return filters, errors.New("invalid date_from, expected RFC3339")
+		}
+		filters.DateFrom = &t
+	}
+
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		t, err := time.Parse(time.RFC3339, dateTo)
+		if err != nil {
+			return filters, errors.New("invalid date_to, expected RFC3339")
+		}
+		filters.DateTo = &t
+	}
+
+	return filters, nil
+}
+
+func (h *AuditHandler) handleServiceError(c *gin.Context, err error) {
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAuditLogNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Audit log not found",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	BaseHandler
+	categoryService services.CategoryService
+}
+
+func NewCategoryHandler(
+	categoryService services.CategoryService,
+	logger utils.Logger,
+) *CategoryHandler {
+	return &CategoryHandler{
+		BaseHandler:     NewBaseHandler(logger),
+		categoryService: categoryService,
+	}
+}
+
+// CreateCategory creates a question category
+// @Summary Create category
+// @Description Creates a question category, optionally nested under one of the user's categories
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param category body services.CreateCategoryRequest true "Category"
+// @Success 201 {object} models.QuestionCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	h.LogRequest(c, "Creating category")
+
+	var req services.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	category, err := h.categoryService.Create(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// GetCategoryTree lists the user's categories as a tree
+// @Summary Get category tree
+// @Description Returns the user's categories as a tree with direct and subtree question counts
+// @Tags categories
+// @Produce json
+// @Success 200 {array} services.CategoryTreeNode
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories [get]
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	h.LogRequest(c, "Getting category tree")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	tree, err := h.categoryService.GetTree(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetCategory returns a category with its direct children
+// @Summary Get category
+// @Tags categories
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Success 200 {object} models.QuestionCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting category", "category_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	category, err := h.categoryService.GetByID(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// UpdateCategory renames a category or changes its description, color or icon
+// @Summary Update category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Param category body services.UpdateCategoryRequest true "Category changes"
+// @Success 200 {object} models.QuestionCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating category", "category_id", id)
+
+	var req services.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	category, err := h.categoryService.Update(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// MoveCategory moves a category and its subtree under another parent
+// @Summary Move category
+// @Description Moves a category with all of its subcategories under a new parent, or to the root when parent_id is null
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Param move body services.MoveCategoryRequest true "New parent"
+// @Success 200 {object} models.QuestionCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id}/move [put]
+func (h *CategoryHandler) MoveCategory(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Moving category", "category_id", id)
+
+	var req services.MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	category, err := h.categoryService.Move(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory deletes a category without subcategories
+// @Summary Delete category
+// @Description Deletes a category. Categories with questions can only be deleted when reassign_to names a category to move the questions to.
+// @Tags categories
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Param reassign_to query uint false "Category that receives the deleted category's questions"
+// @Success 200 {object} services.DeleteCategoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	var reassignTo *uint
+	if reassignStr := c.Query("reassign_to"); reassignStr != "" {
+		target, err := strconv.ParseUint(reassignStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid reassign_to",
+				Details: err.Error(),
+			})
+			return
+		}
+		targetID := uint(target)
+		reassignTo = &targetID
+	}
+
+	h.LogRequest(c, "Deleting category", "category_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.categoryService.Delete(c.Request.Context(), id, reassignTo, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCategoryPath returns the ancestors of a category
+// @Summary Get category path
+// @Description Returns the categories from the root down to the given category
+// @Tags categories
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Success 200 {array} models.QuestionCategory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id}/path [get]
+func (h *CategoryHandler) GetCategoryPath(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting category path", "category_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	path, err := h.categoryService.GetPath(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, path)
+}
+
+// GetCategoryStats returns question statistics of a category subtree
+// @Summary Get category statistics
+// @Tags categories
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Success 200 {object} repositories.CategoryStats
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/{id}/stats [get]
+func (h *CategoryHandler) GetCategoryStats(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting category stats", "category_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	stats, err := h.categoryService.GetStats(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *CategoryHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *CategoryHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Category not found",
+		})
+	case errors.Is(err, services.ErrCategoryDuplicateName):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Category name already exists",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrCategoryHasQuestions),
+		errors.Is(err, services.ErrCategoryHasChildren):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Category cannot be deleted",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrCategoryInvalidParent):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid parent category",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrValidationFailed):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,546 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ClassHandler struct {
+	BaseHandler
+	classService services.ClassService
+}
+
+func NewClassHandler(
+	classService services.ClassService,
+	logger utils.Logger,
+) *ClassHandler {
+	return &ClassHandler{
+		BaseHandler:  NewBaseHandler(logger),
+		classService: classService,
+	}
+}
+
+// CreateClass creates a class with an optional initial roster
+// @Summary Create class
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param class body services.CreateClassRequest true "Class data"
+// @Success 201 {object} models.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes [post]
+func (h *ClassHandler) CreateClass(c *gin.Context) {
+	h.LogRequest(c, "Creating class")
+
+	var req services.CreateClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	class, err := h.classService.Create(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, class)
+}
+
+// ListClasses lists the classes visible to the current user
+// @Summary List classes
+// @Description Teachers see their own classes, admins see every class and students see the active classes they belong to
+// @Tags classes
+// @Produce json
+// @Param q query string false "Search by name or code"
+// @Param active query bool false "Filter by active status"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.ClassListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes [get]
+func (h *ClassHandler) ListClasses(c *gin.Context) {
+	h.LogRequest(c, "Listing classes")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	filters := repositories.ClassFilters{
+		Query:  c.Query("q"),
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+	if active := c.Query("active"); active != "" {
+		isActive := active == "true"
+		filters.IsActive = &isActive
+	}
+
+	result, err := h.classService.List(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetClass returns a class with its roster
+// @Summary Get class
+// @Tags classes
+// @Produce json
+// @Param id path uint true "Class ID"
+// @Success 200 {object} models.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes/{id} [get]
+func (h *ClassHandler) GetClass(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting class", "class_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	class, err := h.classService.GetByID(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, class)
+}
+
+// UpdateClass updates a class
+// @Summary Update class
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Class ID"
+// @Param class body services.UpdateClassRequest true "Class data"
+// @Success 200 {object} models.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes/{id} [put]
+func (h *ClassHandler) UpdateClass(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating class", "class_id", id)
+
+	var req services.UpdateClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	class, err := h.classService.Update(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, class)
+}
+
+// DeleteClass deletes a class with its roster and assignments
+// @Summary Delete class
+// @Tags classes
+// @Param id path uint true "Class ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes/{id} [delete]
+func (h *ClassHandler) DeleteClass(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting class", "class_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.classService.Delete(c.Request.Context(), id, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddClassMembers enrolls students in a class
+// @Summary Add class members
+// @Description Enrolls the listed students. IDs of users who are not students are skipped and returned as rejected.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Class ID"
+// @Param members body services.ClassMembersRequest true "Student IDs"
+// @Success 200 {object} services.AddClassMembersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes/{id}/members [post]
+func (h *ClassHandler) AddClassMembers(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Adding class members", "class_id", id)
+
+	var req services.ClassMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.classService.AddMembers(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveClassMember removes a student from a class
+// @Summary Remove class member
+// @Tags classes
+// @Param id path uint true "Class ID"
+// @Param student_id path string true "Student ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /classes/{id}/members/{student_id} [delete]
+func (h *ClassHandler) RemoveClassMember(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	studentID := strings.TrimSpace(c.Param("student_id"))
+
+	h.LogRequest(c, "Removing class member", "class_id", id, "student_id", studentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.classService.RemoveMember(c.Request.Context(), id, studentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AssignAssessment assigns an assessment to classes or individual students
+// @Summary Assign assessment
+// @Description Only students assigned directly or through an active class can see and take the assessment
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param assignment body services.AssignAssessmentRequest true "Classes and students"
+// @Success 201 {array} models.AssessmentAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/assignments [post]
+func (h *ClassHandler) AssignAssessment(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Assigning assessment", "assessment_id", assessmentID)
+
+	var req services.AssignAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	assignments, err := h.classService.AssignAssessment(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignments)
+}
+
+// GetAssessmentAssignments lists the classes and students an assessment is assigned to
+// @Summary List assessment assignments
+// @Tags classes
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {array} models.AssessmentAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/assignments [get]
+func (h *ClassHandler) GetAssessmentAssignments(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment assignments", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	assignments, err := h.classService.GetAssessmentAssignments(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// UnassignAssessment removes an assessment assignment
+// @Summary Remove assessment assignment
+// @Tags classes
+// @Param id path uint true "Assessment ID"
+// @Param assignment_id path uint true "Assignment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/assignments/{assignment_id} [delete]
+func (h *ClassHandler) UnassignAssessment(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+	assignmentID := h.parseIDParam(c, "assignment_id")
+	if assignmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Removing assessment assignment", "assessment_id", assessmentID, "assignment_id", assignmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.classService.UnassignAssessment(c.Request.Context(), assessmentID, assignmentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *ClassHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ClassHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *ClassHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrClassNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Class not found",
+		})
+	case errors.Is(err, services.ErrClassMemberNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Class member not found",
+		})
+	case errors.Is(err, services.ErrAssignmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assignment not found",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrValidationFailed):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
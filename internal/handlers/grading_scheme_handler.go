@@ -0,0 +1,410 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingSchemeHandler struct {
+	BaseHandler
+	gradingSchemeService services.GradingSchemeService
+}
+
+func NewGradingSchemeHandler(
+	gradingSchemeService services.GradingSchemeService,
+	logger utils.Logger,
+) *GradingSchemeHandler {
+	return &GradingSchemeHandler{
+		BaseHandler:          NewBaseHandler(logger),
+		gradingSchemeService: gradingSchemeService,
+	}
+}
+
+// ===== TEMPLATES =====
+
+// CreateTemplate creates a reusable grading scheme template
+// @Summary Create grading scheme template
+// @Description Creates an organization-level grading scheme that assessments can copy
+// @Tags grading-schemes
+// @Accept json
+// @Produce json
+// @Param scheme body services.GradingSchemeRequest true "Grading scheme"
+// @Success 201 {object} models.GradingScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading-schemes [post]
+func (h *GradingSchemeHandler) CreateTemplate(c *gin.Context) {
+	h.LogRequest(c, "Creating grading scheme template")
+
+	var req services.GradingSchemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	scheme, err := h.gradingSchemeService.CreateTemplate(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, scheme)
+}
+
+// ListTemplates lists grading scheme templates
+// @Summary List grading scheme templates
+// @Description Returns all organization-level grading scheme templates
+// @Tags grading-schemes
+// @Produce json
+// @Success 200 {array} models.GradingScheme
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading-schemes [get]
+func (h *GradingSchemeHandler) ListTemplates(c *gin.Context) {
+	h.LogRequest(c, "Listing grading scheme templates")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	schemes, err := h.gradingSchemeService.ListTemplates(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schemes)
+}
+
+// GetTemplate returns a grading scheme template
+// @Summary Get grading scheme template
+// @Tags grading-schemes
+// @Produce json
+// @Param id path uint true "Grading scheme ID"
+// @Success 200 {object} models.GradingScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading-schemes/{id} [get]
+func (h *GradingSchemeHandler) GetTemplate(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting grading scheme template", "scheme_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	scheme, err := h.gradingSchemeService.GetTemplate(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheme)
+}
+
+// UpdateTemplate updates a grading scheme template
+// @Summary Update grading scheme template
+// @Description Updates a template. Assessments that already copied it keep their own scheme.
+// @Tags grading-schemes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Grading scheme ID"
+// @Param scheme body services.GradingSchemeRequest true "Grading scheme"
+// @Success 200 {object} models.GradingScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading-schemes/{id} [put]
+func (h *GradingSchemeHandler) UpdateTemplate(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating grading scheme template", "scheme_id", id)
+
+	var req services.GradingSchemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	scheme, err := h.gradingSchemeService.UpdateTemplate(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheme)
+}
+
+// DeleteTemplate deletes a grading scheme template
+// @Summary Delete grading scheme template
+// @Tags grading-schemes
+// @Param id path uint true "Grading scheme ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading-schemes/{id} [delete]
+func (h *GradingSchemeHandler) DeleteTemplate(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting grading scheme template", "scheme_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.gradingSchemeService.DeleteTemplate(c.Request.Context(), id, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ===== ASSESSMENT SCHEMES =====
+
+// GetAssessmentScheme returns the grading scheme assigned to an assessment
+// @Summary Get assessment grading scheme
+// @Tags grading-schemes
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} models.GradingScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/grading-scheme [get]
+func (h *GradingSchemeHandler) GetAssessmentScheme(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment grading scheme", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	scheme, err := h.gradingSchemeService.GetAssessmentScheme(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheme)
+}
+
+// AssignAssessmentScheme assigns a grading scheme to an assessment
+// @Summary Assign assessment grading scheme
+// @Description Assigns a grading scheme by copying a template (template_id) or from an inline definition (scheme). Replaces any existing scheme.
+// @Tags grading-schemes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param request body services.AssignGradingSchemeRequest true "Template or inline scheme"
+// @Success 200 {object} models.GradingScheme
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/grading-scheme [put]
+func (h *GradingSchemeHandler) AssignAssessmentScheme(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Assigning assessment grading scheme", "assessment_id", assessmentID)
+
+	var req services.AssignGradingSchemeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	scheme, err := h.gradingSchemeService.AssignToAssessment(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheme)
+}
+
+// RemoveAssessmentScheme removes the grading scheme of an assessment
+// @Summary Remove assessment grading scheme
+// @Description Removes the assigned scheme; the assessment falls back to the default letter scale
+// @Tags grading-schemes
+// @Param id path uint true "Assessment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/grading-scheme [delete]
+func (h *GradingSchemeHandler) RemoveAssessmentScheme(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Removing assessment grading scheme", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.gradingSchemeService.RemoveFromAssessment(c.Request.Context(), assessmentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *GradingSchemeHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *GradingSchemeHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrGradingSchemeNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Grading scheme not found",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrValidationFailed):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize limits question import uploads
+const maxImportFileSize = 20 << 20 // 20 MB
+
+const (
+	contentTypeCSV  = "text/csv"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+type ImportExportHandler struct {
+	BaseHandler
+	importExportService services.ImportExportService
+	validator           *validator.Validator
+}
+
+func NewImportExportHandler(
+	importExportService services.ImportExportService,
+	validator *validator.Validator,
+	logger utils.Logger,
+) *ImportExportHandler {
+	return &ImportExportHandler{
+		BaseHandler:         NewBaseHandler(logger),
+		importExportService: importExportService,
+		validator:           validator,
+	}
+}
+
+// ===== IMPORT =====
+
+// ImportQuestions uploads a question file for import
+// @Summary Import questions
+// @Description Uploads a CSV or XLSX question file. The file is processed by a background import job; poll the job for progress. With dry_run=true the file is only validated and the row errors are returned without saving anything.
+// @Tags import-export
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Question file (.csv or .xlsx)"
+// @Param bank_id formData uint false "Question bank to add the imported questions to"
+// @Param dry_run query bool false "Validate only, do not save" default(false)
+// @Success 202 {object} models.ImportJob
+// @Success 200 {object} services.ImportResult "Dry run result"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/import [post]
+func (h *ImportExportHandler) ImportQuestions(c *gin.Context) {
+	h.LogRequest(c, "Importing questions")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: "file is required",
+		})
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Message: "File too large",
+			Details: fmt.Sprintf("maximum size is %d bytes", maxImportFileSize),
+		})
+		return
+	}
+
+	var bankID *uint
+	if bankIDStr := c.PostForm("bank_id"); bankIDStr != "" {
+		id, err := strconv.ParseUint(bankIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid bank_id",
+				Details: err.Error(),
+			})
+			return
+		}
+		value := uint(id)
+		bankID = &value
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.LogError(c, err, "Failed to open uploaded file")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	if c.Query("dry_run") == "true" {
+		result, err := h.importExportService.ValidateImportFile(c.Request.Context(), file, fileHeader.Filename, userID.(string))
+		if err != nil {
+			h.handleServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	job, err := h.importExportService.StartImportJob(c.Request.Context(), file, fileHeader.Filename, fileHeader.Size, bankID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListImportJobs lists the current user's import jobs
+// @Summary List import jobs
+// @Description Returns the import jobs started by the current user, newest first
+// @Tags import-export
+// @Produce json
+// @Param status query string false "Job status (pending, processing, completed, failed, validation_failed)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.ImportJobListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/import/jobs [get]
+func (h *ImportExportHandler) ListImportJobs(c *gin.Context) {
+	h.LogRequest(c, "Listing import jobs")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	filters := repositories.ImportJobFilters{
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+	if status := c.Query("status"); status != "" {
+		s := models.ImportJobStatus(status)
+		filters.Status = &s
+	}
+
+	result, err := h.importExportService.ListImportJobs(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetImportJob returns the status and progress of an import job
+// @Summary Get import job
+// @Description Returns the status, progress counters and row errors of an import job
+// @Tags import-export
+// @Produce json
+// @Param job_id path string true "Import job ID"
+// @Success 200 {object} models.ImportJob
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/import/jobs/{job_id} [get]
+func (h *ImportExportHandler) GetImportJob(c *gin.Context) {
+	jobID := c.Param("job_id")
+
+	h.LogRequest(c, "Getting import job", "job_id", jobID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.importExportService.GetImportJob(c.Request.Context(), jobID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ===== EXPORT =====
+
+// ExportQuestions downloads questions as a file
+// @Summary Export questions
+// @Description Exports the given questions as a CSV or XLSX file. Questions the user cannot access are skipped.
+// @Tags import-export
+// @Accept json
+// @Produce application/octet-stream
+// @Param request body services.ExportQuestionsRequest true "Questions to export"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/export [post]
+func (h *ImportExportHandler) ExportQuestions(c *gin.Context) {
+	h.LogRequest(c, "Exporting questions")
+
+	var req services.ExportQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var data []byte
+	var err error
+	contentType := contentTypeCSV
+	if req.Format == "xlsx" {
+		contentType = contentTypeXLSX
+		data, err = h.importExportService.ExportQuestionsToExcel(c.Request.Context(), req.QuestionIDs, userID.(string))
+	} else {
+		data, err = h.importExportService.ExportQuestionsToCSV(c.Request.Context(), req.QuestionIDs, userID.(string))
+	}
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("questions_%s.%s", time.Now().Format("20060102_150405"), req.Format)
+	h.sendFile(c, data, filename, contentType)
+}
+
+// ExportAssessmentResults downloads the results of an assessment
+// @Summary Export assessment results
+// @Description Exports all attempts of an assessment with scores and pass/fail status as an XLSX file
+// @Tags import-export
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path uint true "Assessment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/results/export [get]
+func (h *ImportExportHandler) ExportAssessmentResults(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Exporting assessment results", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	data, err := h.importExportService.ExportAssessmentResults(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("assessment_%d_results_%s.xlsx", id, time.Now().Format("20060102_150405"))
+	h.sendFile(c, data, filename, contentTypeXLSX)
+}
+
+// ===== HELPER METHODS =====
+
+// sendFile streams data as a download attachment
+func (h *ImportExportHandler) sendFile(c *gin.Context, data []byte, filename, contentType string) {
+	c.DataFromReader(http.StatusOK, int64(len(data)), contentType, bytes.NewReader(data), map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": filename}),
+	})
+}
+
+func (h *ImportExportHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ImportExportHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *ImportExportHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrImportJobNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Import job not found",
+		})
+	case errors.Is(err, services.ErrImportJobFinished):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Import job already finished",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// importService records whether an upload was validated or started as a job
+type importService struct {
+	services.ImportExportService
+	validated, started bool
+}
+
+func (s *importService) ValidateImportFile(ctx context.Context, reader io.Reader, filename string, creatorID string) (*services.ImportResult, error) {
+	s.validated = true
+	return &services.ImportResult{Status: models.ImportValidationFailed}, nil
+}
+
+func (s *importService) StartImportJob(ctx context.Context, file io.Reader, filename string, size int64, bankID *uint, userID string) (*models.ImportJob, error) {
+	s.started = true
+	return &models.ImportJob{ID: "job", Status: models.ImportPending}, nil
+}
+
+func newImportRequest(t *testing.T, query string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "questions.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(part, "question_type,question_text,correct_answer\n")
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/questions/import"+query, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestImportQuestions_DryRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantStatus    int
+		wantValidated bool
+		wantStarted   bool
+	}{
+		{"dry run only validates", "?dry_run=true", http.StatusOK, true, false},
+		{"import starts a job", "", http.StatusAccepted, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &importService{}
+			logger := utils.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			handler := NewImportExportHandler(service, nil, logger)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/questions/import", func(c *gin.Context) {
+				c.Set("user_id", "teacher")
+				handler.ImportQuestions(c)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newImportRequest(t, tt.query))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if service.validated != tt.wantValidated || service.started != tt.wantStarted {
+				t.Errorf("validated = %v, started = %v, want %v and %v", service.validated, service.started, tt.wantValidated, tt.wantStarted)
+			}
+		})
+	}
+}
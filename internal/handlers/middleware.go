@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
 	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"github.com/gin-gonic/gin"
 	uuid2 "github.com/google/uuid"
@@ -68,14 +73,50 @@ func SecurityMiddleware() gin.HandlerFunc {
 	}
 }
 
-// RateLimitMiddleware provides rate limiting (placeholder)
-func RateLimitMiddleware() gin.HandlerFunc {
+// RateLimitMiddleware limits requests with a token bucket named after the rule, one bucket per
+// authenticated user, or per client IP when it runs before authentication. Rejected requests get
+// 429 with Retry-After; every response carries the X-RateLimit-* headers.
+func RateLimitMiddleware(limiter *cache.RateLimiter, name string, rule services.RateLimit, logger utils.Logger) gin.HandlerFunc {
+	if limiter == nil || rule.RequestsPerMinute <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
 	return func(c *gin.Context) {
-		// Implement rate limiting logic here
+		subject := "ip:" + c.ClientIP()
+		if userID, err := GetUserIDFromContext(c); err == nil && userID != "" {
+			subject = "user:" + userID
+		}
+
+		result, err := limiter.Allow(c.Request.Context(), name+":"+subject, rule.RequestsPerMinute, rule.BurstSize)
+		if err != nil {
+			// The in-memory bucket still decided, limits are per replica until Redis is back
+			logger.WarnContext(c.Request.Context(), "Rate limiter fell back to local buckets", "rule", name, "error", err)
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+				Message: "Too many requests, please retry later",
+				Code:    "RATE_LIMITED",
+			})
+			return
+		}
+
 		c.Next()
 	}
 }
 
+// ceilSeconds rounds a duration up to whole seconds, as used by Retry-After
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
 // RequestIDMiddleware generates a unique request ID for each request
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -86,6 +127,13 @@ func RequestIDMiddleware() gin.HandlerFunc {
 		}
 		c.Header("X-Request-ID", requestID)
 		c.Set("request_id", requestID)
+
+		// Expose request metadata to services through the request context
+		c.Request = c.Request.WithContext(utils.WithRequestInfo(c.Request.Context(), utils.RequestInfo{
+			RequestID: requestID,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}))
 		c.Next()
 	}
 }
@@ -96,7 +144,7 @@ func CORSMiddleware() gin.HandlerFunc {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
-		c.Header("Access-Control-Expose-Headers", "Content-Length")
+		c.Header("Access-Control-Expose-Headers", "Content-Length, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset")
 		c.Header("Access-Control-Allow-Credentials", "true")
 		c.Header("Access-Control-Max-Age", "43200")
 
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedRouter limits requests per X-Test-User header, or per IP without it
+func newRateLimitedRouter(rule services.RateLimit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set("user_id", user)
+		}
+		c.Next()
+	})
+	logger := utils.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	router.Use(RateLimitMiddleware(cache.NewRateLimiter(nil), "test", rule, logger))
+	router.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	// One token every two seconds, burst of 2
+	router := newRateLimitedRouter(services.RateLimit{RequestsPerMinute: 30, BurstSize: 2})
+
+	tests := []struct {
+		name       string
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{"first request", http.StatusOK, map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "1", "X-RateLimit-Reset": "2", "Retry-After": ""}},
+		{"second request", http.StatusOK, map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "4", "Retry-After": ""}},
+		{"over the burst", http.StatusTooManyRequests, map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "4", "Retry-After": "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			for header, want := range tt.wantHeader {
+				if got := w.Header().Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+
+			if tt.wantStatus == http.StatusTooManyRequests {
+				var body ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+					t.Fatalf("invalid error body: %v", err)
+				}
+				if body.Code != "RATE_LIMITED" {
+					t.Errorf("error code = %q, want RATE_LIMITED", body.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_PerUser(t *testing.T) {
+	router := newRateLimitedRouter(services.RateLimit{RequestsPerMinute: 30, BurstSize: 1})
+
+	request := func(user string) int {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Test-User", user)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := request("alice"); code != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusOK)
+	}
+	if code := request("alice"); code != http.StatusTooManyRequests {
+		t.Errorf("second request status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+	// Each user has their own bucket
+	if code := request("bob"); code != http.StatusOK {
+		t.Errorf("other user status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	router := newRateLimitedRouter(services.RateLimit{})
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+		if got := w.Header().Get("X-RateLimit-Limit"); got != "" {
+			t.Errorf("X-RateLimit-Limit = %q, want none", got)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	BaseHandler
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(
+	notificationService services.NotificationService,
+	logger utils.Logger,
+) *NotificationHandler {
+	return &NotificationHandler{
+		BaseHandler:         NewBaseHandler(logger),
+		notificationService: notificationService,
+	}
+}
+
+// ListNotifications returns the current user's in-app notifications
+// @Summary List notifications
+// @Description Returns notifications addressed to the current user and broadcasts for their role, newest first
+// @Tags notifications
+// @Produce json
+// @Param status query string false "Read status (read, unread)"
+// @Param type query string false "Notification type"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.NotificationListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	h.LogRequest(c, "Listing notifications")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	filters, err := h.parseNotificationFilters(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.notificationService.List(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetUnreadCount returns the number of unread notifications
+// @Summary Get unread notification count
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} services.UnreadCountResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	h.LogRequest(c, "Getting unread notification count")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.notificationService.GetUnreadCount(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark notification read
+// @Tags notifications
+// @Produce json
+// @Param id path uint true "Notification ID"
+// @Success 200 {object} models.Notification
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/{id}/read [put]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Marking notification read", "notification_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	notification, err := h.notificationService.MarkRead(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// MarkAllRead marks every notification in the inbox as read
+// @Summary Mark all notifications read
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} services.MarkAllReadResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/read-all [put]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	h.LogRequest(c, "Marking all notifications read")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.notificationService.MarkAllRead(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteNotification removes a notification from the inbox
+// @Summary Delete notification
+// @Description Deletes a notification addressed to the current user. Role broadcasts are hidden for the current user only.
+// @Tags notifications
+// @Param id path uint true "Notification ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/{id} [delete]
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting notification", "notification_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.notificationService.Delete(c.Request.Context(), id, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *NotificationHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *NotificationHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *NotificationHandler) parseNotificationFilters(c *gin.Context) (repositories.NotificationFilters, error) {
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	filters := repositories.NotificationFilters{
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+
+	switch c.Query("status") {
+	case "":
+	case "read":
+		read := true
+		filters.Read = &read
+	case "unread":
+		read := false
+		filters.Read = &read
+	default:
+		return filters, errors.New("invalid status, expected read or unread")
+	}
+
+	if notificationType := c.Query("type"); notificationType != "" {
+		t := models.NotificationType(notificationType)
+		filters.Type = &t
+	}
+
+	return filters, nil
+}
+
+func (h *NotificationHandler) handleServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotificationNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Notification not found",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
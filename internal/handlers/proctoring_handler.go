@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type ProctoringHandler struct {
+	BaseHandler
+	proctoringService services.ProctoringService
+	validator         *validator.Validator
+}
+
+func NewProctoringHandler(
+	proctoringService services.ProctoringService,
+	validator *validator.Validator,
+	logger utils.Logger,
+) *ProctoringHandler {
+	return &ProctoringHandler{
+		BaseHandler:       NewBaseHandler(logger),
+		proctoringService: proctoringService,
+		validator:         validator,
+	}
+}
+
+// RecordEvents records a batch of proctoring events for an attempt
+// @Summary Record proctoring events
+// @Description Records a batch of proctoring signals (tab switches, fullscreen exits, ...) emitted by the client during an attempt. Events for checks disabled in the assessment settings are ignored.
+// @Tags proctoring
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param events body services.RecordProctoringEventsRequest true "Proctoring events"
+// @Success 201 {object} services.RecordProctoringEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/proctoring-events [post]
+func (h *ProctoringHandler) RecordEvents(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Recording proctoring events", "attempt_id", attemptID)
+
+	var req services.RecordProctoringEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	result, err := h.proctoringService.RecordEvents(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetAttemptEvents lists proctoring events of an attempt
+// @Summary Get attempt proctoring events
+// @Description Lists proctoring events recorded for an attempt with optional type, severity and review status filters
+// @Tags proctoring
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param type query string false "Event type"
+// @Param severity query int false "Exact severity (1-5)"
+// @Param min_severity query int false "Minimum severity (1-5)"
+// @Param review_status query string false "Review status (pending, reviewed, dismissed)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.ProctoringEventListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/proctoring-events [get]
+func (h *ProctoringHandler) GetAttemptEvents(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt proctoring events", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	filters := h.parseProctoringEventFilters(c)
+	result, err := h.proctoringService.GetAttemptEvents(c.Request.Context(), attemptID, filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAttemptSummary returns proctoring event counts for an attempt
+// @Summary Get attempt proctoring summary
+// @Description Returns proctoring event counts by type and severity, and the number of events pending review
+// @Tags proctoring
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} repositories.ProctoringSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/proctoring-summary [get]
+func (h *ProctoringHandler) GetAttemptSummary(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt proctoring summary", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	summary, err := h.proctoringService.GetAttemptSummary(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetAssessmentEvents lists proctoring events across all attempts of an assessment
+// @Summary Get assessment proctoring events
+// @Description Lists proctoring events of every attempt of an assessment with optional type, severity and review status filters
+// @Tags proctoring
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param type query string false "Event type"
+// @Param severity query int false "Exact severity (1-5)"
+// @Param min_severity query int false "Minimum severity (1-5)"
+// @Param review_status query string false "Review status (pending, reviewed, dismissed)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.ProctoringEventListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /proctoring/assessments/{assessment_id}/events [get]
+func (h *ProctoringHandler) GetAssessmentEvents(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment proctoring events", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	filters := h.parseProctoringEventFilters(c)
+	result, err := h.proctoringService.GetAssessmentEvents(c.Request.Context(), assessmentID, filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReviewEvent sets the review status and notes of a proctoring event
+// @Summary Review proctoring event
+// @Description Marks a proctoring event as reviewed or dismissed and stores reviewer notes
+// @Tags proctoring
+// @Accept json
+// @Produce json
+// @Param event_id path uint true "Proctoring event ID"
+// @Param review body services.ReviewProctoringEventRequest true "Review data"
+// @Success 200 {object} models.ProctoringEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /proctoring/events/{event_id}/review [put]
+func (h *ProctoringHandler) ReviewEvent(c *gin.Context) {
+	eventID := h.parseIDParam(c, "event_id")
+	if eventID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Reviewing proctoring event", "event_id", eventID)
+
+	var req services.ReviewProctoringEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	event, err := h.proctoringService.ReviewEvent(c.Request.Context(), eventID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// ===== HELPER METHODS =====
+
+func (h *ProctoringHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ProctoringHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *ProctoringHandler) parseProctoringEventFilters(c *gin.Context) repositories.ProctoringEventFilters {
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+
+	filters := repositories.ProctoringEventFilters{
+		Limit:     size,
+		Offset:    (page - 1) * size,
+		SortBy:    c.DefaultQuery("sort_by", "created_at"),
+		SortOrder: c.DefaultQuery("sort_order", "desc"),
+	}
+
+	if eventType := c.Query("type"); eventType != "" {
+		t := models.ProctoringEventType(eventType)
+		filters.Type = &t
+	}
+
+	if severityStr := c.Query("severity"); severityStr != "" {
+		if severity, err := strconv.Atoi(severityStr); err == nil {
+			filters.Severity = &severity
+		}
+	}
+
+	if minSeverityStr := c.Query("min_severity"); minSeverityStr != "" {
+		if minSeverity, err := strconv.Atoi(minSeverityStr); err == nil {
+			filters.MinSeverity = &minSeverity
+		}
+	}
+
+	if reviewStatus := c.Query("review_status"); reviewStatus != "" {
+		filters.ReviewStatus = &reviewStatus
+	}
+
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		if t, err := time.Parse(time.RFC3339, dateFrom); err == nil {
+			filters.DateFrom = &t
+		}
+	}
+
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		if t, err := time.Parse(time.RFC3339, dateTo); err == nil {
+			filters.DateTo = &t
+		}
+	}
+
+	return filters
+}
+
+func (h *ProctoringHandler) handleServiceError(c *gin.Context, err error) {
+	// Handle custom error types first
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrProctoringEventNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Proctoring event not found",
+		})
+	case errors.Is(err, services.ErrAttemptNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Attempt not found",
+		})
+	case errors.Is(err, services.ErrAttemptNotActive):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Attempt is not active",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrValidationFailed):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
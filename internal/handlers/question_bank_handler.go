@@ -702,6 +702,7 @@ func (h *QuestionBankHandler) RemoveQuestionsFromBank(c *gin.Context) {
 // @Param type query string false "Filter by question type"
 // @Param difficulty query string false "Filter by difficulty level"
 // @Param category_id query int false "Filter by category ID"
+// @Param include_subcategories query bool false "Include questions of subcategories of the category"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 10, max: 100)"
 // @Param sort_by query string false "Sort field (created_at, text) (default: created_at)"
@@ -1087,6 +1088,7 @@ func (h *QuestionBankHandler) parseQuestionFilters(c *gin.Context) repositories.
 			filters.CategoryID = &[]uint{uint(id)}[0]
 		}
 	}
+	filters.IncludeSubcategories = c.Query("include_subcategories") == "true"
 
 	// Parse sorting
 	if sortBy := c.Query("sort_by"); sortBy != "" {
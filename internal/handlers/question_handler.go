@@ -279,6 +279,8 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 // @Param type query string false "Question type"
 // @Param difficulty query string false "Difficulty level"
 // @Param creator_id query uint false "Creator ID"
+// @Param category_id query uint false "Category ID"
+// @Param include_subcategories query bool false "Include questions of subcategories"
 // @Success 200 {object} SuccessResponse{data=services.QuestionListResponse}
 // @Failure 500 {object} ErrorResponse
 // @Router /questions [get]
@@ -385,6 +387,8 @@ func (h *QuestionHandler) SearchQuestions(c *gin.Context) {
 // @Param count query int false "Number of questions" default(10)
 // @Param type query string false "Question type"
 // @Param difficulty query string false "Difficulty level"
+// @Param category_id query uint false "Category ID"
+// @Param include_subcategories query bool false "Include questions of subcategories"
 // @Success 200 {object} SuccessResponse{data=[]models.Question}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -693,6 +697,56 @@ func (h *QuestionHandler) GetQuestionStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// PreviewQuestionVariants renders variants of a templated question
+// @Summary Preview question variants
+// @Description Renders variants of a templated question with their drawn variable values, so teachers can check them before publishing
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param count query int false "Number of variants (default: 5, max: 20)"
+// @Success 200 {object} SuccessResponse{data=[]services.QuestionVariant}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/variants [get]
+func (h *QuestionHandler) PreviewQuestionVariants(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Previewing question variants", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil || count < 1 {
+		count = 5
+	}
+	if count > 20 {
+		count = 20
+	}
+
+	variants, err := h.questionService.PreviewVariants(c.Request.Context(), id, count, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Question variants generated successfully",
+		Data:    variants,
+	})
+}
+
 // GetQuestionUsageStats retrieves question usage statistics
 // @Summary Get question usage statistics
 // @Description Retrieves usage statistics for questions by creator
@@ -788,6 +842,7 @@ func (h *QuestionHandler) parseQuestionFilters(c *gin.Context) repositories.Ques
 			filters.CategoryID = &id
 		}
 	}
+	filters.IncludeSubcategories = c.Query("include_subcategories") == "true"
 
 	return filters
 }
@@ -854,6 +909,10 @@ func (h *QuestionHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Message: "Question order already exists in assessment",
 		})
+	case errors.Is(err, services.ErrQuestionNotTemplated):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Question is not templated",
+		})
 	// Question Bank related errors
 	case errors.Is(err, services.ErrQuestionBankNotFound):
 		c.JSON(http.StatusNotFound, ErrorResponse{
@@ -936,6 +995,7 @@ func (h *QuestionHandler) parseRandomQuestionFilters(c *gin.Context) repositorie
 			filters.CategoryID = &id
 		}
 	}
+	filters.IncludeSubcategories = c.Query("include_subcategories") == "true"
 
 	return filters
 }
@@ -12,15 +12,26 @@ import (
 )
 
 type HandlerManager struct {
-	assessmentHandler   *AssessmentHandler
-	questionHandler     *QuestionHandler
-	questionBankHandler *QuestionBankHandler
-	attemptHandler      *AttemptHandler
-	gradingHandler      *GradingHandler
-	dashboardHandler    *DashboardHandler
-	studentHandler      *StudentHandler
-	userHandler         *UserHandler
-	authMiddleware      *CasdoorAuthMiddleware
+	assessmentHandler    *AssessmentHandler
+	questionHandler      *QuestionHandler
+	questionBankHandler  *QuestionBankHandler
+	attemptHandler       *AttemptHandler
+	gradingHandler       *GradingHandler
+	dashboardHandler     *DashboardHandler
+	studentHandler       *StudentHandler
+	proctoringHandler    *ProctoringHandler
+	auditHandler         *AuditHandler
+	gradingSchemeHandler *GradingSchemeHandler
+	analyticsHandler     *AnalyticsHandler
+	categoryHandler      *CategoryHandler
+	attachmentHandler    *AttachmentHandler
+	importExportHandler  *ImportExportHandler
+	notificationHandler  *NotificationHandler
+	classHandler         *ClassHandler
+	userHandler          *UserHandler
+	authMiddleware       *CasdoorAuthMiddleware
+	serviceManager       services.ServiceManager
+	logger               utils.Logger
 }
 
 func NewHandlerManager(
@@ -33,18 +44,36 @@ func NewHandlerManager(
 	authMiddleware := NewCasdoorAuthMiddleware(casdoorConfig, userRepo)
 
 	return &HandlerManager{
-		assessmentHandler:   NewAssessmentHandler(serviceManager.Assessment(), validator, logger),
-		questionHandler:     NewQuestionHandler(serviceManager.Question(), validator, logger),
-		questionBankHandler: NewQuestionBankHandler(serviceManager.QuestionBank(), logger),
-		attemptHandler:      NewAttemptHandler(serviceManager.Attempt(), validator, logger),
-		gradingHandler:      NewGradingHandler(serviceManager.Grading(), validator, logger),
-		dashboardHandler:    NewDashboardHandler(serviceManager.Dashboard(), logger),
-		studentHandler:      NewStudentHandler(serviceManager.Student(), logger),
-		userHandler:         NewUserHandler(userRepo, logger),
-		authMiddleware:      authMiddleware,
+		assessmentHandler:    NewAssessmentHandler(serviceManager.Assessment(), validator, logger),
+		questionHandler:      NewQuestionHandler(serviceManager.Question(), validator, logger),
+		questionBankHandler:  NewQuestionBankHandler(serviceManager.QuestionBank(), logger),
+		attemptHandler:       NewAttemptHandler(serviceManager.Attempt(), validator, logger),
+		gradingHandler:       NewGradingHandler(serviceManager.Grading(), validator, logger),
+		dashboardHandler:     NewDashboardHandler(serviceManager.Dashboard(), logger),
+		studentHandler:       NewStudentHandler(serviceManager.Student(), logger),
+		proctoringHandler:    NewProctoringHandler(serviceManager.Proctoring(), validator, logger),
+		auditHandler:         NewAuditHandler(serviceManager.Audit(), logger),
+		gradingSchemeHandler: NewGradingSchemeHandler(serviceManager.GradingScheme(), logger),
+		analyticsHandler:     NewAnalyticsHandler(serviceManager.Analytics(), logger),
+		categoryHandler:      NewCategoryHandler(serviceManager.Category(), logger),
+		attachmentHandler:    NewAttachmentHandler(serviceManager.Attachment(), logger),
+		importExportHandler:  NewImportExportHandler(serviceManager.ImportExport(), validator, logger),
+		notificationHandler:  NewNotificationHandler(serviceManager.Notification(), logger),
+		classHandler:         NewClassHandler(serviceManager.Class(), logger),
+		userHandler:          NewUserHandler(userRepo, logger),
+		authMiddleware:       authMiddleware,
+		serviceManager:       serviceManager,
+		logger:               logger,
 	}
 }
 
+// rateLimit returns the rate limiting middleware for a rule of ServiceManagerConfig.RateLimitingRules,
+// requests pass through when the rule is not configured
+func (hm *HandlerManager) rateLimit(name string) gin.HandlerFunc {
+	rule, _ := hm.serviceManager.RateLimitRule(name)
+	return RateLimitMiddleware(hm.serviceManager.RateLimiter(), name, rule, hm.logger)
+}
+
 // SetupRoutes sets up all API routes
 func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 	// Health check endpoint
@@ -52,13 +81,14 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 
 	// API v1 routes with authentication
 	v1 := router.Group("/api/v1")
-	v1.Use(hm.authMiddleware.AuthMiddleware()) // Apply authentication to all API routes
+	v1.Use(hm.authMiddleware.AuthMiddleware())  // Apply authentication to all API routes
+	v1.Use(hm.rateLimit(services.RateLimitAPI)) // Per-user limit, after authentication identifies the user
 	{
 		// Assessment routes
 		assessments := v1.Group("/assessments")
 		{
 			// Create/modify assessments - Teachers and Admins only
-			assessments.POST("", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.CreateAssessment)
+			assessments.POST("", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.rateLimit(services.RateLimitAssessmentCreate), hm.assessmentHandler.CreateAssessment)
 			assessments.PUT("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UpdateAssessment)
 			assessments.DELETE("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.DeleteAssessment)
 			assessments.PUT("/:id/status", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UpdateAssessmentStatus)
@@ -74,6 +104,11 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			// Stats - Teachers and Admins only
 			assessments.GET("/:id/stats", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetAssessmentStats)
 
+			// Version snapshots - Teachers and Admins only
+			assessments.GET("/:id/versions", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.ListVersions)
+			assessments.GET("/:id/versions/diff", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.DiffVersions)
+			assessments.GET("/:id/versions/:version", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetVersion)
+
 			// Assessment question management - Teachers and Admins only
 			// Single question operations
 			assessments.POST("/:id/questions/:question_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.AddQuestionToAssessment)
@@ -89,6 +124,23 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			// Question ordering
 			assessments.PUT("/:id/questions/reorder", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.ReorderAssessmentQuestions)
 
+			// Grading scheme - Teachers and Admins only
+			assessments.GET("/:id/grading-scheme", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.gradingSchemeHandler.GetAssessmentScheme)
+			assessments.PUT("/:id/grading-scheme", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.gradingSchemeHandler.AssignAssessmentScheme)
+			assessments.DELETE("/:id/grading-scheme", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.gradingSchemeHandler.RemoveAssessmentScheme)
+
+			// Analytics - Teachers and Admins only
+			assessments.GET("/:id/analytics", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.analyticsHandler.GetAssessmentAnalytics)
+			assessments.POST("/:id/analytics/recalculate", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.analyticsHandler.RecalculateAssessmentAnalytics)
+
+			// Class and student assignment - Teachers and Admins only
+			assessments.GET("/:id/assignments", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.GetAssessmentAssignments)
+			assessments.POST("/:id/assignments", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.AssignAssessment)
+			assessments.DELETE("/:id/assignments/:assignment_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.UnassignAssessment)
+
+			// Results export - Teachers and Admins only
+			assessments.GET("/:id/results/export", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.importExportHandler.ExportAssessmentResults)
+
 			// Creator-specific routes - Teachers and Admins only
 			assessments.GET("/creator/:creator_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetAssessmentsByCreator)
 			assessments.GET("/creator/:creator_id/stats", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetCreatorStats)
@@ -101,13 +153,29 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			questions.POST("/batch", hm.questionHandler.CreateQuestionsBatch)
 			questions.PUT("/batch", hm.questionHandler.UpdateQuestionsBatch)
 			questions.GET("", hm.questionHandler.ListQuestions)
-			questions.GET("/search", hm.questionHandler.SearchQuestions)
+			questions.GET("/search", hm.rateLimit(services.RateLimitQuestionSearch), hm.questionHandler.SearchQuestions)
 			questions.GET("/random", hm.questionHandler.GetRandomQuestions)
 			questions.GET("/:id", hm.questionHandler.GetQuestion)
 			questions.GET("/:id/details", hm.questionHandler.GetQuestionWithDetails)
 			questions.PUT("/:id", hm.questionHandler.UpdateQuestion)
 			questions.DELETE("/:id", hm.questionHandler.DeleteQuestion)
 			questions.GET("/:id/stats", hm.questionHandler.GetQuestionStats)
+			questions.GET("/:id/variants", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.questionHandler.PreviewQuestionVariants)
+			questions.GET("/:id/analytics", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.analyticsHandler.GetQuestionAnalytics)
+			questions.POST("/:id/analytics/recalculate", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.analyticsHandler.RecalculateQuestionAnalytics)
+
+			// Attachments
+			questions.GET("/:id/attachments", hm.attachmentHandler.ListAttachments)
+			questions.POST("/:id/attachments", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attachmentHandler.UploadAttachment)
+			questions.PUT("/:id/attachments/order", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attachmentHandler.ReorderAttachments)
+			questions.PUT("/:id/attachments/:attachment_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attachmentHandler.UpdateAttachment)
+			questions.DELETE("/:id/attachments/:attachment_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attachmentHandler.DeleteAttachment)
+
+			// Import/export - Teachers and Admins only
+			questions.POST("/import", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.importExportHandler.ImportQuestions)
+			questions.GET("/import/jobs", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.importExportHandler.ListImportJobs)
+			questions.GET("/import/jobs/:job_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.importExportHandler.GetImportJob)
+			questions.POST("/export", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.importExportHandler.ExportQuestions)
 
 			// Question bank management
 			questions.GET("/bank/:bank_id", hm.questionHandler.GetQuestionsByBank)
@@ -119,6 +187,32 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			questions.GET("/creator/:creator_id/usage-stats", hm.questionHandler.GetQuestionUsageStats)
 		}
 
+		// Question category routes - Teachers and Admins only
+		categories := v1.Group("/categories")
+		categories.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			categories.GET("", hm.categoryHandler.GetCategoryTree)
+			categories.POST("", hm.categoryHandler.CreateCategory)
+			categories.GET("/:id", hm.categoryHandler.GetCategory)
+			categories.PUT("/:id", hm.categoryHandler.UpdateCategory)
+			categories.DELETE("/:id", hm.categoryHandler.DeleteCategory)
+			categories.PUT("/:id/move", hm.categoryHandler.MoveCategory)
+			categories.GET("/:id/path", hm.categoryHandler.GetCategoryPath)
+			categories.GET("/:id/stats", hm.categoryHandler.GetCategoryStats)
+		}
+
+		// Class roster routes - students can list the classes they belong to
+		classes := v1.Group("/classes")
+		{
+			classes.GET("", hm.classHandler.ListClasses)
+			classes.POST("", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.CreateClass)
+			classes.GET("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.GetClass)
+			classes.PUT("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.UpdateClass)
+			classes.DELETE("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.DeleteClass)
+			classes.POST("/:id/members", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.AddClassMembers)
+			classes.DELETE("/:id/members/:student_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.classHandler.RemoveClassMember)
+		}
+
 		// Question Bank routes
 		questionBanks := v1.Group("/question-banks")
 		{
@@ -160,17 +254,28 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 		// Attempt routes
 		attempts := v1.Group("/attempts")
 		{
-			attempts.POST("/start", hm.attemptHandler.StartAttempt)
+			attempts.POST("/start", hm.rateLimit(services.RateLimitAttemptStart), hm.attemptHandler.StartAttempt)
 			attempts.POST("/submit", hm.attemptHandler.SubmitAttempt)
 			attempts.GET("", hm.attemptHandler.ListAttempts)
 			attempts.GET("/:id", hm.attemptHandler.GetAttempt)
 			attempts.GET("/:id/details", hm.attemptHandler.GetAttemptWithDetails)
 			attempts.POST("/:id/resume", hm.attemptHandler.ResumeAttempt)
-			attempts.POST("/:id/answer", hm.attemptHandler.SubmitAnswer)
+			attempts.POST("/:id/answer", hm.rateLimit(services.RateLimitAttemptAnswer), hm.attemptHandler.SubmitAnswer)
 			attempts.GET("/:id/time-remaining", hm.attemptHandler.GetTimeRemaining)
 			attempts.POST("/:id/extend", hm.attemptHandler.ExtendTime)
 			attempts.POST("/:id/timeout", hm.attemptHandler.HandleTimeout)
 			attempts.GET("/:id/is-active", hm.attemptHandler.IsAttemptActive)
+			attempts.POST("/:id/heartbeat", hm.attemptHandler.Heartbeat)
+			attempts.PUT("/:id/flags", hm.attemptHandler.FlagQuestion)
+			attempts.POST("/:id/review", hm.attemptHandler.EnterReview)
+			attempts.GET("/:id/review", hm.attemptHandler.GetReviewSummary)
+			attempts.PUT("/:id/navigate", hm.attemptHandler.NavigateToQuestion)
+			attempts.GET("/:id/answers/:answer_id/history", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attemptHandler.GetAnswerHistory)
+
+			// Proctoring - students report events, proctors/teachers review them
+			attempts.POST("/:id/proctoring-events", hm.proctoringHandler.RecordEvents)
+			attempts.GET("/:id/proctoring-events", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleProctor, models.RoleAdmin), hm.proctoringHandler.GetAttemptEvents)
+			attempts.GET("/:id/proctoring-summary", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleProctor, models.RoleAdmin), hm.proctoringHandler.GetAttemptSummary)
 
 			// Assessment-specific routes
 			attempts.GET("/current/:assessment_id", hm.attemptHandler.GetCurrentAttempt)
@@ -183,9 +288,20 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			attempts.GET("/student/:student_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attemptHandler.GetAttemptsByStudent)
 		}
 
+		// Notification inbox routes - every authenticated user
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("", hm.notificationHandler.ListNotifications)
+			notifications.GET("/unread-count", hm.notificationHandler.GetUnreadCount)
+			notifications.PUT("/read-all", hm.notificationHandler.MarkAllRead)
+			notifications.PUT("/:id/read", hm.notificationHandler.MarkRead)
+			notifications.DELETE("/:id", hm.notificationHandler.DeleteNotification)
+		}
+
 		// Grading routes - Teachers, Proctors and Admins only
 		grading := v1.Group("/grading")
 		grading.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleProctor, models.RoleAdmin))
+		grading.Use(hm.rateLimit(services.RateLimitGradingSubmit))
 		{
 			// Manual grading
 			grading.POST("/answers/:answer_id", hm.gradingHandler.GradeAnswer)
@@ -209,6 +325,25 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			grading.GET("/assessments/:assessment_id/overview", hm.gradingHandler.GetGradingOverview)
 		}
 
+		// Proctoring review routes - Teachers, Proctors and Admins only
+		proctoring := v1.Group("/proctoring")
+		proctoring.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleProctor, models.RoleAdmin))
+		{
+			proctoring.GET("/assessments/:assessment_id/events", hm.proctoringHandler.GetAssessmentEvents)
+			proctoring.PUT("/events/:event_id/review", hm.proctoringHandler.ReviewEvent)
+		}
+
+		// Grading scheme template routes - Teachers and Admins only
+		gradingSchemes := v1.Group("/grading-schemes")
+		gradingSchemes.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			gradingSchemes.GET("", hm.gradingSchemeHandler.ListTemplates)
+			gradingSchemes.POST("", hm.gradingSchemeHandler.CreateTemplate)
+			gradingSchemes.GET("/:id", hm.gradingSchemeHandler.GetTemplate)
+			gradingSchemes.PUT("/:id", hm.gradingSchemeHandler.UpdateTemplate)
+			gradingSchemes.DELETE("/:id", hm.gradingSchemeHandler.DeleteTemplate)
+		}
+
 		// Dashboard routes - Teachers and Admins only
 		dashboard := v1.Group("/dashboard")
 		dashboard.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
@@ -229,6 +364,14 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			students.GET("/me/assessments/:id", hm.studentHandler.GetStudentAssessmentDetail)
 			students.GET("/me/attempts", hm.studentHandler.GetStudentAttempts)
 		}
+
+		// Admin routes - Admins only
+		admin := v1.Group("/admin")
+		admin.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			admin.GET("/audit-logs", hm.auditHandler.ListAuditLogs)
+			admin.GET("/audit-logs/:id", hm.auditHandler.GetAuditLog)
+		}
 	}
 
 	// Health check endpoint
@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS notification_receipts;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS import_jobs;
+DROP TABLE IF EXISTS question_analytics;
+DROP TABLE IF EXISTS assessment_analytics;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS grading_schemes;
+DROP TABLE IF EXISTS proctoring_events;
+DROP TABLE IF EXISTS student_answers;
+DROP TABLE IF EXISTS assessment_attempts;
+DROP TABLE IF EXISTS assessment_assignments;
+DROP TABLE IF EXISTS class_members;
+DROP TABLE IF EXISTS classes;
+DROP TABLE IF EXISTS assessment_reminders;
+DROP TABLE IF EXISTS assessment_questions;
+DROP TABLE IF EXISTS assessment_settings;
+DROP TABLE IF EXISTS assessments;
+DROP TABLE IF EXISTS question_bank_shares;
+DROP TABLE IF EXISTS question_bank_questions;
+DROP TABLE IF EXISTS question_banks;
+DROP TABLE IF EXISTS question_attachments;
+DROP TABLE IF EXISTS questions;
+DROP TABLE IF EXISTS question_categories;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,463 @@
+-- Baseline schema. Tables are created with IF NOT EXISTS so databases that were set up by hand
+-- or by GORM AutoMigrate can adopt the migrations; the ALTERs after some tables add columns
+-- those older databases may be missing.
+
+-- ===== USERS =====
+-- Mirror of the identity provider's users, joined by the repositories for names and emails
+CREATE TABLE IF NOT EXISTS users (
+    id         VARCHAR(255) PRIMARY KEY,
+    full_name  VARCHAR(100) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    avatar_url VARCHAR(500),
+    created_at TEXT,
+    updated_at TEXT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+-- ===== QUESTIONS =====
+CREATE TABLE IF NOT EXISTS question_categories (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+    color       VARCHAR(7) DEFAULT '#3B82F6',
+    icon        VARCHAR(50),
+    parent_id   BIGINT REFERENCES question_categories (id) ON DELETE SET NULL,
+    level       BIGINT DEFAULT 0,
+    path        VARCHAR(500),
+    created_by  VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+ALTER TABLE question_categories ADD COLUMN IF NOT EXISTS path VARCHAR(500);
+CREATE INDEX IF NOT EXISTS idx_question_categories_parent_id ON question_categories (parent_id);
+CREATE INDEX IF NOT EXISTS idx_question_categories_created_by ON question_categories (created_by);
+-- Subtree lookups use "path LIKE '/1/4/%'"
+CREATE INDEX IF NOT EXISTS idx_question_categories_path ON question_categories (path varchar_pattern_ops);
+
+CREATE TABLE IF NOT EXISTS questions (
+    id          BIGSERIAL PRIMARY KEY,
+    type        TEXT NOT NULL,
+    text        TEXT NOT NULL,
+    points      BIGINT DEFAULT 10,
+    time_limit  BIGINT,
+    "order"     BIGINT DEFAULT 0,
+    content     JSONB,
+    answer      JSONB,
+    category_id BIGINT REFERENCES question_categories (id) ON DELETE SET NULL,
+    difficulty  TEXT DEFAULT 'medium',
+    tags        JSONB,
+    explanation TEXT,
+    created_by  VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_questions_type ON questions (type);
+CREATE INDEX IF NOT EXISTS idx_questions_category_id ON questions (category_id);
+CREATE INDEX IF NOT EXISTS idx_questions_difficulty ON questions (difficulty);
+CREATE INDEX IF NOT EXISTS idx_questions_created_by ON questions (created_by);
+-- Tag filters use containment: tags @> '["algebra"]'
+CREATE INDEX IF NOT EXISTS idx_questions_tags ON questions USING GIN (tags jsonb_path_ops);
+
+-- No foreign key: questions are hard-deleted and the attachment cleanup removes the leftover rows and files
+CREATE TABLE IF NOT EXISTS question_attachments (
+    id            BIGSERIAL PRIMARY KEY,
+    question_id   BIGINT NOT NULL,
+    file_name     VARCHAR(255) NOT NULL,
+    file_type     VARCHAR(50) NOT NULL,
+    file_size     BIGINT NOT NULL,
+    mime_type     VARCHAR(100) NOT NULL,
+    storage_path  VARCHAR(500) NOT NULL,
+    url           VARCHAR(500) NOT NULL,
+    thumbnail_url VARCHAR(500),
+    alt           VARCHAR(255),
+    caption       TEXT,
+    "order"       BIGINT DEFAULT 0,
+    created_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_question_attachments_question_id ON question_attachments (question_id);
+
+-- ===== QUESTION BANKS =====
+CREATE TABLE IF NOT EXISTS question_banks (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(200) NOT NULL,
+    description TEXT,
+    is_public   BOOLEAN DEFAULT FALSE,
+    is_shared   BOOLEAN DEFAULT FALSE,
+    created_by  VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_question_banks_created_by ON question_banks (created_by);
+
+CREATE TABLE IF NOT EXISTS question_bank_questions (
+    question_bank_id BIGINT NOT NULL REFERENCES question_banks (id) ON DELETE CASCADE,
+    question_id      BIGINT NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    created_at       TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (question_bank_id, question_id)
+);
+CREATE INDEX IF NOT EXISTS idx_question_bank_questions_question_id ON question_bank_questions (question_id);
+
+CREATE TABLE IF NOT EXISTS question_bank_shares (
+    id         BIGSERIAL PRIMARY KEY,
+    bank_id    BIGINT NOT NULL REFERENCES question_banks (id) ON DELETE CASCADE,
+    user_id    VARCHAR(255) NOT NULL,
+    can_view   BOOLEAN DEFAULT TRUE,
+    can_edit   BOOLEAN DEFAULT FALSE,
+    can_delete BOOLEAN DEFAULT FALSE,
+    shared_at  TIMESTAMPTZ,
+    shared_by  VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_question_bank_shares_bank_id ON question_bank_shares (bank_id);
+CREATE INDEX IF NOT EXISTS idx_question_bank_shares_user_id ON question_bank_shares (user_id);
+
+-- ===== ASSESSMENTS =====
+CREATE TABLE IF NOT EXISTS assessments (
+    id             BIGSERIAL PRIMARY KEY,
+    title          VARCHAR(200) NOT NULL,
+    description    TEXT,
+    duration       BIGINT NOT NULL,
+    status         TEXT DEFAULT 'Draft',
+    passing_score  BIGINT NOT NULL,
+    max_attempts   BIGINT DEFAULT 1,
+    time_warning   BIGINT DEFAULT 300,
+    due_date       TIMESTAMPTZ,
+    available_from TIMESTAMPTZ,
+    created_by     VARCHAR(255) NOT NULL,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    version        BIGINT DEFAULT 1
+);
+ALTER TABLE assessments ADD COLUMN IF NOT EXISTS available_from TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_assessments_title ON assessments (title);
+CREATE INDEX IF NOT EXISTS idx_assessments_status ON assessments (status);
+CREATE INDEX IF NOT EXISTS idx_assessments_created_by ON assessments (created_by);
+-- Lifecycle scheduler: expiry and reminders by due date, scheduled publishes by available_from
+CREATE INDEX IF NOT EXISTS idx_assessments_status_due_date ON assessments (status, due_date) WHERE due_date IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_assessments_available_from ON assessments (available_from) WHERE status = 'Draft' AND available_from IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS assessment_settings (
+    assessment_id                 BIGINT PRIMARY KEY REFERENCES assessments (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_at                    TIMESTAMPTZ NOT NULL,
+    updated_at                    TIMESTAMPTZ NOT NULL,
+    randomize_questions           BOOLEAN NOT NULL DEFAULT FALSE,
+    randomize_options             BOOLEAN NOT NULL DEFAULT FALSE,
+    show_progress_bar             BOOLEAN NOT NULL DEFAULT TRUE,
+    require_webcam                BOOLEAN NOT NULL DEFAULT FALSE,
+    prevent_tab_switching         BOOLEAN NOT NULL DEFAULT FALSE,
+    prevent_right_click           BOOLEAN NOT NULL DEFAULT FALSE,
+    prevent_copy_paste            BOOLEAN NOT NULL DEFAULT FALSE,
+    require_identity_verification BOOLEAN NOT NULL DEFAULT FALSE,
+    require_full_screen           BOOLEAN NOT NULL DEFAULT FALSE,
+    allow_screen_reader           BOOLEAN NOT NULL DEFAULT FALSE,
+    font_size_adjustment          BIGINT NOT NULL DEFAULT 0 CHECK (font_size_adjustment >= -2 AND font_size_adjustment <= 2),
+    high_contrast_mode            BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS assessment_questions (
+    id            BIGSERIAL PRIMARY KEY,
+    assessment_id BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    question_id   BIGINT NOT NULL REFERENCES questions (id),
+    "order"       BIGINT NOT NULL,
+    points        BIGINT,
+    time_limit    BIGINT,
+    required      BOOLEAN DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_assessment_questions_assessment_id ON assessment_questions (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_questions_question_id ON assessment_questions (question_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_questions_deleted_at ON assessment_questions (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assessment_question ON assessment_questions (assessment_id, question_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS assessment_reminders (
+    assessment_id  BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    offset_minutes BIGINT NOT NULL,
+    sent_at        TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (assessment_id, offset_minutes)
+);
+
+-- ===== CLASSES AND ASSIGNMENTS =====
+CREATE TABLE IF NOT EXISTS classes (
+    id          BIGSERIAL PRIMARY KEY,
+    name        VARCHAR(200) NOT NULL,
+    code        VARCHAR(50),
+    description TEXT,
+    is_active   BOOLEAN DEFAULT TRUE,
+    created_by  VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_classes_code ON classes (code);
+CREATE INDEX IF NOT EXISTS idx_classes_is_active ON classes (is_active);
+CREATE INDEX IF NOT EXISTS idx_classes_created_by ON classes (created_by);
+
+CREATE TABLE IF NOT EXISTS class_members (
+    class_id   BIGINT NOT NULL REFERENCES classes (id) ON DELETE CASCADE,
+    student_id VARCHAR(255) NOT NULL,
+    added_by   VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (class_id, student_id)
+);
+CREATE INDEX IF NOT EXISTS idx_class_members_student_id ON class_members (student_id);
+
+CREATE TABLE IF NOT EXISTS assessment_assignments (
+    id            BIGSERIAL PRIMARY KEY,
+    assessment_id BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    class_id      BIGINT REFERENCES classes (id) ON DELETE CASCADE,
+    student_id    VARCHAR(255),
+    assigned_by   VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMPTZ,
+    CHECK ((class_id IS NULL) <> (student_id IS NULL))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assignment_class ON assessment_assignments (assessment_id, class_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assignment_student ON assessment_assignments (assessment_id, student_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_assignments_class_id ON assessment_assignments (class_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_assignments_student_id ON assessment_assignments (student_id);
+
+-- ===== ATTEMPTS AND ANSWERS =====
+CREATE TABLE IF NOT EXISTS assessment_attempts (
+    id                     BIGSERIAL PRIMARY KEY,
+    assessment_id          BIGINT NOT NULL REFERENCES assessments (id),
+    student_id             VARCHAR(255) NOT NULL,
+    attempt_number         BIGINT NOT NULL,
+    status                 TEXT DEFAULT 'in_progress',
+    started_at             TIMESTAMPTZ,
+    ended_at               TIMESTAMPTZ,
+    completed_at           TIMESTAMPTZ,
+    time_spent             BIGINT,
+    time_remaining         BIGINT,
+    score                  NUMERIC,
+    max_score              BIGINT,
+    percentage             NUMERIC,
+    passed                 BOOLEAN,
+    is_graded              BOOLEAN,
+    current_question_index BIGINT,
+    questions_answered     BIGINT,
+    total_questions        BIGINT,
+    is_review              BOOLEAN,
+    ip_address             VARCHAR(45),
+    user_agent             TEXT,
+    session_data           JSONB,
+    end_reason             TEXT,
+    created_at             TIMESTAMPTZ,
+    updated_at             TIMESTAMPTZ,
+    deleted_at             TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_assessment_id ON assessment_attempts (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_student_id ON assessment_attempts (student_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_status ON assessment_attempts (status);
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_deleted_at ON assessment_attempts (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_student_assessment_attempt ON assessment_attempts (student_id, assessment_id, attempt_number) WHERE deleted_at IS NULL;
+-- Timeout sweeper: in-progress attempts ordered by end time
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_in_progress_ended_at ON assessment_attempts (ended_at) WHERE status = 'in_progress';
+
+CREATE TABLE IF NOT EXISTS student_answers (
+    id                BIGSERIAL PRIMARY KEY,
+    attempt_id        BIGINT NOT NULL REFERENCES assessment_attempts (id) ON DELETE CASCADE,
+    question_id       BIGINT NOT NULL REFERENCES questions (id),
+    answer            JSONB,
+    score             NUMERIC,
+    max_score         BIGINT,
+    is_correct        BOOLEAN,
+    graded_by         VARCHAR(255),
+    graded_at         TIMESTAMPTZ,
+    feedback          TEXT,
+    time_spent        BIGINT,
+    first_answered_at TIMESTAMPTZ,
+    last_modified_at  TIMESTAMPTZ,
+    answer_history    JSONB,
+    flagged           BOOLEAN,
+    is_graded         BOOLEAN,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_student_answers_attempt_id ON student_answers (attempt_id);
+CREATE INDEX IF NOT EXISTS idx_student_answers_question_id ON student_answers (question_id);
+-- One answer per question and attempt, answers are upserted on save
+CREATE UNIQUE INDEX IF NOT EXISTS idx_student_answers_attempt_question ON student_answers (attempt_id, question_id);
+
+-- ===== PROCTORING =====
+CREATE TABLE IF NOT EXISTS proctoring_events (
+    id             BIGSERIAL PRIMARY KEY,
+    attempt_id     BIGINT NOT NULL REFERENCES assessment_attempts (id) ON DELETE CASCADE,
+    type           TEXT NOT NULL,
+    data           JSONB,
+    severity       BIGINT DEFAULT 1,
+    screenshot_url TEXT,
+    video_url      TEXT,
+    audio_url      TEXT,
+    question_id    BIGINT REFERENCES questions (id) ON DELETE SET NULL,
+    time_offset    BIGINT,
+    user_agent     TEXT,
+    ip_address     VARCHAR(45),
+    review_status  TEXT DEFAULT 'pending',
+    reviewed_by    VARCHAR(255),
+    reviewed_at    TIMESTAMPTZ,
+    review_notes   TEXT,
+    created_at     TIMESTAMPTZ
+);
+ALTER TABLE proctoring_events ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_proctoring_events_attempt_id ON proctoring_events (attempt_id);
+CREATE INDEX IF NOT EXISTS idx_proctoring_events_type ON proctoring_events (type);
+CREATE INDEX IF NOT EXISTS idx_proctoring_events_question_id ON proctoring_events (question_id);
+
+-- ===== GRADING =====
+CREATE TABLE IF NOT EXISTS grading_schemes (
+    id              BIGSERIAL PRIMARY KEY,
+    assessment_id   BIGINT REFERENCES assessments (id) ON DELETE CASCADE,
+    is_template     BOOLEAN DEFAULT FALSE,
+    name            VARCHAR(100) NOT NULL,
+    type            TEXT NOT NULL,
+    passing_score   NUMERIC,
+    max_score       BIGINT,
+    grade_ranges    JSONB,
+    weightings      JSONB,
+    round_to        BIGINT DEFAULT 2,
+    use_drop_lowest BIGINT,
+    bonus_points    NUMERIC,
+    curve_points    NUMERIC,
+    created_by      VARCHAR(255) NOT NULL,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+ALTER TABLE grading_schemes ADD COLUMN IF NOT EXISTS assessment_id BIGINT;
+ALTER TABLE grading_schemes ADD COLUMN IF NOT EXISTS is_template BOOLEAN DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_grading_schemes_assessment_id ON grading_schemes (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_grading_schemes_is_template ON grading_schemes (is_template);
+CREATE INDEX IF NOT EXISTS idx_grading_schemes_created_by ON grading_schemes (created_by);
+
+-- ===== AUDIT =====
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id               BIGSERIAL PRIMARY KEY,
+    event_type       TEXT NOT NULL,
+    user_id          VARCHAR(255) NOT NULL,
+    user_email       VARCHAR(255) NOT NULL,
+    user_role        TEXT NOT NULL,
+    target_type      VARCHAR(50),
+    target_id        BIGINT,
+    description      TEXT NOT NULL,
+    changes          JSONB,
+    metadata         JSONB,
+    ip_address       VARCHAR(45),
+    user_agent       TEXT,
+    request_id       VARCHAR(36),
+    compliance_level VARCHAR(20),
+    retention_period BIGINT DEFAULT 2555,
+    created_at       TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_event_type ON audit_logs (event_type);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id ON audit_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+
+-- ===== ANALYTICS =====
+CREATE TABLE IF NOT EXISTS assessment_analytics (
+    id                 BIGSERIAL PRIMARY KEY,
+    assessment_id      BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    total_attempts     BIGINT,
+    completed_attempts BIGINT,
+    abandoned_attempts BIGINT,
+    average_score      NUMERIC,
+    median_score       NUMERIC,
+    highest_score      NUMERIC,
+    lowest_score       NUMERIC,
+    standard_deviation NUMERIC,
+    average_time_spent BIGINT,
+    median_time_spent  BIGINT,
+    pass_rate          NUMERIC,
+    passed_count       BIGINT,
+    failed_count       BIGINT,
+    score_distribution JSONB,
+    time_distribution  JSONB,
+    last_calculated_at TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ,
+    updated_at         TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assessment_analytics_assessment_id ON assessment_analytics (assessment_id);
+
+CREATE TABLE IF NOT EXISTS question_analytics (
+    id                      BIGSERIAL PRIMARY KEY,
+    question_id             BIGINT NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+    total_responses         BIGINT,
+    correct_responses       BIGINT,
+    difficulty_index        NUMERIC,
+    discrimination_index    NUMERIC,
+    average_score           NUMERIC,
+    average_time_spent      BIGINT,
+    option_stats            JSONB,
+    top_quartile_correct    NUMERIC,
+    bottom_quartile_correct NUMERIC,
+    last_calculated_at      TIMESTAMPTZ,
+    created_at              TIMESTAMPTZ,
+    updated_at              TIMESTAMPTZ
+);
+ALTER TABLE question_analytics ADD COLUMN IF NOT EXISTS difficulty_index NUMERIC;
+ALTER TABLE question_analytics ADD COLUMN IF NOT EXISTS discrimination_index NUMERIC;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_question_analytics_question_id ON question_analytics (question_id);
+
+-- ===== IMPORT JOBS =====
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id             VARCHAR(36) PRIMARY KEY,
+    assessment_id  BIGINT REFERENCES assessments (id) ON DELETE SET NULL,
+    bank_id        BIGINT REFERENCES question_banks (id) ON DELETE SET NULL,
+    user_id        VARCHAR(255) NOT NULL,
+    file_name      VARCHAR(255) NOT NULL,
+    file_type      VARCHAR(20) NOT NULL,
+    file_size      BIGINT NOT NULL,
+    file_path      VARCHAR(500) NOT NULL,
+    status         TEXT DEFAULT 'pending',
+    progress       BIGINT DEFAULT 0,
+    total_rows     BIGINT,
+    processed_rows BIGINT,
+    success_count  BIGINT,
+    error_count    BIGINT,
+    errors         JSONB,
+    summary        JSONB,
+    started_at     TIMESTAMPTZ,
+    completed_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_assessment_id ON import_jobs (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_bank_id ON import_jobs (bank_id);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_user_id ON import_jobs (user_id);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status);
+
+-- ===== NOTIFICATIONS =====
+CREATE TABLE IF NOT EXISTS notifications (
+    id              BIGSERIAL PRIMARY KEY,
+    type            TEXT NOT NULL,
+    title           VARCHAR(255) NOT NULL,
+    message         TEXT,
+    recipient_id    VARCHAR(255),
+    recipient_role  TEXT,
+    assessment_id   BIGINT REFERENCES assessments (id) ON DELETE CASCADE,
+    attempt_id      BIGINT REFERENCES assessment_attempts (id) ON DELETE CASCADE,
+    channels        JSONB,
+    priority        BIGINT DEFAULT 1,
+    sent_at         TIMESTAMPTZ,
+    read_at         TIMESTAMPTZ,
+    delivery_status TEXT DEFAULT 'pending',
+    scheduled_for   TIMESTAMPTZ,
+    expires_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ,
+    created_by      VARCHAR(255) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_type ON notifications (type);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_id ON notifications (recipient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient_role ON notifications (recipient_role, created_at DESC) WHERE recipient_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_notifications_assessment_id ON notifications (assessment_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_attempt_id ON notifications (attempt_id);
+
+CREATE TABLE IF NOT EXISTS notification_receipts (
+    notification_id BIGINT NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    user_id         VARCHAR(255) NOT NULL,
+    read_at         TIMESTAMPTZ,
+    dismissed_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ,
+    PRIMARY KEY (notification_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_notification_receipts_user_id ON notification_receipts (user_id);
+
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_classes_name_trgm;
+DROP INDEX IF EXISTS idx_question_categories_name_trgm;
+DROP INDEX IF EXISTS idx_question_banks_name_trgm;
+DROP INDEX IF EXISTS idx_questions_text_trgm;
+DROP INDEX IF EXISTS idx_assessments_title_trgm;
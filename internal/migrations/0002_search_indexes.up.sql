@@ -0,0 +1,8 @@
+-- Trigram indexes for the ILIKE / LIKE '%term%' searches on titles, names and question text
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_assessments_title_trgm ON assessments USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_questions_text_trgm ON questions USING GIN (LOWER(text) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_question_banks_name_trgm ON question_banks USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_question_categories_name_trgm ON question_categories USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_classes_name_trgm ON classes USING GIN (name gin_trgm_ops);
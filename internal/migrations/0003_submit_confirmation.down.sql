@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_student_answers_flagged;
+
+ALTER TABLE assessment_settings DROP COLUMN IF EXISTS require_submit_confirmation;
@@ -0,0 +1,5 @@
+-- Assessments can require students to confirm submits that leave required questions unanswered
+ALTER TABLE assessment_settings ADD COLUMN IF NOT EXISTS require_submit_confirmation BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Review summaries count flagged answers per attempt
+CREATE INDEX IF NOT EXISTS idx_student_answers_flagged ON student_answers (attempt_id) WHERE flagged;
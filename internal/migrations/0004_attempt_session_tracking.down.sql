@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_assessment_attempts_session_flagged;
+
+ALTER TABLE assessment_attempts DROP COLUMN IF EXISTS session_flagged;
@@ -0,0 +1,4 @@
+-- Attempts taken from more than one device or network are flagged for proctor review
+ALTER TABLE assessment_attempts ADD COLUMN IF NOT EXISTS session_flagged BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_assessment_attempts_session_flagged ON assessment_attempts (session_flagged);
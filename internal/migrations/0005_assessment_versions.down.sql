@@ -0,0 +1,3 @@
+ALTER TABLE assessment_attempts DROP COLUMN IF EXISTS assessment_version;
+
+DROP TABLE IF EXISTS assessment_versions;
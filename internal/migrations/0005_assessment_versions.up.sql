@@ -0,0 +1,16 @@
+-- Immutable snapshots of an assessment and its questions; attempts are graded against the version they started on
+CREATE TABLE IF NOT EXISTS assessment_versions (
+    id            BIGSERIAL PRIMARY KEY,
+    assessment_id BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    version       BIGINT NOT NULL,
+    snapshot      JSONB NOT NULL,
+    checksum      VARCHAR(64) NOT NULL,
+    reason        VARCHAR(50) NOT NULL,
+    created_by    VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assessment_version ON assessment_versions (assessment_id, version);
+
+-- 0 marks attempts started before versioning, they keep using the live questions
+ALTER TABLE assessment_attempts ADD COLUMN IF NOT EXISTS assessment_version BIGINT NOT NULL DEFAULT 0;
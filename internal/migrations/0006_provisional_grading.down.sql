@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_student_answers_is_provisional;
+
+ALTER TABLE student_answers DROP COLUMN IF EXISTS grading_confidence;
+ALTER TABLE student_answers DROP COLUMN IF EXISTS is_provisional;
@@ -0,0 +1,5 @@
+-- Keyword-scored essays are graded provisionally until a teacher confirms or overrides the score
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS is_provisional BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS grading_confidence DOUBLE PRECISION;
+
+CREATE INDEX IF NOT EXISTS idx_student_answers_is_provisional ON student_answers (is_provisional);
@@ -0,0 +1,9 @@
+ALTER TABLE student_answers DROP COLUMN IF EXISTS omitted;
+ALTER TABLE student_answers DROP COLUMN IF EXISTS confidence;
+
+ALTER TABLE assessment_questions DROP COLUMN IF EXISTS negative_marking_value;
+ALTER TABLE assessment_questions DROP COLUMN IF EXISTS negative_marking;
+
+ALTER TABLE assessment_settings DROP COLUMN IF EXISTS confidence_marking;
+ALTER TABLE assessment_settings DROP COLUMN IF EXISTS negative_marking_value;
+ALTER TABLE assessment_settings DROP COLUMN IF EXISTS negative_marking;
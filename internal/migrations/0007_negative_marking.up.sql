@@ -0,0 +1,11 @@
+-- Negative marking for wrong answers, set per assessment and overridable per question
+ALTER TABLE assessment_settings ADD COLUMN IF NOT EXISTS negative_marking VARCHAR(20) NOT NULL DEFAULT 'none';
+ALTER TABLE assessment_settings ADD COLUMN IF NOT EXISTS negative_marking_value DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE assessment_settings ADD COLUMN IF NOT EXISTS confidence_marking BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE assessment_questions ADD COLUMN IF NOT EXISTS negative_marking VARCHAR(20);
+ALTER TABLE assessment_questions ADD COLUMN IF NOT EXISTS negative_marking_value DOUBLE PRECISION;
+
+-- Confidence students attach to answers for confidence-based marking (1-3), and deliberate omissions
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS confidence INTEGER;
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS omitted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+-- Variable values drawn per attempt for templated (algorithmic) questions
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS variant JSONB;
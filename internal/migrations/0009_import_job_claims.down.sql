@@ -0,0 +1,2 @@
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS claim_expires_at;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS claimed_by;
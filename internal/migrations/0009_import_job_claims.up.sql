@@ -0,0 +1,3 @@
+-- Replica processing an import job and until when, so only one replica runs it
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS claimed_by VARCHAR(100);
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS claim_expires_at TIMESTAMPTZ;
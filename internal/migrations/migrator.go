@@ -0,0 +1,339 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration scripts are named <version>_<name>.up.sql and <version>_<name>.down.sql
+//
+//go:embed *.sql
+var scripts embed.FS
+
+// advisoryLockKey is the pg_advisory_lock key held while migrations run, so replicas
+// starting together do not apply the same migration twice
+const advisoryLockKey int64 = 0x6173736d6e74 // "assmnt"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration does not match its script")
+	ErrNoDownScript     = errors.New("migration has no down script")
+	ErrUnknownMigration = errors.New("applied migration is not known to this binary")
+)
+
+// Migration is a versioned schema change
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of the up script
+}
+
+// MigrationStatus describes a migration and whether it has been applied
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Modified  bool       `json:"modified"` // the script changed after it was applied
+}
+
+// schemaMigration is a row of the tracking table
+type schemaMigration struct {
+	Version     int       `gorm:"primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"not null"`
+	Checksum    string    `gorm:"not null"`
+	AppliedAt   time.Time `gorm:"not null"`
+	ExecutionMs int64     `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createTrackingTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version      BIGINT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    checksum     TEXT NOT NULL,
+    applied_at   TIMESTAMPTZ NOT NULL,
+    execution_ms BIGINT NOT NULL
+)`
+
+// Migrator applies the embedded migrations to a PostgreSQL database
+type Migrator struct {
+	db         *gorm.DB
+	logger     *slog.Logger
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations embedded in the binary
+func NewMigrator(db *gorm.DB, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := loadMigrations(scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		logger:     logger,
+		migrations: migrations,
+	}, nil
+}
+
+// Migrations returns the known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies all pending migrations in order, each in its own transaction. It refuses to run
+// when an applied migration's script was changed afterwards. It returns the number applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		done, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+			started := time.Now()
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{
+					Version:     migration.Version,
+					Name:        migration.Name,
+					Checksum:    migration.Checksum,
+					AppliedAt:   time.Now(),
+					ExecutionMs: time.Since(started).Milliseconds(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the most recent steps applied migrations, newest first. It returns the number reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		done, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+		for _, version := range versions {
+			if reverted >= steps {
+				break
+			}
+
+			migration := m.find(version)
+			if migration == nil {
+				return fmt.Errorf("%w: version %d", ErrUnknownMigration, version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %d_%s", ErrNoDownScript, migration.Version, migration.Name)
+			}
+
+			m.logger.Info("Reverting migration", "version", migration.Version, "name", migration.Name)
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		done, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]MigrationStatus, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := MigrationStatus{
+				Version: migration.Version,
+				Name:    migration.Name,
+			}
+			if row, ok := done[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = row.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// ===== HELPERS =====
+
+// withLock runs fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			// The lock belongs to the session, release it even when ctx was cancelled
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey).Error; err != nil {
+				m.logger.Error("Failed to release migration lock", "error", err)
+			}
+		}()
+
+		if err := conn.Exec(createTrackingTableSQL).Error; err != nil {
+			return fmt.Errorf("failed to create migration tracking table: %w", err)
+		}
+
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) appliedMigrations(conn *gorm.DB) (map[int]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := conn.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// verify checks that applied migrations still match the scripts in this binary
+func (m *Migrator) verify(applied map[int]schemaMigration) error {
+	for version, row := range applied {
+		migration := m.find(version)
+		if migration == nil {
+			// Applied by a newer binary, e.g. during a rolling deploy; nothing to check
+			m.logger.Warn("Database has a migration this binary does not know", "version", version, "name", row.Name)
+			continue
+		}
+		if migration.Checksum != row.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// loadMigrations reads the migration scripts from fsys and returns them in version order
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
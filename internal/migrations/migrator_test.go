@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(scripts)
+	if err != nil {
+		t.Fatalf("Expected embedded migrations to load, got %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("Expected at least one embedded migration")
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("Expected migration versions to be sequential, got %d at position %d", migration.Version, i)
+		}
+		if migration.Down == "" {
+			t.Errorf("Expected migration %d_%s to have a down script", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_second.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+		"0001_first.up.sql":    {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+		"0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(migrations) != 2 || migrations[0].Name != "first" || migrations[1].Name != "second" {
+		t.Fatalf("Expected migrations first and second in order, got %+v", migrations)
+	}
+
+	if migrations[0].Checksum == "" || migrations[0].Checksum == migrations[1].Checksum {
+		t.Errorf("Expected distinct checksums, got %q and %q", migrations[0].Checksum, migrations[1].Checksum)
+	}
+
+	// A down script without its up script is rejected
+	_, err = loadMigrations(fstest.MapFS{"0001_first.down.sql": {Data: []byte("DROP TABLE a;")}})
+	if err == nil {
+		t.Error("Expected error for migration without up script")
+	}
+
+	// Two names for one version are rejected
+	_, err = loadMigrations(fstest.MapFS{
+		"0001_first.up.sql": {Data: []byte("SELECT 1;")},
+		"0001_other.up.sql": {Data: []byte("SELECT 1;")},
+	})
+	if err == nil {
+		t.Error("Expected error for duplicate migration version")
+	}
+}
@@ -40,7 +40,7 @@ type AssessmentAnalytics struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 
 	// Relations
-	Assessment Assessment `json:"assessment" gorm:"foreignKey:AssessmentID"`
+	Assessment *Assessment `json:"assessment,omitempty" gorm:"foreignKey:AssessmentID"`
 }
 
 type QuestionAnalytics struct {
@@ -52,8 +52,8 @@ type QuestionAnalytics struct {
 	CorrectResponses int `json:"correct_responses"`
 
 	// Performance metrics
-	DifficultyIndex     float64 `json:"difficulty_index"`     // % who got it right
-	DiscriminationIndex float64 `json:"discrimination_index"` // How well it separates high/low performers
+	DifficultyIndex     float64 `json:"difficulty_index"`     // Share who got it right (0.0 - 1.0)
+	DiscriminationIndex float64 `json:"discrimination_index"` // Point-biserial correlation with the attempt score (-1.0 - 1.0)
 
 	// Score statistics
 	AverageScore     float64 `json:"average_score"`
@@ -62,7 +62,7 @@ type QuestionAnalytics struct {
 	// Option analysis (for MC questions)
 	OptionStats datatypes.JSON `json:"option_stats" gorm:"type:jsonb"` // []OptionStat
 
-	// Performance by difficulty groups
+	// Performance by difficulty groups (share correct in the upper/lower 27% of attempts)
 	TopQuartileCorrect    float64 `json:"top_quartile_correct"`
 	BottomQuartileCorrect float64 `json:"bottom_quartile_correct"`
 
@@ -71,5 +71,5 @@ type QuestionAnalytics struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 
 	// Relations
-	Question Question `json:"question" gorm:"foreignKey:QuestionID"`
+	Question *Question `json:"question,omitempty" gorm:"foreignKey:QuestionID"`
 }
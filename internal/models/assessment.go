@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/datatypes"
 )
 
 type AssessmentStatus string
@@ -24,6 +26,9 @@ type Assessment struct {
 	TimeWarning  int              `json:"time_warning" gorm:"default:300"` // Warning time in seconds
 	DueDate      *time.Time       `json:"due_date"`
 
+	// Scheduling, a Draft assessment is published automatically once AvailableFrom has passed
+	AvailableFrom *time.Time `json:"available_from" gorm:"index"`
+
 	// Metadata
 	CreatedBy string    `json:"created_by" gorm:"not null;index;size:255"`
 	CreatedAt time.Time `json:"created_at"`
@@ -63,6 +68,14 @@ type AssessmentSettings struct {
 	RequireIdentityVerification bool `json:"require_identity_verification" gorm:"not null;default:false;comment:Require identity verification"`
 	RequireFullScreen           bool `json:"require_full_screen" gorm:"not null;default:false;comment:Force fullscreen mode"`
 
+	// Submission Settings
+	RequireSubmitConfirmation bool `json:"require_submit_confirmation" gorm:"not null;default:false;comment:Submits leaving required questions unanswered must be confirmed"`
+
+	// Marking Settings, questions can override negative marking
+	NegativeMarking      NegativeMarkingMode `json:"negative_marking" gorm:"not null;default:none;size:20;comment:Penalty for wrong answers (none, fixed, fraction)"`
+	NegativeMarkingValue float64             `json:"negative_marking_value" gorm:"not null;default:0;comment:Points deducted (fixed) or share of the question points deducted (fraction)"`
+	ConfidenceMarking    bool                `json:"confidence_marking" gorm:"not null;default:false;comment:Scale rewards and penalties by the confidence students give"`
+
 	// Accessibility Settings
 	AllowScreenReader  bool `json:"allow_screen_reader" gorm:"not null;default:false;comment:Enable screen reader support"`
 	FontSizeAdjustment int  `json:"font_size_adjustment" gorm:"not null;default:0;check:font_size_adjustment >= -2 AND font_size_adjustment <= 2;comment:Font size adjustment (-2 to +2)"`
@@ -72,6 +85,84 @@ type AssessmentSettings struct {
 	// Assessment Assessment `json:"assessment" gorm:"foreignKey:AssessmentID;references:ID"`
 }
 
+// NegativeMarkingMode selects how wrong answers are penalized. Unanswered and omitted questions
+// always score zero.
+type NegativeMarkingMode string
+
+const (
+	NegativeMarkingNone     NegativeMarkingMode = "none"
+	NegativeMarkingFixed    NegativeMarkingMode = "fixed"    // Deduct NegativeMarkingValue points
+	NegativeMarkingFraction NegativeMarkingMode = "fraction" // Deduct NegativeMarkingValue (0-1) of the question points
+)
+
+// Confidence levels students can attach to answers when confidence marking is enabled
+const (
+	ConfidenceLow    = 1
+	ConfidenceMedium = 2
+	ConfidenceHigh   = 3
+)
+
+// AssessmentReminder records a due-date reminder that was sent, so each offset fires once per assessment
+type AssessmentReminder struct {
+	AssessmentID  uint      `json:"assessment_id" gorm:"primaryKey;constraint:OnDelete:CASCADE"`
+	OffsetMinutes int       `json:"offset_minutes" gorm:"primaryKey"` // Minutes before the due date
+	SentAt        time.Time `json:"sent_at" gorm:"not null"`
+}
+
+// AssessmentVersion is an immutable snapshot of an assessment and its questions. Attempts are pinned
+// to the version they were started on and graded against it.
+type AssessmentVersion struct {
+	ID           uint           `json:"id" gorm:"primaryKey"`
+	AssessmentID uint           `json:"assessment_id" gorm:"not null;uniqueIndex:idx_assessment_version"`
+	Version      int            `json:"version" gorm:"not null;uniqueIndex:idx_assessment_version"`
+	Snapshot     datatypes.JSON `json:"snapshot,omitempty" gorm:"type:jsonb;not null"` // AssessmentSnapshot
+	Checksum     string         `json:"checksum" gorm:"not null;size:64"`              // SHA-256 of Snapshot
+	Reason       string         `json:"reason" gorm:"not null;size:50"`                // published, updated, attempt_started
+	CreatedBy    string         `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// AssessmentSnapshot is the content of an assessment version
+type AssessmentSnapshot struct {
+	Title        string                       `json:"title"`
+	Description  *string                      `json:"description,omitempty"`
+	Duration     int                          `json:"duration"`
+	PassingScore int                          `json:"passing_score"`
+	MaxAttempts  int                          `json:"max_attempts"`
+	TotalPoints  int                          `json:"total_points"`
+	Questions    []AssessmentQuestionSnapshot `json:"questions"`
+
+	ConfidenceMarking bool `json:"confidence_marking,omitempty"`
+}
+
+// AssessmentQuestionSnapshot is a question as it was in an assessment version
+type AssessmentQuestionSnapshot struct {
+	QuestionID  uint            `json:"question_id"`
+	Order       int             `json:"order"`
+	Points      int             `json:"points"` // AssessmentQuestion.Points, or Question.Points when not overridden
+	Required    bool            `json:"required"`
+	Type        QuestionType    `json:"type"`
+	Text        string          `json:"text"`
+	Content     datatypes.JSON  `json:"content"` // Includes the answer key
+	Answer      datatypes.JSON  `json:"answer,omitempty"`
+	Difficulty  DifficultyLevel `json:"difficulty"`
+	Explanation *string         `json:"explanation,omitempty"`
+
+	// Negative marking in effect for the question, after question overrides
+	NegativeMarking      NegativeMarkingMode `json:"negative_marking,omitempty"`
+	NegativeMarkingValue float64             `json:"negative_marking_value,omitempty"`
+}
+
+// Question returns the snapshot question for questionID
+func (s *AssessmentSnapshot) Question(questionID uint) (*AssessmentQuestionSnapshot, bool) {
+	for i := range s.Questions {
+		if s.Questions[i].QuestionID == questionID {
+			return &s.Questions[i], true
+		}
+	}
+	return nil, false
+}
+
 func (Assessment) TableName() string {
 	return "assessments"
 }
@@ -79,3 +170,11 @@ func (Assessment) TableName() string {
 func (AssessmentSettings) TableName() string {
 	return "assessment_settings"
 }
+
+func (AssessmentReminder) TableName() string {
+	return "assessment_reminders"
+}
+
+func (AssessmentVersion) TableName() string {
+	return "assessment_versions"
+}
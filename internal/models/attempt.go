@@ -27,6 +27,9 @@ type AssessmentAttempt struct {
 	AttemptNumber int           `json:"attempt_number" gorm:"not null"`
 	Status        AttemptStatus `json:"status" gorm:"default:in_progress;index"`
 
+	// AssessmentVersion is the snapshot the attempt was started on, 0 for attempts that predate versioning
+	AssessmentVersion int `json:"assessment_version" gorm:"not null;default:0"`
+
 	// Timing
 	StartedAt     *time.Time `json:"started_at"`
 	EndedAt       *time.Time `json:"ended_at"`
@@ -50,8 +53,10 @@ type AssessmentAttempt struct {
 	// Metadata
 	IPAddress   *string        `json:"ip_address" gorm:"size:45"`
 	UserAgent   *string        `json:"user_agent" gorm:"type:text"`
-	SessionData datatypes.JSON `json:"session_data" gorm:"type:jsonb"` // Browser info, screen resolution, etc.
-	EndReason   *string        `json:"end_reason" gorm:"type:text"`    // e.g., "time_out", "abandoned", "completed"
+	SessionData datatypes.JSON `json:"session_data" gorm:"type:jsonb"` // AttemptSession: browser info, screen resolution, etc.
+	// SessionFlagged is set when the device fingerprint or IP address changed mid-attempt
+	SessionFlagged bool    `json:"session_flagged" gorm:"default:false;index"`
+	EndReason      *string `json:"end_reason" gorm:"type:text"` // e.g., "time_out", "abandoned", "completed"
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -66,6 +71,31 @@ type AssessmentAttempt struct {
 	gorm.Model `gorm:"uniqueIndex:idx_student_assessment_attempt"`
 }
 
+// AttemptClient identifies the device an attempt is taken on
+type AttemptClient struct {
+	IPAddress         string `json:"ip_address,omitempty"`
+	UserAgent         string `json:"user_agent,omitempty"`
+	ScreenResolution  string `json:"screen_resolution,omitempty"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"` // Supplied by the client
+}
+
+// AttemptSession is stored in AssessmentAttempt.SessionData
+type AttemptSession struct {
+	AttemptClient                        // Last seen client
+	FirstSeenAt   time.Time              `json:"first_seen_at"`
+	LastSeenAt    time.Time              `json:"last_seen_at"`
+	Heartbeats    int                    `json:"heartbeats"`
+	Changes       []AttemptSessionChange `json:"changes,omitempty"`
+}
+
+// AttemptSessionChange records the client switching device or network mid-attempt
+type AttemptSessionChange struct {
+	DetectedAt time.Time     `json:"detected_at"`
+	Fields     []string      `json:"fields"` // "ip_address", "device_fingerprint"
+	Previous   AttemptClient `json:"previous"`
+	Current    AttemptClient `json:"current"`
+}
+
 type StudentAnswer struct {
 	ID         uint `json:"id" gorm:"primaryKey"`
 	AttemptID  uint `json:"attempt_id" gorm:"not null;index"`
@@ -74,6 +104,9 @@ type StudentAnswer struct {
 	// Answer content (polymorphic based on question type)
 	Answer datatypes.JSON `json:"answer" gorm:"type:jsonb"`
 
+	// Variable values drawn for a templated question when the attempt started
+	Variant datatypes.JSON `json:"variant,omitempty" gorm:"type:jsonb"`
+
 	// Grading
 	Score     float64    `json:"score"`
 	MaxScore  int        `json:"max_score"`
@@ -90,8 +123,14 @@ type StudentAnswer struct {
 	// Metadata
 	AnswerHistory datatypes.JSON `json:"answer_history" gorm:"type:jsonb"` // Track changes
 	Flagged       bool           `json:"flagged"`                          // Student flagged for review
+	Confidence    *int           `json:"confidence"`                       // 1-3, for confidence-based marking
+	Omitted       bool           `json:"omitted"`                          // Student chose not to answer; scores zero, never negative
 	IsGraded      bool           `json:"is_graded"`                        // Whether the answer has been graded
 
+	// Provisional auto-grades (keyword-scored essays) stay open for a teacher to confirm or override
+	IsProvisional     bool     `json:"is_provisional" gorm:"default:false;index"`
+	GradingConfidence *float64 `json:"grading_confidence"` // 0-1
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -24,6 +24,7 @@ const (
 	AuditUserLogout          AuditEventType = "user_logout"
 	AuditPermissionChanged   AuditEventType = "permission_changed"
 	AuditDataExported        AuditEventType = "data_exported"
+	AuditDataImported        AuditEventType = "data_imported"
 	AuditProctoringViolation AuditEventType = "proctoring_violation"
 )
 
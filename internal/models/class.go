@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Class is a roster of students, e.g. a course section, that assessments can be assigned to
+type Class struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	Name        string  `json:"name" gorm:"not null;size:200" validate:"required,max=200"`
+	Code        *string `json:"code" gorm:"size:50;index"` // Optional course code, e.g. "CS101-A"
+	Description *string `json:"description" gorm:"type:text"`
+	IsActive    bool    `json:"is_active" gorm:"default:true;index"`
+
+	// Metadata
+	CreatedBy string    `json:"created_by" gorm:"not null;index;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Members []ClassMember `json:"members,omitempty" gorm:"foreignKey:ClassID;constraint:OnDelete:CASCADE"`
+	Creator User          `json:"-" gorm:"foreignKey:CreatedBy"`
+
+	// Statistics
+	MemberCount int `json:"member_count" gorm:"-"`
+}
+
+// ClassMember enrolls a student in a class
+type ClassMember struct {
+	ClassID   uint      `json:"class_id" gorm:"primaryKey"`
+	StudentID string    `json:"student_id" gorm:"primaryKey;size:255;index"`
+	AddedBy   string    `json:"added_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	Class Class `json:"-" gorm:"foreignKey:ClassID"`
+}
+
+// AssessmentAssignment makes an assessment available to a whole class or to a single student.
+// Exactly one of ClassID and StudentID is set.
+type AssessmentAssignment struct {
+	ID           uint    `json:"id" gorm:"primaryKey"`
+	AssessmentID uint    `json:"assessment_id" gorm:"not null;uniqueIndex:idx_assignment_class;uniqueIndex:idx_assignment_student"`
+	ClassID      *uint   `json:"class_id" gorm:"uniqueIndex:idx_assignment_class;index"`
+	StudentID    *string `json:"student_id" gorm:"size:255;uniqueIndex:idx_assignment_student;index"`
+
+	AssignedBy string    `json:"assigned_by" gorm:"not null;size:255"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	Assessment Assessment `json:"-" gorm:"foreignKey:AssessmentID;constraint:OnDelete:CASCADE"`
+	Class      *Class     `json:"class,omitempty" gorm:"foreignKey:ClassID;constraint:OnDelete:CASCADE"`
+}
@@ -45,7 +45,7 @@ type AssessmentSettingsRequest struct {
 }
 
 type QuestionCreateRequest struct {
-	Type        QuestionType    `json:"type" validate:"required,oneof=multiple_choice true_false essay fill_blank matching ordering short_answer"`
+	Type        QuestionType    `json:"type" validate:"required,oneof=multiple_choice true_false essay fill_blank matching ordering short_answer numeric formula"`
 	Text        string          `json:"text" validate:"required"`
 	Points      int             `json:"points" validate:"min=1,max=100"`
 	TimeLimit   *int            `json:"time_limit" validate:"omitempty,min=10,max=7200"` // DEPRECATED: Not used in timing logic
@@ -15,9 +15,12 @@ const (
 	GradingCustom     GradingSchemeType = "custom"
 )
 
+// GradingScheme either belongs to a single assessment or, with IsTemplate set and no
+// AssessmentID, is an organization-wide template that assessments copy from.
 type GradingScheme struct {
 	ID           uint              `json:"id" gorm:"primaryKey"`
-	AssessmentID uint              `json:"assessment_id" gorm:"not null;index"`
+	AssessmentID *uint             `json:"assessment_id" gorm:"index"`
+	IsTemplate   bool              `json:"is_template" gorm:"default:false;index"`
 	Name         string            `json:"name" gorm:"not null;size:100"`
 	Type         GradingSchemeType `json:"type" gorm:"not null"`
 
@@ -37,13 +40,15 @@ type GradingScheme struct {
 	BonusPoints   float64 `json:"bonus_points"`
 	CurvePoints   float64 `json:"curve_points"`
 
+	CreatedBy string    `json:"created_by" gorm:"not null;index;size:255"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// Relations
-	Assessment Assessment `json:"assessment" gorm:"foreignKey:AssessmentID"`
+	Assessment *Assessment `json:"assessment,omitempty" gorm:"foreignKey:AssessmentID"`
 }
 
+// GradeRange maps a percentage range (0-100) to a grade
 type GradeRange struct {
 	MinScore float64  `json:"min_score"`
 	MaxScore float64  `json:"max_score"`
@@ -42,6 +42,10 @@ type ImportJob struct {
 	Errors  datatypes.JSON `json:"errors" gorm:"type:jsonb"` // []ImportValidationError
 	Summary datatypes.JSON `json:"summary" gorm:"type:jsonb"`
 
+	// Worker claim; only the replica holding an unexpired claim processes the job
+	ClaimedBy      *string    `json:"-" gorm:"size:100"`
+	ClaimExpiresAt *time.Time `json:"-"`
+
 	// Timestamps
 	StartedAt   *time.Time `json:"started_at"`
 	CompletedAt *time.Time `json:"completed_at"`
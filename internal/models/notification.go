@@ -19,6 +19,11 @@ const (
 	NotificationQuestionBankShared  NotificationType = "question_bank_shared"
 	NotificationImportCompleted     NotificationType = "import_completed"
 	NotificationSystemMaintenance   NotificationType = "system_maintenance"
+	NotificationAttemptStarted      NotificationType = "attempt_started"
+	NotificationAttemptSubmitted    NotificationType = "attempt_submitted"
+	NotificationTimeWarning         NotificationType = "time_warning"
+	NotificationGradingCompleted    NotificationType = "grading_completed"
+	NotificationGradingRequired     NotificationType = "grading_required"
 
 	// Priority levels
 	PriorityLow      NotificationPriority = 1
@@ -63,3 +68,16 @@ type Notification struct {
 	Attempt    *AssessmentAttempt `json:"attempt" gorm:"foreignKey:AttemptID"`
 	Creator    User               `json:"creator" gorm:"foreignKey:CreatedBy"`
 }
+
+// NotificationReceipt keeps per-user read and dismiss state for role broadcasts,
+// which are stored once and shared by every user with the recipient role
+type NotificationReceipt struct {
+	NotificationID uint       `json:"notification_id" gorm:"primaryKey"`
+	UserID         string     `json:"user_id" gorm:"primaryKey;size:255"`
+	ReadAt         *time.Time `json:"read_at"`
+	DismissedAt    *time.Time `json:"dismissed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Relations
+	Notification Notification `json:"-" gorm:"foreignKey:NotificationID;constraint:OnDelete:CASCADE"`
+}
@@ -19,6 +19,15 @@ const (
 	EventRightClick       ProctoringEventType = "right_click"
 	EventCopyPaste        ProctoringEventType = "copy_paste"
 	EventScreenshot       ProctoringEventType = "screenshot"
+
+	// Recorded by the server, not reported by clients
+	EventSessionChange ProctoringEventType = "session_change"
+)
+
+const (
+	ProctoringReviewPending   = "pending"
+	ProctoringReviewReviewed  = "reviewed"
+	ProctoringReviewDismissed = "dismissed"
 )
 
 type ProctoringEvent struct {
@@ -43,7 +52,7 @@ type ProctoringEvent struct {
 
 	// Review status
 	ReviewStatus string     `json:"review_status" gorm:"default:pending"` // pending, reviewed, dismissed
-	ReviewedBy   *uint      `json:"reviewed_by"`
+	ReviewedBy   *string    `json:"reviewed_by" gorm:"size:255"`
 	ReviewedAt   *time.Time `json:"reviewed_at"`
 	ReviewNotes  *string    `json:"review_notes" gorm:"type:text"`
 
@@ -17,6 +17,8 @@ const (
 	Matching       QuestionType = "matching"
 	Ordering       QuestionType = "ordering"
 	ShortAnswer    QuestionType = "short_answer"
+	Numeric        QuestionType = "numeric"
+	Formula        QuestionType = "formula"
 )
 
 type DifficultyLevel string
@@ -27,6 +29,28 @@ const (
 	DifficultyHard   DifficultyLevel = "hard"
 )
 
+// ScoringRule selects how partial credit is computed for multi-part questions
+type ScoringRule string
+
+const (
+	ScoringAllOrNothing    ScoringRule = "all_or_nothing"
+	ScoringProportional    ScoringRule = "proportional"      // Multiple choice: share of options classified correctly
+	ScoringRightMinusWrong ScoringRule = "right_minus_wrong" // Multiple choice and matching: wrong choices cancel right ones
+	ScoringPerPair         ScoringRule = "per_pair"          // Matching: share of pairs matched correctly
+	ScoringPositional      ScoringRule = "positional"        // Ordering: share of items in their exact position
+	ScoringKendallTau      ScoringRule = "kendall_tau"       // Ordering: share of item pairs in the right relative order
+	ScoringLongestSequence ScoringRule = "longest_correct_subsequence"
+)
+
+// AnswerMatchType selects how an AnswerRule is compared with a typed answer
+type AnswerMatchType string
+
+const (
+	AnswerMatchText    AnswerMatchType = "text"    // Normalized text, or one of its synonyms
+	AnswerMatchRegex   AnswerMatchType = "regex"   // Regular expression that must match the whole answer
+	AnswerMatchNumeric AnswerMatchType = "numeric" // Number within a tolerance, with an optional unit
+)
+
 type Question struct {
 	ID        uint         `json:"id" gorm:"primaryKey"`
 	Type      QuestionType `json:"type" gorm:"not null;index"`
@@ -73,6 +97,10 @@ type AssessmentQuestion struct {
 	TimeLimit *int `json:"time_limit"` // DEPRECATED: Not used in timing logic. Use Assessment.Duration instead. Kept for backward compatibility.
 	Required  bool `json:"required" gorm:"default:true"`
 
+	// Negative marking overrides, nil uses the assessment settings
+	NegativeMarking      *NegativeMarkingMode `json:"negative_marking" gorm:"size:20"`
+	NegativeMarkingValue *float64             `json:"negative_marking_value"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -94,7 +122,7 @@ type QuestionCategory struct {
 	// Hierarchy support
 	ParentID *uint  `json:"parent_id" gorm:"index"`
 	Level    int    `json:"level" gorm:"default:0"`
-	Path     string `json:"path" gorm:"size:500"` // "/parent/child/grandchild"
+	Path     string `json:"path" gorm:"size:500;index"` // "/1/4/9": category IDs from the root down to this one
 
 	// Metadata
 	CreatedBy string    `json:"created_by" gorm:"not null;index;size:255"`
@@ -139,11 +167,12 @@ type QuestionAttachment struct {
 // ===== QUESTION CONTENT SCHEMAS =====
 
 type MultipleChoiceContent struct {
-	Options          []MCOption `json:"options" validate:"min=2,max=10"`
-	CorrectAnswers   []string   `json:"correct_answers" validate:"min=1"`
-	MultipleCorrect  bool       `json:"multiple_correct"`
-	RandomizeOptions bool       `json:"randomize_options"`
-	PartialCredit    bool       `json:"partial_credit"`
+	Options          []MCOption  `json:"options" validate:"min=2,max=10"`
+	CorrectAnswers   []string    `json:"correct_answers" validate:"min=1"`
+	MultipleCorrect  bool        `json:"multiple_correct"`
+	RandomizeOptions bool        `json:"randomize_options"`
+	PartialCredit    bool        `json:"partial_credit"`
+	ScoringRule      ScoringRule `json:"scoring_rule,omitempty"` // With PartialCredit; default right_minus_wrong
 }
 
 type MCOption struct {
@@ -166,20 +195,30 @@ type EssayContent struct {
 	RubricCriteria  []string `json:"rubric_criteria"`
 	SampleAnswer    *string  `json:"sample_answer"`
 	AutoGrade       bool     `json:"auto_grade"`
-	KeyWords        []string `json:"key_words"` // For auto-grading
+	KeyWords        []string `json:"key_words"` // For auto-grading; entries may be phrases
+
+	// Auto-grading tuning
+	Synonyms      map[string][]string `json:"synonyms,omitempty"`       // Key word -> alternatives that also count
+	LengthPenalty *float64            `json:"length_penalty,omitempty"` // Max share of the score lost outside MinWords/MaxWords, default 0.25
 }
 
 type FillBlankContent struct {
-	Template      string              `json:"template"` // "The capital of {blank1} is {blank2}"
-	Blanks        map[string]BlankDef `json:"blanks"`
-	CaseSensitive bool                `json:"case_sensitive"`
-	TrimSpaces    bool                `json:"trim_spaces"`
+	Template       string              `json:"template"` // "The capital of {blank1} is {blank2}"
+	Blanks         map[string]BlankDef `json:"blanks"`
+	CaseSensitive  bool                `json:"case_sensitive"`
+	TrimSpaces     bool                `json:"trim_spaces"`
+	FoldDiacritics bool                `json:"fold_diacritics,omitempty"` // Ignore accents, e.g. "Ha Noi" matches "Hà Nội"
 }
 
 type BlankDef struct {
 	AcceptedAnswers []string `json:"accepted_answers"`
 	Points          int      `json:"points"`
 	PlaceholderText *string  `json:"placeholder_text"`
+
+	// Matching beyond AcceptedAnswers
+	AnswerRules    []AnswerRule `json:"answer_rules,omitempty"`
+	FuzzyMatching  bool         `json:"fuzzy_matching,omitempty"`
+	FuzzyThreshold *float64     `json:"fuzzy_threshold,omitempty"` // Minimum similarity for partial credit, default 0.8
 }
 
 type MatchingContent struct {
@@ -189,6 +228,7 @@ type MatchingContent struct {
 	RandomizeLeft  bool        `json:"randomize_left"`
 	RandomizeRight bool        `json:"randomize_right"`
 	PartialCredit  bool        `json:"partial_credit"`
+	ScoringRule    ScoringRule `json:"scoring_rule,omitempty"` // With PartialCredit; default per_pair
 }
 
 type MatchItem struct {
@@ -207,6 +247,7 @@ type OrderingContent struct {
 	CorrectOrder  []string    `json:"correct_order"`
 	RandomizeInit bool        `json:"randomize_initial"`
 	PartialCredit bool        `json:"partial_credit"`
+	ScoringRule   ScoringRule `json:"scoring_rule,omitempty"` // With PartialCredit; default positional
 }
 
 type OrderItem struct {
@@ -222,4 +263,75 @@ type ShortAnswerContent struct {
 	MaxLength       int      `json:"max_length" validate:"min=1,max=500"`
 	PlaceholderText *string  `json:"placeholder_text"`
 	FuzzyMatching   bool     `json:"fuzzy_matching"`
+
+	// Matching beyond AcceptedAnswers
+	AnswerRules    []AnswerRule `json:"answer_rules,omitempty"`
+	FuzzyThreshold *float64     `json:"fuzzy_threshold,omitempty"` // Minimum similarity for partial credit, default 0.8
+	FoldDiacritics bool         `json:"fold_diacritics,omitempty"` // Ignore accents, e.g. "Ha Noi" matches "Hà Nội"
+}
+
+// NumericContent is a calculated answer graded within a tolerance
+type NumericContent struct {
+	CorrectValue      float64  `json:"correct_value"`
+	AbsoluteTolerance *float64 `json:"absolute_tolerance,omitempty"`
+	RelativeTolerance *float64 `json:"relative_tolerance,omitempty"` // Share of CorrectValue, 0.01 = 1%
+
+	// Significant figures; an answer with the right value but another number of figures loses
+	// SignificantFiguresPenalty of the credit, default 0.5
+	SignificantFigures        *int     `json:"significant_figures,omitempty"`
+	SignificantFiguresPenalty *float64 `json:"significant_figures_penalty,omitempty"`
+
+	Unit            string             `json:"unit,omitempty"`
+	UnitRequired    bool               `json:"unit_required"`
+	UnitFactors     map[string]float64 `json:"unit_factors,omitempty"` // Other accepted units -> factor to Unit
+	PlaceholderText *string            `json:"placeholder_text"`
+}
+
+// FormulaContent is an expression graded by algebraic equivalence: the student's expression must
+// agree with CorrectFormula at random sample points of the variables
+type FormulaContent struct {
+	CorrectFormula  string            `json:"correct_formula"` // e.g. "(x+1)^2"
+	Variables       []FormulaVariable `json:"variables"`
+	SamplePoints    int               `json:"sample_points,omitempty"` // Default 10
+	Tolerance       *float64          `json:"tolerance,omitempty"`     // Relative difference allowed per point, default 1e-6
+	PlaceholderText *string           `json:"placeholder_text"`
+}
+
+// FormulaVariable is a variable of a formula and the range its sample values are drawn from
+type FormulaVariable struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+}
+
+// QuestionTemplate makes a question algorithmic. It is declared under "template" in the content of
+// any question type; every attempt draws its own variable values, which replace {{expression}}
+// placeholders (or {{expression:decimals}}) in the question text and content.
+type QuestionTemplate struct {
+	Variables     []TemplateVariable `json:"variables"`
+	AnswerFormula string             `json:"answer_formula,omitempty"` // Numeric questions: computes correct_value
+}
+
+// TemplateVariable draws from Values when set, otherwise from Min to Max in steps of Step
+type TemplateVariable struct {
+	Name   string    `json:"name"`
+	Min    float64   `json:"min"`
+	Max    float64   `json:"max"`
+	Step   *float64  `json:"step,omitempty"` // Default 1
+	Values []float64 `json:"values,omitempty"`
+}
+
+// AnswerRule is an accepted answer of a short answer question or blank. Answers are compared
+// after Unicode normalization (NFC) and whitespace collapsing.
+type AnswerRule struct {
+	Type     AnswerMatchType `json:"type"`
+	Value    string          `json:"value"`              // Text, pattern or number
+	Synonyms []string        `json:"synonyms,omitempty"` // Text: other answers accepted as the same
+
+	// Numeric; without a tolerance the number must be exact
+	AbsoluteTolerance *float64           `json:"absolute_tolerance,omitempty"`
+	RelativeTolerance *float64           `json:"relative_tolerance,omitempty"` // Share of Value, 0.01 = 1%
+	Unit              string             `json:"unit,omitempty"`
+	UnitRequired      bool               `json:"unit_required,omitempty"`
+	UnitFactors       map[string]float64 `json:"unit_factors,omitempty"` // Other accepted units -> factor to Unit, e.g. "cm": 0.01 for "m"
 }
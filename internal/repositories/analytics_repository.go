@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AnalyticsRepository interface for materialized assessment and question analytics
+type AnalyticsRepository interface {
+	// Materialized analytics (one row per assessment/question, replaced on recalculation)
+	UpsertAssessmentAnalytics(ctx context.Context, tx *gorm.DB, analytics *models.AssessmentAnalytics) error
+	GetAssessmentAnalytics(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentAnalytics, error)
+	UpsertQuestionAnalytics(ctx context.Context, tx *gorm.DB, analytics *models.QuestionAnalytics) error
+	GetQuestionAnalytics(ctx context.Context, tx *gorm.DB, questionID uint) (*models.QuestionAnalytics, error)
+	GetQuestionAnalyticsByIDs(ctx context.Context, tx *gorm.DB, questionIDs []uint) ([]*models.QuestionAnalytics, error)
+
+	// Source data for recalculation
+	GetAttemptResults(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]AttemptResult, error)
+	GetQuestionResponses(ctx context.Context, tx *gorm.DB, questionID uint) ([]QuestionResponse, error)
+}
+
+// ===== ADDITIONAL STRUCTS =====
+
+// AttemptResult is the per-attempt input of assessment analytics
+type AttemptResult struct {
+	AttemptID  uint                 `json:"attempt_id"`
+	Status     models.AttemptStatus `json:"status"`
+	Score      float64              `json:"score"`
+	Percentage float64              `json:"percentage"`
+	Passed     bool                 `json:"passed"`
+	IsGraded   bool                 `json:"is_graded"`
+	TimeSpent  int                  `json:"time_spent"`
+}
+
+// QuestionResponse is a graded answer to a question together with the attempt's total result,
+// the input of item analysis
+type QuestionResponse struct {
+	AnswerID          uint           `json:"answer_id"`
+	AttemptID         uint           `json:"attempt_id"`
+	Answer            datatypes.JSON `json:"answer"`
+	Score             float64        `json:"score"`
+	MaxScore          int            `json:"max_score"`
+	IsCorrect         *bool          `json:"is_correct"`
+	TimeSpent         int            `json:"time_spent"`
+	AttemptPercentage float64        `json:"attempt_percentage"`
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"gorm.io/gorm"
@@ -14,7 +15,8 @@ type AssessmentRepository interface {
 	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Assessment, error)
 	GetByIDWithDetails(ctx context.Context, tx *gorm.DB, id uint) (*models.Assessment, error) // Include questions, settings
 	Update(ctx context.Context, tx *gorm.DB, assessment *models.Assessment) error
-	Delete(ctx context.Context, tx *gorm.DB, id uint) error // Soft delete
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error                            // Soft delete
+	RaiseVersion(ctx context.Context, tx *gorm.DB, id uint, version int) (bool, error) // False when already at or past version
 
 	// Query operations
 	List(ctx context.Context, tx *gorm.DB, filters AssessmentFilters) ([]*models.Assessment, int64, error)
@@ -27,6 +29,11 @@ type AssessmentRepository interface {
 	GetExpiredAssessments(ctx context.Context, tx *gorm.DB) ([]*models.Assessment, error)
 	BulkUpdateStatus(ctx context.Context, tx *gorm.DB, ids []uint, status models.AssessmentStatus) error
 
+	// Lifecycle scheduling
+	GetScheduledForPublish(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]*models.Assessment, error) // Drafts whose AvailableFrom has passed
+	GetDueWithin(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*models.Assessment, error)                    // Active with due date in (from, to]
+	MarkReminderSent(ctx context.Context, tx *gorm.DB, assessmentID uint, offsetMinutes int) (bool, error)              // False when already sent
+
 	// Permission checks
 	IsOwner(ctx context.Context, tx *gorm.DB, assessmentID uint, userID string) (bool, error)
 	CanAccess(ctx context.Context, tx *gorm.DB, assessmentID uint, userID string, role models.UserRole) (bool, error)
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AssessmentVersionRepository interface for assessment version snapshots. Versions are immutable,
+// there is no update or delete.
+type AssessmentVersionRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, version *models.AssessmentVersion) error
+	GetByVersion(ctx context.Context, tx *gorm.DB, assessmentID uint, version int) (*models.AssessmentVersion, error)
+	GetLatest(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentVersion, error)
+	ListByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentVersion, error) // Newest first, without snapshots
+}
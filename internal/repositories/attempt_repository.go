@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
@@ -38,9 +39,14 @@ type AttemptRepository interface {
 	GetInProgressAttempts(ctx context.Context, tx *gorm.DB) ([]*models.AssessmentAttempt, error)
 	GetTimedOutAttempts(ctx context.Context, tx *gorm.DB) ([]*models.AssessmentAttempt, error)
 	GetExpiredAttempts(ctx context.Context, tx *gorm.DB, cutoffTime time.Time) ([]*models.AssessmentAttempt, error)
+	GetOverdueAttempts(ctx context.Context, tx *gorm.DB, cutoffTime time.Time, limit int) ([]*models.AssessmentAttempt, error) // In progress with EndedAt before cutoff
+	MarkTimedOut(ctx context.Context, tx *gorm.DB, id uint, completedAt time.Time) (bool, error)                               // Only applies while still in progress
+	MarkSubmitted(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt) (bool, error)                           // Only applies while still in progress
+	ExtendEndTime(ctx context.Context, tx *gorm.DB, id uint, endedAt time.Time) (bool, error)                                  // Only applies while still in progress
 
 	// Progress tracking
 	UpdateProgress(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex, questionsAnswered int) error
+	UpdateNavigation(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex int, isReview bool) (bool, error) // Only applies while still in progress
 	GetProgress(ctx context.Context, tx *gorm.DB, id uint) (*AttemptProgress, error)
 
 	// Scoring and completion
@@ -61,6 +67,8 @@ type AttemptRepository interface {
 	// Session management
 	UpdateSessionData(ctx context.Context, tx *gorm.DB, id uint, sessionData interface{}) error
 	GetSessionData(ctx context.Context, tx *gorm.DB, id uint) (interface{}, error)
+	LockSessionData(ctx context.Context, tx *gorm.DB, id uint) (json.RawMessage, error) // Locks the attempt row until tx ends
+	FlagSession(ctx context.Context, tx *gorm.DB, id uint) error
 }
 
 // AnswerRepository interface for student answer operations
@@ -89,7 +97,7 @@ type AnswerRepository interface {
 	GetGradedAnswers(ctx context.Context, tx *gorm.DB, graderID string, filters AnswerFilters) ([]*models.StudentAnswer, error)
 
 	// Answer tracking
-	UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, newAnswer interface{}) error
+	UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, entry AnswerHistoryEntry, maxEntries int) error
 	GetAnswerHistory(ctx context.Context, tx *gorm.DB, id uint) ([]AnswerHistoryEntry, error)
 	FlagAnswer(ctx context.Context, tx *gorm.DB, id uint, flagged bool) error
 	GetFlaggedAnswers(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.StudentAnswer, error)
@@ -147,10 +155,23 @@ type StudentAttemptStats struct {
 	StatusBreakdown    map[models.AttemptStatus]int `json:"status_breakdown"`
 }
 
+// Answer history actions
+const (
+	AnswerActionCreated   = "created"   // first answer to the question
+	AnswerActionUpdated   = "updated"   // answer changed while the attempt was in progress
+	AnswerActionSubmitted = "submitted" // answer changed by the final submit
+	AnswerActionOmitted   = "omitted"   // answer withdrawn so the question scores zero
+)
+
+// AnswerHistoryEntry records one change of a student's answer
 type AnswerHistoryEntry struct {
-	Timestamp time.Time   `json:"timestamp"`
-	Answer    interface{} `json:"answer"`
-	Action    string      `json:"action"` // "created", "updated", "submitted"
+	Timestamp      time.Time       `json:"timestamp"`
+	Action         string          `json:"action"` // "created", "updated", "submitted"
+	PreviousAnswer json.RawMessage `json:"previous_answer,omitempty"`
+	Answer         json.RawMessage `json:"answer"`
+	OffsetSeconds  int             `json:"offset_seconds"` // since the attempt started
+	IPAddress      string          `json:"ip_address,omitempty"`
+	UserAgent      string          `json:"user_agent,omitempty"`
 }
 
 type AnswerStats struct {
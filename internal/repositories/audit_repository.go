@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepository interface for audit log operations
+type AuditRepository interface {
+	// Audit entries are append-only: no update or delete operations
+	Create(ctx context.Context, tx *gorm.DB, log *models.AuditLog) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AuditLog, error)
+
+	// Query operations
+	List(ctx context.Context, tx *gorm.DB, filters AuditLogFilters) ([]*models.AuditLog, int64, error)
+}
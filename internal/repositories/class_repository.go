@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ClassRepository interface for class rosters and assessment assignments.
+// A student is assigned an assessment when it is assigned to them directly, or to an
+// active class they are a member of.
+type ClassRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, class *models.Class) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Class, error)
+	GetByIDWithMembers(ctx context.Context, tx *gorm.DB, id uint) (*models.Class, error)
+	Update(ctx context.Context, tx *gorm.DB, class *models.Class) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+	List(ctx context.Context, tx *gorm.DB, filters ClassFilters) ([]*models.Class, int64, error)
+
+	// Membership
+	AddMembers(ctx context.Context, tx *gorm.DB, classID uint, studentIDs []string, addedBy string) (int64, error)
+	RemoveMember(ctx context.Context, tx *gorm.DB, classID uint, studentID string) error
+	GetMemberIDs(ctx context.Context, tx *gorm.DB, classID uint) ([]string, error)
+
+	// Assessment assignment
+	Assign(ctx context.Context, tx *gorm.DB, assignment *models.AssessmentAssignment) error
+	GetAssignment(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentAssignment, error)
+	DeleteAssignment(ctx context.Context, tx *gorm.DB, id uint) error
+	GetAssignments(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentAssignment, error)
+	IsAssigned(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (bool, error)
+	GetAssignedStudentIDs(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error)
+	GetAssignedAssessmentIDs(ctx context.Context, tx *gorm.DB, studentID string) ([]uint, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// GradingSchemeRepository interface for grading scheme operations
+type GradingSchemeRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, scheme *models.GradingScheme) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradingScheme, error)
+	Update(ctx context.Context, tx *gorm.DB, scheme *models.GradingScheme) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.GradingScheme, error)
+	ListTemplates(ctx context.Context, tx *gorm.DB) ([]*models.GradingScheme, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository interface for asynchronous question import jobs
+type ImportJobRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error
+	GetByID(ctx context.Context, tx *gorm.DB, id string) (*models.ImportJob, error)
+	Update(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error
+
+	// Progress tracking (lightweight update used by the worker while processing)
+	UpdateProgress(ctx context.Context, tx *gorm.DB, id string, processedRows, successCount, errorCount, progress int) error
+
+	// Worker claims, so only one replica processes a job
+	Claim(ctx context.Context, tx *gorm.DB, id, owner string, lease time.Duration) (bool, error) // Takes or renews the claim; false while another owner holds it
+	ReleaseClaim(ctx context.Context, tx *gorm.DB, id, owner string) error
+
+	// Query operations
+	ListByUser(ctx context.Context, tx *gorm.DB, userID string, filters ImportJobFilters) ([]*models.ImportJob, int64, error)
+	ListByStatus(ctx context.Context, tx *gorm.DB, statuses []models.ImportJobStatus) ([]*models.ImportJob, error)
+}
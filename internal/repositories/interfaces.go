@@ -9,46 +9,50 @@ import (
 // ===== SHARED FILTER STRUCTS =====
 
 type AssessmentFilters struct {
-	Status    *models.AssessmentStatus `json:"status"`
-	CreatedBy *string                  `json:"created_by"`
-	DateFrom  *time.Time               `json:"date_from"`
-	DateTo    *time.Time               `json:"date_to"`
-	Limit     int                      `json:"limit"`
-	Offset    int                      `json:"offset"`
-	SortBy    string                   `json:"sort_by"`    // "created_at", "title", "due_date"
-	SortOrder string                   `json:"sort_order"` // "asc", "desc"
+	Status     *models.AssessmentStatus `json:"status"`
+	CreatedBy  *string                  `json:"created_by"`
+	AssignedTo *string                  `json:"assigned_to"` // Student ID; matches direct and class assignments
+	DateFrom   *time.Time               `json:"date_from"`
+	DateTo     *time.Time               `json:"date_to"`
+	Limit      int                      `json:"limit"`
+	Offset     int                      `json:"offset"`
+	SortBy     string                   `json:"sort_by"`    // "created_at", "title", "due_date"
+	SortOrder  string                   `json:"sort_order"` // "asc", "desc"
 }
 
 type QuestionFilters struct {
-	Type       *models.QuestionType    `json:"type"`
-	Difficulty *models.DifficultyLevel `json:"difficulty"`
-	CategoryID *uint                   `json:"category_id"`
-	CreatedBy  *string                 `json:"created_by"`
-	Tags       []string                `json:"tags"`
-	Limit      int                     `json:"limit"`
-	Offset     int                     `json:"offset"`
-	SortBy     string                  `json:"sort_by"`
-	SortOrder  string                  `json:"sort_order"`
+	Type                 *models.QuestionType    `json:"type"`
+	Difficulty           *models.DifficultyLevel `json:"difficulty"`
+	CategoryID           *uint                   `json:"category_id"`
+	IncludeSubcategories bool                    `json:"include_subcategories"` // Match CategoryID's whole subtree
+	CreatedBy            *string                 `json:"created_by"`
+	Tags                 []string                `json:"tags"`
+	Limit                int                     `json:"limit"`
+	Offset               int                     `json:"offset"`
+	SortBy               string                  `json:"sort_by"`
+	SortOrder            string                  `json:"sort_order"`
 }
 
 type RandomQuestionFilters struct {
-	CategoryID *uint                   `json:"category_id"`
-	Difficulty *models.DifficultyLevel `json:"difficulty"`
-	Type       *models.QuestionType    `json:"type"`
-	ExcludeIDs []uint                  `json:"exclude_ids"`
-	Count      int                     `json:"count"`
+	CategoryID           *uint                   `json:"category_id"`
+	IncludeSubcategories bool                    `json:"include_subcategories"`
+	Difficulty           *models.DifficultyLevel `json:"difficulty"`
+	Type                 *models.QuestionType    `json:"type"`
+	ExcludeIDs           []uint                  `json:"exclude_ids"`
+	Count                int                     `json:"count"`
 }
 
 type AttemptFilters struct {
-	Status        *models.AttemptStatus `json:"status"`
-	UserID        *string               `json:"user_id"`
-	DateFrom      *time.Time            `json:"date_from"`
-	DateTo        *time.Time            `json:"date_to"`
-	Limit         int                   `json:"limit"`
-	Offset        int                   `json:"offset"`
-	SortBy        string                `json:"sort_by"`    // "created_at", "title", "due_date"
-	SortOrder     string                `json:"sort_order"` // "asc", "desc"
-	IsTeacherView bool                  `json:"is_teacher_view"`
+	Status         *models.AttemptStatus `json:"status"`
+	UserID         *string               `json:"user_id"`
+	DateFrom       *time.Time            `json:"date_from"`
+	DateTo         *time.Time            `json:"date_to"`
+	SessionFlagged *bool                 `json:"session_flagged"`
+	Limit          int                   `json:"limit"`
+	Offset         int                   `json:"offset"`
+	SortBy         string                `json:"sort_by"`    // "created_at", "title", "due_date"
+	SortOrder      string                `json:"sort_order"` // "asc", "desc"
+	IsTeacherView  bool                  `json:"is_teacher_view"`
 }
 
 type AnswerFilters struct {
@@ -60,6 +64,53 @@ type AnswerFilters struct {
 	Offset   int        `json:"offset"`
 }
 
+type ProctoringEventFilters struct {
+	Type         *models.ProctoringEventType `json:"type"`
+	Severity     *int                        `json:"severity"`
+	MinSeverity  *int                        `json:"min_severity"`
+	ReviewStatus *string                     `json:"review_status"`
+	DateFrom     *time.Time                  `json:"date_from"`
+	DateTo       *time.Time                  `json:"date_to"`
+	Limit        int                         `json:"limit"`
+	Offset       int                         `json:"offset"`
+	SortBy       string                      `json:"sort_by"`    // "created_at", "severity", "type"
+	SortOrder    string                      `json:"sort_order"` // "asc", "desc"
+}
+
+type AuditLogFilters struct {
+	UserID     *string                `json:"user_id"`
+	TargetType *string                `json:"target_type"`
+	TargetID   *uint                  `json:"target_id"`
+	EventType  *models.AuditEventType `json:"event_type"`
+	DateFrom   *time.Time             `json:"date_from"`
+	DateTo     *time.Time             `json:"date_to"`
+	Limit      int                    `json:"limit"`
+	Offset     int                    `json:"offset"`
+	SortOrder  string                 `json:"sort_order"` // "asc", "desc" (by created_at)
+}
+
+type ImportJobFilters struct {
+	Status *models.ImportJobStatus `json:"status"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
+
+type NotificationFilters struct {
+	Read   *bool                    `json:"read"`
+	Type   *models.NotificationType `json:"type"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+type ClassFilters struct {
+	CreatedBy *string `json:"created_by"`
+	StudentID *string `json:"student_id"` // Classes the student is a member of
+	Query     string  `json:"query"`      // Matches name or code
+	IsActive  *bool   `json:"is_active"`
+	Limit     int     `json:"limit"`
+	Offset    int     `json:"offset"`
+}
+
 // ===== SHARED HELPER STRUCTS =====
 
 type QuestionOrder struct {
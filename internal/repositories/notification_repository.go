@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository interface for the in-app notification inbox.
+// A notification is visible to a user when it is addressed to them directly, or when it
+// is a role broadcast (no recipient ID) for their role; scheduled and expired rows are hidden.
+type NotificationRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, notification *models.Notification) error
+	CreateBatch(ctx context.Context, tx *gorm.DB, notifications []*models.Notification) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Inbox queries (ReadAt of role broadcasts is filled from the user's receipt)
+	GetForUser(ctx context.Context, tx *gorm.DB, id uint, userID string, role models.UserRole) (*models.Notification, error)
+	ListForUser(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole, filters NotificationFilters) ([]*models.Notification, int64, error)
+	CountUnread(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole) (int64, error)
+
+	// Read state
+	MarkRead(ctx context.Context, tx *gorm.DB, id uint, userID string) error
+	MarkBroadcastRead(ctx context.Context, tx *gorm.DB, id uint, userID string) error
+	MarkAllRead(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole) (int64, error)
+
+	// Dismiss hides a role broadcast for a single user
+	DismissBroadcast(ctx context.Context, tx *gorm.DB, id uint, userID string) error
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnalyticsPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsPostgreSQL(db *gorm.DB) repositories.AnalyticsRepository {
+	return &AnalyticsPostgreSQL{db: db}
+}
+
+// ===== MATERIALIZED ANALYTICS =====
+
+func (a *AnalyticsPostgreSQL) UpsertAssessmentAnalytics(ctx context.Context, tx *gorm.DB, analytics *models.AssessmentAnalytics) error {
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "assessment_id"}},
+			DoUpdates: clause.AssignmentColumns(assessmentAnalyticsColumns),
+		}).
+		Create(analytics).Error; err != nil {
+		return handleDBError(err, "upsert assessment analytics")
+	}
+	return nil
+}
+
+func (a *AnalyticsPostgreSQL) GetAssessmentAnalytics(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentAnalytics, error) {
+	db := a.getDB(tx)
+	var analytics models.AssessmentAnalytics
+	if err := db.WithContext(ctx).Where("assessment_id = ?", assessmentID).First(&analytics).Error; err != nil {
+		return nil, handleDBError(err, "get assessment analytics")
+	}
+	return &analytics, nil
+}
+
+func (a *AnalyticsPostgreSQL) UpsertQuestionAnalytics(ctx context.Context, tx *gorm.DB, analytics *models.QuestionAnalytics) error {
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "question_id"}},
+			DoUpdates: clause.AssignmentColumns(questionAnalyticsColumns),
+		}).
+		Create(analytics).Error; err != nil {
+		return handleDBError(err, "upsert question analytics")
+	}
+	return nil
+}
+
+func (a *AnalyticsPostgreSQL) GetQuestionAnalytics(ctx context.Context, tx *gorm.DB, questionID uint) (*models.QuestionAnalytics, error) {
+	db := a.getDB(tx)
+	var analytics models.QuestionAnalytics
+	if err := db.WithContext(ctx).Where("question_id = ?", questionID).First(&analytics).Error; err != nil {
+		return nil, handleDBError(err, "get question analytics")
+	}
+	return &analytics, nil
+}
+
+func (a *AnalyticsPostgreSQL) GetQuestionAnalyticsByIDs(ctx context.Context, tx *gorm.DB, questionIDs []uint) ([]*models.QuestionAnalytics, error) {
+	var analytics []*models.QuestionAnalytics
+	if len(questionIDs) == 0 {
+		return analytics, nil
+	}
+
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).
+		Where("question_id IN ?", questionIDs).
+		Order("question_id ASC").
+		Find(&analytics).Error; err != nil {
+		return nil, handleDBError(err, "get question analytics by ids")
+	}
+	return analytics, nil
+}
+
+// ===== SOURCE DATA =====
+
+func (a *AnalyticsPostgreSQL) GetAttemptResults(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.AttemptResult, error) {
+	db := a.getDB(tx)
+	var results []repositories.AttemptResult
+	if err := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Select("id AS attempt_id, status, score, percentage, passed, is_graded, time_spent").
+		Where("assessment_id = ?", assessmentID).
+		Scan(&results).Error; err != nil {
+		return nil, handleDBError(err, "get attempt results")
+	}
+	return results, nil
+}
+
+func (a *AnalyticsPostgreSQL) GetQuestionResponses(ctx context.Context, tx *gorm.DB, questionID uint) ([]repositories.QuestionResponse, error) {
+	db := a.getDB(tx)
+	var responses []repositories.QuestionResponse
+	if err := db.WithContext(ctx).Model(&models.StudentAnswer{}).
+		Select(`student_answers.id AS answer_id, student_answers.attempt_id, student_answers.answer,
+			student_answers.score, student_answers.max_score, student_answers.is_correct,
+			student_answers.time_spent, assessment_attempts.percentage AS attempt_percentage`).
+		Joins("JOIN assessment_attempts ON assessment_attempts.id = student_answers.attempt_id").
+		Where("student_answers.question_id = ?", questionID).
+		Where("student_answers.is_graded = ?", true).
+		Where("assessment_attempts.is_graded = ?", true).
+		Where("assessment_attempts.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("assessment_attempts.deleted_at IS NULL").
+		Scan(&responses).Error; err != nil {
+		return nil, handleDBError(err, "get question responses")
+	}
+	return responses, nil
+}
+
+// ===== HELPER METHODS =====
+
+// Columns replaced when a recalculated analytics row conflicts with an existing one
+var assessmentAnalyticsColumns = []string{
+	"total_attempts", "completed_attempts", "abandoned_attempts",
+	"average_score", "median_score", "highest_score", "lowest_score", "standard_deviation",
+	"average_time_spent", "median_time_spent",
+	"pass_rate", "passed_count", "failed_count",
+	"score_distribution", "time_distribution",
+	"last_calculated_at", "updated_at",
+}
+
+var questionAnalyticsColumns = []string{
+	"total_responses", "correct_responses",
+	"difficulty_index", "discrimination_index",
+	"average_score", "average_time_spent",
+	"option_stats",
+	"top_quartile_correct", "bottom_quartile_correct",
+	"last_calculated_at", "updated_at",
+}
+
+func (a *AnalyticsPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return a.db
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AssessmentPostgreSQL struct {
@@ -140,18 +141,26 @@ func (a *AssessmentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, assessme
 	//assessment.Version = currentAssessment.Version + 1
 	//assessment.UpdatedAt = time.Now()
 
+	// Reminders sent for the old due date, whoever moves or clears it, must fire again for the new one
+	changedDueDate := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Assessment{}).Select("id").
+		Where("id = ? AND due_date IS DISTINCT FROM ?", assessment.ID, assessment.DueDate)
+	if err := tx.WithContext(ctx).Where("assessment_id IN (?)", changedDueDate).Delete(&models.AssessmentReminder{}).Error; err != nil {
+		return fmt.Errorf("failed to reset due date reminders: %w", err)
+	}
+
 	// Update assessment
 	if err := tx.WithContext(ctx).Model(&models.Assessment{}).Where("id = ?", assessment.ID).Updates(map[string]interface{}{
-		"title":         assessment.Title,
-		"description":   assessment.Description,
-		"duration":      assessment.Duration,
-		"max_attempts":  assessment.MaxAttempts,
-		"passing_score": assessment.PassingScore,
-		"time_warning":  assessment.TimeWarning,
-		"due_date":      assessment.DueDate,
-		"status":        assessment.Status,
-		"version":       assessment.Version,
-		"updated_at":    assessment.UpdatedAt,
+		"title":          assessment.Title,
+		"description":    assessment.Description,
+		"duration":       assessment.Duration,
+		"max_attempts":   assessment.MaxAttempts,
+		"passing_score":  assessment.PassingScore,
+		"time_warning":   assessment.TimeWarning,
+		"available_from": assessment.AvailableFrom,
+		"due_date":       assessment.DueDate,
+		"status":         assessment.Status,
+		"version":        assessment.Version,
+		"updated_at":     assessment.UpdatedAt,
 	}).Error; err != nil {
 		return fmt.Errorf("failed to update assessment: %w", err)
 	}
@@ -276,6 +285,31 @@ func (a *AssessmentPostgreSQL) Search(ctx context.Context, tx *gorm.DB, query st
 	return assessments, total, nil
 }
 
+// RaiseVersion moves the version of an assessment up to version without touching its other columns,
+// so it cannot undo a concurrent edit
+func (a *AssessmentPostgreSQL) RaiseVersion(ctx context.Context, tx *gorm.DB, id uint, version int) (bool, error) {
+	db := a.getDB(tx)
+
+	result := db.WithContext(ctx).
+		Model(&models.Assessment{}).
+		Where("id = ? AND version < ?", id, version).
+		Update("version", version)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	var assessment models.Assessment
+	if err := db.WithContext(ctx).Select("id, created_by").First(&assessment, id).Error; err != nil {
+		return false, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	cache.InvalidateAssessmentCache(ctx, a.cacheManager, id, assessment.CreatedBy)
+
+	return true, nil
+}
+
 // UpdateStatus updates the status of an assessment
 func (a *AssessmentPostgreSQL) UpdateStatus(ctx context.Context, tx *gorm.DB, id uint, status models.AssessmentStatus) error {
 	db := a.getDB(tx)
@@ -340,6 +374,48 @@ func (a *AssessmentPostgreSQL) GetAssessmentsNearExpiry(ctx context.Context, wit
 	return assessments, err
 }
 
+// GetScheduledForPublish retrieves draft assessments whose scheduled publish time has passed
+func (a *AssessmentPostgreSQL) GetScheduledForPublish(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]*models.Assessment, error) {
+	db := a.getDB(tx)
+	var assessments []*models.Assessment
+	err := db.WithContext(ctx).
+		Where("status = ? AND available_from IS NOT NULL AND available_from <= ?", models.StatusDraft, before).
+		Order("available_from ASC").
+		Limit(limit).
+		Find(&assessments).Error
+
+	return assessments, err
+}
+
+// GetDueWithin retrieves active assessments due after from and no later than to
+func (a *AssessmentPostgreSQL) GetDueWithin(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*models.Assessment, error) {
+	db := a.getDB(tx)
+	var assessments []*models.Assessment
+	err := db.WithContext(ctx).
+		Where("status = ? AND due_date > ? AND due_date <= ?", models.StatusActive, from, to).
+		Order("due_date ASC").
+		Find(&assessments).Error
+
+	return assessments, err
+}
+
+// MarkReminderSent claims the reminder for the given offset; it reports false if it was already sent
+func (a *AssessmentPostgreSQL) MarkReminderSent(ctx context.Context, tx *gorm.DB, assessmentID uint, offsetMinutes int) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.AssessmentReminder{
+			AssessmentID:  assessmentID,
+			OffsetMinutes: offsetMinutes,
+			SentAt:        time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // BulkUpdateStatus updates the status of multiple assessments
 func (a *AssessmentPostgreSQL) BulkUpdateStatus(ctx context.Context, tx *gorm.DB, ids []uint, status models.AssessmentStatus) error {
 	return a.helpers.BulkUpdateAssessmentStatus(ctx, ids, status)
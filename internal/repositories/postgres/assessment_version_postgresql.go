@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AssessmentVersionPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAssessmentVersionPostgreSQL(db *gorm.DB) repositories.AssessmentVersionRepository {
+	return &AssessmentVersionPostgreSQL{db: db}
+}
+
+func (v *AssessmentVersionPostgreSQL) Create(ctx context.Context, tx *gorm.DB, version *models.AssessmentVersion) error {
+	db := v.getDB(tx)
+	if err := db.WithContext(ctx).Create(version).Error; err != nil {
+		return handleDBError(err, "create assessment version")
+	}
+	return nil
+}
+
+func (v *AssessmentVersionPostgreSQL) GetByVersion(ctx context.Context, tx *gorm.DB, assessmentID uint, version int) (*models.AssessmentVersion, error) {
+	db := v.getDB(tx)
+	var assessmentVersion models.AssessmentVersion
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND version = ?", assessmentID, version).
+		First(&assessmentVersion).Error; err != nil {
+		return nil, handleDBError(err, "get assessment version")
+	}
+	return &assessmentVersion, nil
+}
+
+func (v *AssessmentVersionPostgreSQL) GetLatest(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentVersion, error) {
+	db := v.getDB(tx)
+	var assessmentVersion models.AssessmentVersion
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("version DESC").
+		First(&assessmentVersion).Error; err != nil {
+		return nil, handleDBError(err, "get latest assessment version")
+	}
+	return &assessmentVersion, nil
+}
+
+func (v *AssessmentVersionPostgreSQL) ListByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentVersion, error) {
+	db := v.getDB(tx)
+	var versions []*models.AssessmentVersion
+	if err := db.WithContext(ctx).
+		Omit("snapshot").
+		Where("assessment_id = ?", assessmentID).
+		Order("version DESC").
+		Find(&versions).Error; err != nil {
+		return nil, handleDBError(err, "list assessment versions")
+	}
+	return versions, nil
+}
+
+// getDB returns the transaction DB if provided, otherwise returns the default DB
+func (v *AssessmentVersionPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return v.db
+}
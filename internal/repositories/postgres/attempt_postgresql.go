@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type AttemptPostgreSQL struct {
@@ -277,6 +279,71 @@ func (a *AttemptPostgreSQL) GetExpiredAttempts(ctx context.Context, tx *gorm.DB,
 	return attempts, nil
 }
 
+// GetOverdueAttempts returns in-progress attempts whose time ran out before cutoffTime, oldest first
+func (a *AttemptPostgreSQL) GetOverdueAttempts(ctx context.Context, tx *gorm.DB, cutoffTime time.Time, limit int) ([]*models.AssessmentAttempt, error) {
+	db := a.getDB(tx)
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Where("status = ? AND ended_at IS NOT NULL AND ended_at <= ?", models.AttemptInProgress, cutoffTime).
+		Order("ended_at ASC").
+		Limit(limit).
+		Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// MarkTimedOut closes the attempt as timed out. It reports false when the attempt was no
+// longer in progress, so a concurrent submit and timeout never both win.
+func (a *AttemptPostgreSQL) MarkTimedOut(ctx context.Context, tx *gorm.DB, id uint, completedAt time.Time) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ? AND status = ?", id, models.AttemptInProgress).
+		Updates(map[string]interface{}{
+			"status":       models.AttemptTimeOut,
+			"end_reason":   models.AttemptEndReasonTimeout,
+			"completed_at": completedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// MarkSubmitted closes the attempt with the status, end reason and time spent set on it. It
+// reports false when the attempt was no longer in progress, e.g. timed out by the sweeper.
+func (a *AttemptPostgreSQL) MarkSubmitted(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ? AND status = ?", attempt.ID, models.AttemptInProgress).
+		Updates(map[string]interface{}{
+			"status":       attempt.Status,
+			"end_reason":   attempt.EndReason,
+			"completed_at": attempt.CompletedAt,
+			"time_spent":   attempt.TimeSpent,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// ExtendEndTime moves the end time of an attempt that is still in progress
+func (a *AttemptPostgreSQL) ExtendEndTime(ctx context.Context, tx *gorm.DB, id uint, endedAt time.Time) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ? AND status = ?", id, models.AttemptInProgress).
+		Update("ended_at", endedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 func (a *AttemptPostgreSQL) UpdateProgress(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex, questionsAnswered int) error {
 	db := a.getDB(tx)
 	return db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
@@ -287,6 +354,22 @@ func (a *AttemptPostgreSQL) UpdateProgress(ctx context.Context, tx *gorm.DB, id
 		}).Error
 }
 
+// UpdateNavigation moves the student to a question or into review mode while the attempt is in progress
+func (a *AttemptPostgreSQL) UpdateNavigation(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex int, isReview bool) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ? AND status = ?", id, models.AttemptInProgress).
+		Updates(map[string]interface{}{
+			"current_question_index": currentQuestionIndex,
+			"is_review":              isReview,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 func (a *AttemptPostgreSQL) GetProgress(ctx context.Context, tx *gorm.DB, id uint) (*repositories.AttemptProgress, error) {
 	db := a.getDB(tx)
 	var attempt models.AssessmentAttempt
@@ -552,6 +635,29 @@ func (a *AttemptPostgreSQL) GetSessionData(ctx context.Context, tx *gorm.DB, id
 	return sessionData, nil
 }
 
+// LockSessionData reads the session data and locks the attempt row, so concurrent requests of the
+// same attempt update the session one after another
+func (a *AttemptPostgreSQL) LockSessionData(ctx context.Context, tx *gorm.DB, id uint) (json.RawMessage, error) {
+	db := a.getDB(tx)
+	var attempt models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "session_data").
+		First(&attempt, id).Error; err != nil {
+		return nil, err
+	}
+	return json.RawMessage(attempt.SessionData), nil
+}
+
+// FlagSession marks the attempt as taken from more than one device or network
+func (a *AttemptPostgreSQL) FlagSession(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := a.getDB(tx)
+	return db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("id = ?", id).
+		Update("session_flagged", true).Error
+}
+
 // applyFiltersAttempt applies common filters to a query
 func (a *AttemptPostgreSQL) applyFiltersAttempt(query *gorm.DB, filters repositories.AttemptFilters) *gorm.DB {
 	return a.helpers.ApplyAttemptFilters(query, filters)
@@ -659,21 +765,25 @@ func (ar *AnswerPostgreSQL) Update(ctx context.Context, tx *gorm.DB, answer *mod
 	}
 	// Use Updates instead of Save to avoid cascading to associations
 	// This prevents foreign key constraint errors when associations are loaded
+	// answer_history is left out, it is append-only through UpdateAnswerHistory
 	if err := db.WithContext(ctx).Model(newAnswer).Updates(map[string]interface{}{
-		"answer":            answer.Answer,
-		"score":             answer.Score,
-		"max_score":         answer.MaxScore,
-		"is_correct":        answer.IsCorrect,
-		"graded_by":         answer.GradedBy,
-		"graded_at":         answer.GradedAt,
-		"feedback":          answer.Feedback,
-		"time_spent":        answer.TimeSpent,
-		"first_answered_at": answer.FirstAnsweredAt,
-		"last_modified_at":  answer.LastModifiedAt,
-		"answer_history":    answer.AnswerHistory,
-		"flagged":           answer.Flagged,
-		"is_graded":         answer.IsGraded,
-		"updated_at":        answer.UpdatedAt,
+		"answer":             answer.Answer,
+		"score":              answer.Score,
+		"max_score":          answer.MaxScore,
+		"is_correct":         answer.IsCorrect,
+		"graded_by":          answer.GradedBy,
+		"graded_at":          answer.GradedAt,
+		"feedback":           answer.Feedback,
+		"time_spent":         answer.TimeSpent,
+		"first_answered_at":  answer.FirstAnsweredAt,
+		"last_modified_at":   answer.LastModifiedAt,
+		"flagged":            answer.Flagged,
+		"confidence":         answer.Confidence,
+		"omitted":            answer.Omitted,
+		"is_graded":          answer.IsGraded,
+		"is_provisional":     answer.IsProvisional,
+		"grading_confidence": answer.GradingConfidence,
+		"updated_at":         answer.UpdatedAt,
 	}).Error; err != nil {
 		return fmt.Errorf("failed to update answer: %w", err)
 	}
@@ -750,19 +860,20 @@ func (ar *AnswerPostgreSQL) UpdateBatch(ctx context.Context, tx *gorm.DB, answer
 		// Batch update using GORM
 		for _, answer := range answers {
 			if err := txInner.Model(&models.StudentAnswer{}).Where("id = ?", answer.ID).Updates(map[string]interface{}{
-				"answer":            answer.Answer,
-				"score":             answer.Score,
-				"max_score":         answer.MaxScore,
-				"is_correct":        answer.IsCorrect,
-				"graded_by":         answer.GradedBy,
-				"graded_at":         answer.GradedAt,
-				"feedback":          answer.Feedback,
-				"time_spent":        answer.TimeSpent,
-				"first_answered_at": answer.FirstAnsweredAt,
-				"last_modified_at":  answer.LastModifiedAt,
-				"answer_history":    answer.AnswerHistory,
-				"flagged":           answer.Flagged,
-				"is_graded":         answer.IsGraded,
+				"answer":             answer.Answer,
+				"score":              answer.Score,
+				"max_score":          answer.MaxScore,
+				"is_correct":         answer.IsCorrect,
+				"graded_by":          answer.GradedBy,
+				"graded_at":          answer.GradedAt,
+				"feedback":           answer.Feedback,
+				"time_spent":         answer.TimeSpent,
+				"first_answered_at":  answer.FirstAnsweredAt,
+				"last_modified_at":   answer.LastModifiedAt,
+				"flagged":            answer.Flagged,
+				"is_graded":          answer.IsGraded,
+				"is_provisional":     answer.IsProvisional,
+				"grading_confidence": answer.GradingConfidence,
 			}).Error; err != nil {
 				return fmt.Errorf("failed to update answer ID %d: %w", answer.ID, err)
 			}
@@ -937,7 +1048,7 @@ func (ar *AnswerPostgreSQL) GetPendingGrading(ctx context.Context, tx *gorm.DB,
 	if err := db.WithContext(ctx).
 		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
 		Joins("JOIN assessments a ON a.id = aa.assessment_id").
-		Where("a.created_by = ? AND student_answers.graded_at IS NULL", teacherID).
+		Where("a.created_by = ? AND (student_answers.graded_at IS NULL OR student_answers.is_provisional)", teacherID).
 		Preload("Attempt").
 		Preload("Question").
 		Find(&answers).Error; err != nil {
@@ -963,43 +1074,58 @@ func (ar *AnswerPostgreSQL) GetGradedAnswers(ctx context.Context, tx *gorm.DB, g
 
 // ===== ANSWER TRACKING =====
 
-// UpdateAnswerHistory updates the history of answer changes
-func (ar *AnswerPostgreSQL) UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, newAnswer interface{}) error {
+// UpdateAnswerHistory appends an entry to the answer's history, keeping the newest maxEntries.
+// The append happens in a single statement so concurrent autosaves do not drop entries.
+func (ar *AnswerPostgreSQL) UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, entry repositories.AnswerHistoryEntry, maxEntries int) error {
 	db := ar.getDB(tx)
-	// Get current answer
-	_, err := ar.GetByID(ctx, tx, id)
-	if err != nil {
-		return err
-	}
-
-	// Create history entry
-	_ = repositories.AnswerHistoryEntry{
-		Timestamp: time.Now(),
-		Answer:    newAnswer,
-		Action:    "updated",
-	}
 
-	// In a full implementation, you would store this in a separate history table
-	// For now, we'll just update the last_modified_at field
-	now := time.Now()
-	if err := db.WithContext(ctx).
-		Model(&models.StudentAnswer{}).
-		Where("id = ?", id).
-		Update("last_modified_at", &now).Error; err != nil {
-		return fmt.Errorf("failed to update answer history: %w", err)
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal answer history entry: %w", err)
+	}
+
+	result := db.WithContext(ctx).Exec(`
+		UPDATE student_answers SET answer_history = (
+			SELECT COALESCE(jsonb_agg(kept.entry ORDER BY kept.position), '[]'::jsonb)
+			FROM (
+				SELECT h.entry, h.position
+				FROM jsonb_array_elements(
+					CASE WHEN jsonb_typeof(answer_history) = 'array' THEN answer_history ELSE '[]'::jsonb END
+					|| jsonb_build_array(?::jsonb)
+				) WITH ORDINALITY AS h(entry, position)
+				ORDER BY h.position DESC
+				LIMIT ?
+			) kept
+		)
+		WHERE id = ?`, string(encoded), maxEntries, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update answer history: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("answer not found with ID %d: %w", id, gorm.ErrRecordNotFound)
 	}
 
-	// Invalidate cache
-	// ar.cacheManager.Fast.Delete(ctx, fmt.Sprintf("answer:id:%d", id))
-
 	return nil
 }
 
-// GetAnswerHistory retrieves the history of answer changes
+// GetAnswerHistory retrieves the history of answer changes, oldest first
 func (ar *AnswerPostgreSQL) GetAnswerHistory(ctx context.Context, tx *gorm.DB, id uint) ([]repositories.AnswerHistoryEntry, error) {
-	// This would require a separate answer_history table in a full implementation
-	// For now, return empty history
-	return []repositories.AnswerHistoryEntry{}, nil
+	db := ar.getDB(tx)
+
+	var answer models.StudentAnswer
+	if err := db.WithContext(ctx).Select("id", "answer_history").First(&answer, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer history: %w", err)
+	}
+
+	history := []repositories.AnswerHistoryEntry{}
+	if len(answer.AnswerHistory) == 0 {
+		return history, nil
+	}
+	if err := json.Unmarshal(answer.AnswerHistory, &history); err != nil {
+		return nil, fmt.Errorf("failed to decode answer history: %w", err)
+	}
+
+	return history, nil
 }
 
 // FlagAnswer flags/unflags an answer for review
@@ -1008,7 +1134,7 @@ func (ar *AnswerPostgreSQL) FlagAnswer(ctx context.Context, tx *gorm.DB, id uint
 	if err := db.WithContext(ctx).
 		Model(&models.StudentAnswer{}).
 		Where("id = ?", id).
-		Update("is_flagged", flagged).Error; err != nil {
+		Update("flagged", flagged).Error; err != nil {
 		return fmt.Errorf("failed to flag answer: %w", err)
 	}
 
@@ -1023,7 +1149,7 @@ func (ar *AnswerPostgreSQL) GetFlaggedAnswers(ctx context.Context, tx *gorm.DB,
 	db := ar.getDB(tx)
 	var answers []*models.StudentAnswer
 	if err := db.WithContext(ctx).
-		Where("attempt_id = ? AND is_flagged = true", attemptID).
+		Where("attempt_id = ? AND flagged = true", attemptID).
 		Find(&answers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get flagged answers: %w", err)
 	}
@@ -1183,7 +1309,7 @@ func (ar *AnswerPostgreSQL) GetStudentAnswerStats(ctx context.Context, tx *gorm.
 	if err := ar.db.WithContext(ctx).
 		Table("student_answers sa").
 		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
-		Where("aa.student_id = ? AND sa.is_flagged = true", studentID).
+		Where("aa.student_id = ? AND sa.flagged = true", studentID).
 		Count(&flaggedCount).Error; err != nil {
 		return nil, fmt.Errorf("failed to count flagged answers: %w", err)
 	}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AuditPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAuditPostgreSQL(db *gorm.DB) repositories.AuditRepository {
+	return &AuditPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (a *AuditPostgreSQL) Create(ctx context.Context, tx *gorm.DB, log *models.AuditLog) error {
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).Omit("User").Create(log).Error; err != nil {
+		return handleDBError(err, "create audit log")
+	}
+	return nil
+}
+
+func (a *AuditPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AuditLog, error) {
+	db := a.getDB(tx)
+	var log models.AuditLog
+	if err := db.WithContext(ctx).First(&log, id).Error; err != nil {
+		return nil, handleDBError(err, "get audit log by id")
+	}
+	return &log, nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (a *AuditPostgreSQL) List(ctx context.Context, tx *gorm.DB, filters repositories.AuditLogFilters) ([]*models.AuditLog, int64, error) {
+	db := a.getDB(tx)
+	var logs []*models.AuditLog
+	var total int64
+
+	query := a.applyFilters(db.WithContext(ctx).Model(&models.AuditLog{}), filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, handleDBError(err, "count audit logs")
+	}
+
+	order := "created_at DESC, id DESC"
+	if filters.SortOrder == "asc" || filters.SortOrder == "ASC" {
+		order = "created_at ASC, id ASC"
+	}
+	query = query.Order(order)
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, 0, handleDBError(err, "list audit logs")
+	}
+
+	return logs, total, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (a *AuditPostgreSQL) applyFilters(query *gorm.DB, filters repositories.AuditLogFilters) *gorm.DB {
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+	if filters.TargetType != nil {
+		query = query.Where("target_type = ?", *filters.TargetType)
+	}
+	if filters.TargetID != nil {
+		query = query.Where("target_id = ?", *filters.TargetID)
+	}
+	if filters.EventType != nil {
+		query = query.Where("event_type = ?", *filters.EventType)
+	}
+	if filters.DateFrom != nil {
+		query = query.Where("created_at >= ?", *filters.DateFrom)
+	}
+	if filters.DateTo != nil {
+		query = query.Where("created_at <= ?", *filters.DateTo)
+	}
+	return query
+}
+
+func (a *AuditPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return a.db
+}
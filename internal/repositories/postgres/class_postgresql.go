@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ClassPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewClassPostgreSQL(db *gorm.DB) repositories.ClassRepository {
+	return &ClassPostgreSQL{db: db}
+}
+
+// assignedAssessmentIDsSQL selects the assessments assigned to a student, directly or through
+// an active class. Both placeholders take the student ID.
+const assignedAssessmentIDsSQL = `SELECT aa.assessment_id FROM assessment_assignments aa
+	WHERE aa.student_id = ? OR aa.class_id IN (
+		SELECT cm.class_id FROM class_members cm
+		JOIN classes c ON c.id = cm.class_id
+		WHERE cm.student_id = ? AND c.is_active = true)`
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (c *ClassPostgreSQL) Create(ctx context.Context, tx *gorm.DB, class *models.Class) error {
+	db := c.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Members", "Creator").Create(class).Error; err != nil {
+		return handleDBError(err, "create class")
+	}
+	return nil
+}
+
+func (c *ClassPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Class, error) {
+	db := c.getDB(tx)
+	var class models.Class
+	if err := db.WithContext(ctx).First(&class, id).Error; err != nil {
+		return nil, handleDBError(err, "get class by id")
+	}
+	if err := c.loadMemberCounts(ctx, db, []*models.Class{&class}); err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+func (c *ClassPostgreSQL) GetByIDWithMembers(ctx context.Context, tx *gorm.DB, id uint) (*models.Class, error) {
+	db := c.getDB(tx)
+	var class models.Class
+	if err := db.WithContext(ctx).
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&class, id).Error; err != nil {
+		return nil, handleDBError(err, "get class with members")
+	}
+	class.MemberCount = len(class.Members)
+	return &class, nil
+}
+
+func (c *ClassPostgreSQL) Update(ctx context.Context, tx *gorm.DB, class *models.Class) error {
+	db := c.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.Class{ID: class.ID}).
+		Select("name", "code", "description", "is_active").
+		Updates(class).Error; err != nil {
+		return handleDBError(err, "update class")
+	}
+	return nil
+}
+
+// Delete removes the class together with its members and the assignments made through it
+func (c *ClassPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := c.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("class_id = ?", id).Delete(&models.AssessmentAssignment{}).Error; err != nil {
+			return handleDBError(err, "delete class assignments")
+		}
+		if err := tx.Where("class_id = ?", id).Delete(&models.ClassMember{}).Error; err != nil {
+			return handleDBError(err, "delete class members")
+		}
+
+		result := tx.Delete(&models.Class{}, id)
+		if result.Error != nil {
+			return handleDBError(result.Error, "delete class")
+		}
+		if result.RowsAffected == 0 {
+			return handleDBError(gorm.ErrRecordNotFound, "delete class")
+		}
+		return nil
+	})
+}
+
+func (c *ClassPostgreSQL) List(ctx context.Context, tx *gorm.DB, filters repositories.ClassFilters) ([]*models.Class, int64, error) {
+	db := c.getDB(tx)
+	var classes []*models.Class
+	var total int64
+
+	query := db.WithContext(ctx).Model(&models.Class{})
+	if filters.CreatedBy != nil {
+		query = query.Where("created_by = ?", *filters.CreatedBy)
+	}
+	if filters.StudentID != nil {
+		query = query.Where("id IN (?)", db.Model(&models.ClassMember{}).
+			Select("class_id").
+			Where("student_id = ?", *filters.StudentID))
+	}
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+	if q := strings.TrimSpace(filters.Query); q != "" {
+		pattern := "%" + q + "%"
+		query = query.Where("(name ILIKE ? OR code ILIKE ?)", pattern, pattern)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, handleDBError(err, "count classes")
+	}
+
+	query = query.Order("name ASC, id ASC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	if err := query.Find(&classes).Error; err != nil {
+		return nil, 0, handleDBError(err, "list classes")
+	}
+
+	if err := c.loadMemberCounts(ctx, db, classes); err != nil {
+		return nil, 0, err
+	}
+	return classes, total, nil
+}
+
+// ===== MEMBERSHIP =====
+
+// AddMembers enrolls the students, skipping those already in the class, and returns how many were added
+func (c *ClassPostgreSQL) AddMembers(ctx context.Context, tx *gorm.DB, classID uint, studentIDs []string, addedBy string) (int64, error) {
+	if len(studentIDs) == 0 {
+		return 0, nil
+	}
+
+	members := make([]*models.ClassMember, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		members = append(members, &models.ClassMember{
+			ClassID:   classID,
+			StudentID: studentID,
+			AddedBy:   addedBy,
+		})
+	}
+
+	db := c.getDB(tx)
+	result := db.WithContext(ctx).
+		Omit("Class").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(members, 100)
+	if result.Error != nil {
+		return 0, handleDBError(result.Error, "add class members")
+	}
+	return result.RowsAffected, nil
+}
+
+func (c *ClassPostgreSQL) RemoveMember(ctx context.Context, tx *gorm.DB, classID uint, studentID string) error {
+	db := c.getDB(tx)
+	result := db.WithContext(ctx).
+		Where("class_id = ? AND student_id = ?", classID, studentID).
+		Delete(&models.ClassMember{})
+	if result.Error != nil {
+		return handleDBError(result.Error, "remove class member")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "remove class member")
+	}
+	return nil
+}
+
+func (c *ClassPostgreSQL) GetMemberIDs(ctx context.Context, tx *gorm.DB, classID uint) ([]string, error) {
+	db := c.getDB(tx)
+	var studentIDs []string
+	if err := db.WithContext(ctx).
+		Model(&models.ClassMember{}).
+		Where("class_id = ?", classID).
+		Order("student_id ASC").
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		return nil, handleDBError(err, "get class member ids")
+	}
+	return studentIDs, nil
+}
+
+// ===== ASSESSMENT ASSIGNMENT =====
+
+// Assign stores the assignment; assigning the same class or student twice returns the existing row
+func (c *ClassPostgreSQL) Assign(ctx context.Context, tx *gorm.DB, assignment *models.AssessmentAssignment) error {
+	db := c.getDB(tx)
+
+	query := db.WithContext(ctx).Where("assessment_id = ?", assignment.AssessmentID)
+	if assignment.ClassID != nil {
+		query = query.Where("class_id = ?", *assignment.ClassID)
+	} else {
+		query = query.Where("student_id = ?", *assignment.StudentID)
+	}
+
+	var existing models.AssessmentAssignment
+	err := query.First(&existing).Error
+	if err == nil {
+		*assignment = existing
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return handleDBError(err, "check assessment assignment")
+	}
+
+	if err := db.WithContext(ctx).Omit("Assessment", "Class").Create(assignment).Error; err != nil {
+		return handleDBError(err, "create assessment assignment")
+	}
+	return nil
+}
+
+func (c *ClassPostgreSQL) GetAssignment(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentAssignment, error) {
+	db := c.getDB(tx)
+	var assignment models.AssessmentAssignment
+	if err := db.WithContext(ctx).First(&assignment, id).Error; err != nil {
+		return nil, handleDBError(err, "get assessment assignment")
+	}
+	return &assignment, nil
+}
+
+func (c *ClassPostgreSQL) DeleteAssignment(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := c.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.AssessmentAssignment{}, id)
+	if result.Error != nil {
+		return handleDBError(result.Error, "delete assessment assignment")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "delete assessment assignment")
+	}
+	return nil
+}
+
+func (c *ClassPostgreSQL) GetAssignments(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentAssignment, error) {
+	db := c.getDB(tx)
+	var assignments []*models.AssessmentAssignment
+	if err := db.WithContext(ctx).
+		Preload("Class").
+		Where("assessment_id = ?", assessmentID).
+		Order("created_at ASC, id ASC").
+		Find(&assignments).Error; err != nil {
+		return nil, handleDBError(err, "get assessment assignments")
+	}
+	return assignments, nil
+}
+
+func (c *ClassPostgreSQL) IsAssigned(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (bool, error) {
+	db := c.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.Assessment{}).
+		Where("id = ?", assessmentID).
+		Where("id IN ("+assignedAssessmentIDsSQL+")", studentID, studentID).
+		Count(&count).Error; err != nil {
+		return false, handleDBError(err, "check assessment assignment")
+	}
+	return count > 0, nil
+}
+
+// GetAssignedStudentIDs returns the distinct students an assessment reaches, directly or through active classes
+func (c *ClassPostgreSQL) GetAssignedStudentIDs(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error) {
+	db := c.getDB(tx)
+	var studentIDs []string
+	if err := db.WithContext(ctx).Raw(`
+		SELECT student_id FROM assessment_assignments
+		WHERE assessment_id = ? AND student_id IS NOT NULL
+		UNION
+		SELECT cm.student_id FROM assessment_assignments aa
+		JOIN classes c ON c.id = aa.class_id AND c.is_active = true
+		JOIN class_members cm ON cm.class_id = aa.class_id
+		WHERE aa.assessment_id = ?
+		ORDER BY student_id`, assessmentID, assessmentID).
+		Scan(&studentIDs).Error; err != nil {
+		return nil, handleDBError(err, "get assigned student ids")
+	}
+	return studentIDs, nil
+}
+
+func (c *ClassPostgreSQL) GetAssignedAssessmentIDs(ctx context.Context, tx *gorm.DB, studentID string) ([]uint, error) {
+	db := c.getDB(tx)
+	var assessmentIDs []uint
+	if err := db.WithContext(ctx).
+		Raw("SELECT DISTINCT assessment_id FROM ("+assignedAssessmentIDsSQL+") assigned", studentID, studentID).
+		Scan(&assessmentIDs).Error; err != nil {
+		return nil, handleDBError(err, "get assigned assessment ids")
+	}
+	return assessmentIDs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (c *ClassPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return c.db
+}
+
+func (c *ClassPostgreSQL) loadMemberCounts(ctx context.Context, db *gorm.DB, classes []*models.Class) error {
+	if len(classes) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(classes))
+	for i, class := range classes {
+		ids[i] = class.ID
+	}
+
+	var counts []struct {
+		ClassID uint
+		Count   int
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.ClassMember{}).
+		Select("class_id, COUNT(*) AS count").
+		Where("class_id IN ?", ids).
+		Group("class_id").
+		Scan(&counts).Error; err != nil {
+		return handleDBError(err, "count class members")
+	}
+
+	byClass := make(map[uint]int, len(counts))
+	for _, count := range counts {
+		byClass[count.ClassID] = count.Count
+	}
+	for _, class := range classes {
+		class.MemberCount = byClass[class.ID]
+	}
+	return nil
+}
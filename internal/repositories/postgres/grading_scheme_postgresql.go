@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type GradingSchemePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewGradingSchemePostgreSQL(db *gorm.DB) repositories.GradingSchemeRepository {
+	return &GradingSchemePostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (g *GradingSchemePostgreSQL) Create(ctx context.Context, tx *gorm.DB, scheme *models.GradingScheme) error {
+	db := g.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment").Create(scheme).Error; err != nil {
+		return handleDBError(err, "create grading scheme")
+	}
+	return nil
+}
+
+func (g *GradingSchemePostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradingScheme, error) {
+	db := g.getDB(tx)
+	var scheme models.GradingScheme
+	if err := db.WithContext(ctx).First(&scheme, id).Error; err != nil {
+		return nil, handleDBError(err, "get grading scheme by id")
+	}
+	return &scheme, nil
+}
+
+func (g *GradingSchemePostgreSQL) Update(ctx context.Context, tx *gorm.DB, scheme *models.GradingScheme) error {
+	db := g.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment").Save(scheme).Error; err != nil {
+		return handleDBError(err, "update grading scheme")
+	}
+	return nil
+}
+
+func (g *GradingSchemePostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := g.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.GradingScheme{}, id)
+	if result.Error != nil {
+		return handleDBError(result.Error, "delete grading scheme")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "delete grading scheme")
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (g *GradingSchemePostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.GradingScheme, error) {
+	db := g.getDB(tx)
+	var scheme models.GradingScheme
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND is_template = ?", assessmentID, false).
+		First(&scheme).Error; err != nil {
+		return nil, handleDBError(err, "get grading scheme by assessment")
+	}
+	return &scheme, nil
+}
+
+func (g *GradingSchemePostgreSQL) ListTemplates(ctx context.Context, tx *gorm.DB) ([]*models.GradingScheme, error) {
+	db := g.getDB(tx)
+	var schemes []*models.GradingScheme
+	if err := db.WithContext(ctx).
+		Where("is_template = ?", true).
+		Order("name ASC").
+		Find(&schemes).Error; err != nil {
+		return nil, handleDBError(err, "list grading scheme templates")
+	}
+	return schemes, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (g *GradingSchemePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return g.db
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ImportJobPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewImportJobPostgreSQL(db *gorm.DB) repositories.ImportJobRepository {
+	return &ImportJobPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (i *ImportJobPostgreSQL) Create(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error {
+	db := i.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment", "Bank", "User").Create(job).Error; err != nil {
+		return handleDBError(err, "create import job")
+	}
+	return nil
+}
+
+func (i *ImportJobPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id string) (*models.ImportJob, error) {
+	db := i.getDB(tx)
+	var job models.ImportJob
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, handleDBError(err, "get import job by id")
+	}
+	return &job, nil
+}
+
+func (i *ImportJobPostgreSQL) Update(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error {
+	db := i.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment", "Bank", "User", "ClaimedBy", "ClaimExpiresAt").Save(job).Error; err != nil {
+		return handleDBError(err, "update import job")
+	}
+	return nil
+}
+
+// ===== PROGRESS TRACKING =====
+
+func (i *ImportJobPostgreSQL) UpdateProgress(ctx context.Context, tx *gorm.DB, id string, processedRows, successCount, errorCount, progress int) error {
+	db := i.getDB(tx)
+	result := db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"processed_rows": processedRows,
+			"success_count":  successCount,
+			"error_count":    errorCount,
+			"progress":       progress,
+		})
+	if result.Error != nil {
+		return handleDBError(result.Error, "update import job progress")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "update import job progress")
+	}
+	return nil
+}
+
+// ===== WORKER CLAIMS =====
+
+// Claim gives owner the job until the lease runs out. An unfinished job can be claimed when it is
+// unclaimed, already held by owner, or its previous claim expired.
+func (i *ImportJobPostgreSQL) Claim(ctx context.Context, tx *gorm.DB, id, owner string, lease time.Duration) (bool, error) {
+	db := i.getDB(tx)
+	now := time.Now()
+	result := db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ? AND status IN ?", id, []models.ImportJobStatus{models.ImportPending, models.ImportProcessing}).
+		Where("(claimed_by IS NULL OR claimed_by = ? OR claim_expires_at < ?)", owner, now).
+		Updates(map[string]interface{}{
+			"claimed_by":       owner,
+			"claim_expires_at": now.Add(lease),
+		})
+	if result.Error != nil {
+		return false, handleDBError(result.Error, "claim import job")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (i *ImportJobPostgreSQL) ReleaseClaim(ctx context.Context, tx *gorm.DB, id, owner string) error {
+	db := i.getDB(tx)
+	if err := db.WithContext(ctx).Model(&models.ImportJob{}).
+		Where("id = ? AND claimed_by = ?", id, owner).
+		Updates(map[string]interface{}{
+			"claimed_by":       nil,
+			"claim_expires_at": nil,
+		}).Error; err != nil {
+		return handleDBError(err, "release import job claim")
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (i *ImportJobPostgreSQL) ListByUser(ctx context.Context, tx *gorm.DB, userID string, filters repositories.ImportJobFilters) ([]*models.ImportJob, int64, error) {
+	db := i.getDB(tx)
+	var jobs []*models.ImportJob
+	var total int64
+
+	query := db.WithContext(ctx).Model(&models.ImportJob{}).Where("user_id = ?", userID)
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, handleDBError(err, "count import jobs")
+	}
+
+	query = query.Order("created_at DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, 0, handleDBError(err, "list import jobs")
+	}
+
+	return jobs, total, nil
+}
+
+func (i *ImportJobPostgreSQL) ListByStatus(ctx context.Context, tx *gorm.DB, statuses []models.ImportJobStatus) ([]*models.ImportJob, error) {
+	db := i.getDB(tx)
+	var jobs []*models.ImportJob
+	if err := db.WithContext(ctx).
+		Where("status IN ?", statuses).
+		Order("created_at ASC").
+		Find(&jobs).Error; err != nil {
+		return nil, handleDBError(err, "list import jobs by status")
+	}
+	return jobs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (i *ImportJobPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return i.db
+}
@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewNotificationPostgreSQL(db *gorm.DB) repositories.NotificationRepository {
+	return &NotificationPostgreSQL{db: db}
+}
+
+// unreadCondition matches direct notifications without read_at and role broadcasts the user has no read receipt for
+const unreadCondition = `((notifications.recipient_id IS NOT NULL AND notifications.read_at IS NULL) OR
+	(notifications.recipient_id IS NULL AND NOT EXISTS (
+		SELECT 1 FROM notification_receipts r
+		WHERE r.notification_id = notifications.id AND r.user_id = ? AND r.read_at IS NOT NULL)))`
+
+// ===== BASIC OPERATIONS =====
+
+func (n *NotificationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, notification *models.Notification) error {
+	db := n.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Recipient", "Assessment", "Attempt", "Creator").Create(notification).Error; err != nil {
+		return handleDBError(err, "create notification")
+	}
+	return nil
+}
+
+func (n *NotificationPostgreSQL) CreateBatch(ctx context.Context, tx *gorm.DB, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	db := n.getDB(tx)
+	if err := db.WithContext(ctx).
+		Omit("Recipient", "Assessment", "Attempt", "Creator").
+		CreateInBatches(notifications, 100).Error; err != nil {
+		return handleDBError(err, "create notifications")
+	}
+	return nil
+}
+
+func (n *NotificationPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := n.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.Notification{}, id)
+	if result.Error != nil {
+		return handleDBError(result.Error, "delete notification")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "delete notification")
+	}
+	return nil
+}
+
+// ===== INBOX QUERIES =====
+
+func (n *NotificationPostgreSQL) GetForUser(ctx context.Context, tx *gorm.DB, id uint, userID string, role models.UserRole) (*models.Notification, error) {
+	db := n.getDB(tx)
+	var notification models.Notification
+
+	if err := n.inboxQuery(db.WithContext(ctx), userID, role).
+		Where("notifications.id = ?", id).
+		First(&notification).Error; err != nil {
+		return nil, handleDBError(err, "get notification")
+	}
+
+	if err := n.applyReceipts(ctx, db, userID, []*models.Notification{&notification}); err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (n *NotificationPostgreSQL) ListForUser(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole, filters repositories.NotificationFilters) ([]*models.Notification, int64, error) {
+	db := n.getDB(tx)
+	var notifications []*models.Notification
+	var total int64
+
+	query := n.inboxQuery(db.WithContext(ctx), userID, role)
+	if filters.Type != nil {
+		query = query.Where("notifications.type = ?", *filters.Type)
+	}
+	if filters.Read != nil {
+		if *filters.Read {
+			query = query.Where("NOT "+unreadCondition, userID)
+		} else {
+			query = query.Where(unreadCondition, userID)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, handleDBError(err, "count notifications")
+	}
+
+	query = query.Order("notifications.created_at DESC, notifications.id DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	if err := query.Find(&notifications).Error; err != nil {
+		return nil, 0, handleDBError(err, "list notifications")
+	}
+
+	if err := n.applyReceipts(ctx, db, userID, notifications); err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (n *NotificationPostgreSQL) CountUnread(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole) (int64, error) {
+	db := n.getDB(tx)
+	var count int64
+	if err := n.inboxQuery(db.WithContext(ctx), userID, role).
+		Where(unreadCondition, userID).
+		Count(&count).Error; err != nil {
+		return 0, handleDBError(err, "count unread notifications")
+	}
+	return count, nil
+}
+
+// ===== READ STATE =====
+
+func (n *NotificationPostgreSQL) MarkRead(ctx context.Context, tx *gorm.DB, id uint, userID string) error {
+	db := n.getDB(tx)
+	if err := db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND recipient_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", time.Now()).Error; err != nil {
+		return handleDBError(err, "mark notification read")
+	}
+	return nil
+}
+
+func (n *NotificationPostgreSQL) MarkBroadcastRead(ctx context.Context, tx *gorm.DB, id uint, userID string) error {
+	db := n.getDB(tx)
+	now := time.Now()
+	receipt := &models.NotificationReceipt{
+		NotificationID: id,
+		UserID:         userID,
+		ReadAt:         &now,
+	}
+
+	if err := db.WithContext(ctx).
+		Omit("Notification").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "notification_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"read_at": gorm.Expr("COALESCE(notification_receipts.read_at, EXCLUDED.read_at)"),
+			}),
+		}).
+		Create(receipt).Error; err != nil {
+		return handleDBError(err, "mark broadcast notification read")
+	}
+	return nil
+}
+
+func (n *NotificationPostgreSQL) MarkAllRead(ctx context.Context, tx *gorm.DB, userID string, role models.UserRole) (int64, error) {
+	db := n.getDB(tx)
+	now := time.Now()
+	var updated int64
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Notification{}).
+			Where("recipient_id = ? AND read_at IS NULL", userID).
+			Where("(scheduled_for IS NULL OR scheduled_for <= ?)", now).
+			Update("read_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		updated += result.RowsAffected
+
+		// Write receipts for every visible broadcast the user has not read yet
+		result = tx.Exec(`
+			INSERT INTO notification_receipts (notification_id, user_id, read_at, created_at)
+			SELECT n.id, ?, ?, ? FROM notifications n
+			WHERE n.recipient_id IS NULL AND n.recipient_role = ?
+				AND (n.scheduled_for IS NULL OR n.scheduled_for <= ?)
+				AND (n.expires_at IS NULL OR n.expires_at > ?)
+			ON CONFLICT (notification_id, user_id)
+			DO UPDATE SET read_at = EXCLUDED.read_at
+			WHERE notification_receipts.read_at IS NULL AND notification_receipts.dismissed_at IS NULL`,
+			userID, now, now, role, now, now)
+		if result.Error != nil {
+			return result.Error
+		}
+		updated += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, handleDBError(err, "mark all notifications read")
+	}
+
+	return updated, nil
+}
+
+func (n *NotificationPostgreSQL) DismissBroadcast(ctx context.Context, tx *gorm.DB, id uint, userID string) error {
+	db := n.getDB(tx)
+	now := time.Now()
+	receipt := &models.NotificationReceipt{
+		NotificationID: id,
+		UserID:         userID,
+		DismissedAt:    &now,
+	}
+
+	if err := db.WithContext(ctx).
+		Omit("Notification").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "notification_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"dismissed_at"}),
+		}).
+		Create(receipt).Error; err != nil {
+		return handleDBError(err, "dismiss broadcast notification")
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (n *NotificationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return n.db
+}
+
+// inboxQuery scopes notifications to those currently visible in the user's inbox
+func (n *NotificationPostgreSQL) inboxQuery(db *gorm.DB, userID string, role models.UserRole) *gorm.DB {
+	now := time.Now()
+	return db.Model(&models.Notification{}).
+		Where("(notifications.recipient_id = ? OR (notifications.recipient_id IS NULL AND notifications.recipient_role = ?))", userID, role).
+		Where("(notifications.scheduled_for IS NULL OR notifications.scheduled_for <= ?)", now).
+		Where("(notifications.expires_at IS NULL OR notifications.expires_at > ?)", now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM notification_receipts r
+			WHERE r.notification_id = notifications.id AND r.user_id = ? AND r.dismissed_at IS NOT NULL)`, userID)
+}
+
+// applyReceipts copies the user's read time onto role broadcasts, whose own read_at is never set
+func (n *NotificationPostgreSQL) applyReceipts(ctx context.Context, db *gorm.DB, userID string, notifications []*models.Notification) error {
+	var broadcastIDs []uint
+	for _, notification := range notifications {
+		if notification.RecipientID == nil {
+			broadcastIDs = append(broadcastIDs, notification.ID)
+		}
+	}
+	if len(broadcastIDs) == 0 {
+		return nil
+	}
+
+	var receipts []models.NotificationReceipt
+	if err := db.WithContext(ctx).
+		Where("user_id = ? AND notification_id IN ?", userID, broadcastIDs).
+		Find(&receipts).Error; err != nil {
+		return handleDBError(err, "get notification receipts")
+	}
+
+	readAt := make(map[uint]*time.Time, len(receipts))
+	for _, receipt := range receipts {
+		readAt[receipt.NotificationID] = receipt.ReadAt
+	}
+	for _, notification := range notifications {
+		if notification.RecipientID == nil {
+			notification.ReadAt = readAt[notification.ID]
+		}
+	}
+	return nil
+}
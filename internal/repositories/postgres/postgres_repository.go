@@ -22,6 +22,7 @@ type PostgreSQLRepository struct {
 	// Repository instances
 	assessment         repositories.AssessmentRepository
 	assessmentSettings repositories.AssessmentSettingsRepository
+	assessmentVersion  repositories.AssessmentVersionRepository
 	question           repositories.QuestionRepository
 	questionCategory   repositories.QuestionCategoryRepository
 	questionAttachment repositories.QuestionAttachmentRepository
@@ -29,8 +30,15 @@ type PostgreSQLRepository struct {
 	assessmentQuestion repositories.AssessmentQuestionRepository
 	attempt            repositories.AttemptRepository
 	answer             repositories.AnswerRepository
+	proctoringEvent    repositories.ProctoringEventRepository
+	gradingScheme      repositories.GradingSchemeRepository
 	user               repositories.UserRepository
 	dashboard          repositories.DashboardRepository
+	audit              repositories.AuditRepository
+	analytics          repositories.AnalyticsRepository
+	importJob          repositories.ImportJobRepository
+	notification       repositories.NotificationRepository
+	class              repositories.ClassRepository
 }
 
 // RepositoryConfig holds configuration for repository initialization
@@ -63,11 +71,29 @@ func NewPostgreSQLRepository(config RepositoryConfig) repositories.Repository {
 	// Dashboard repository
 	repo.dashboard = NewDashboardRepository(config.DB)
 
+	// Audit repository
+	repo.audit = NewAuditPostgreSQL(config.DB)
+
+	// Analytics repository
+	repo.analytics = NewAnalyticsPostgreSQL(config.DB)
+
+	// Import job repository
+	repo.importJob = NewImportJobPostgreSQL(config.DB)
+
+	// Notification repository
+	repo.notification = NewNotificationPostgreSQL(config.DB)
+
+	// Class and assignment repository
+	repo.class = NewClassPostgreSQL(config.DB)
+
 	// TODO: Initialize other repositories
 	repo.assessmentSettings = NewAssessmentSettingsPostgreSQL(config.DB, cacheManager)
-	// repo.questionCategory = NewQuestionCategoryPostgreSQL(config.DB, config.RedisClient)
-	// repo.questionAttachment = NewQuestionAttachmentPostgreSQL(config.DB, config.RedisClient)
+	repo.questionCategory = NewQuestionCategoryPostgreSQL(config.DB, config.RedisClient)
+	repo.questionAttachment = NewQuestionAttachmentPostgreSQL(config.DB)
 	repo.answer = NewAnswerPostgreSQL(config.DB, config.RedisClient)
+	repo.proctoringEvent = NewProctoringEventPostgreSQL(config.DB)
+	repo.gradingScheme = NewGradingSchemePostgreSQL(config.DB)
+	repo.assessmentVersion = NewAssessmentVersionPostgreSQL(config.DB)
 
 	return repo
 }
@@ -82,6 +108,11 @@ func (r *PostgreSQLRepository) AssessmentSettings() repositories.AssessmentSetti
 	return r.assessmentSettings
 }
 
+// AssessmentVersion returns the assessment version snapshot repository
+func (r *PostgreSQLRepository) AssessmentVersion() repositories.AssessmentVersionRepository {
+	return r.assessmentVersion
+}
+
 // Question returns the question repository
 func (r *PostgreSQLRepository) Question() repositories.QuestionRepository {
 	return r.question
@@ -117,6 +148,16 @@ func (r *PostgreSQLRepository) Answer() repositories.AnswerRepository {
 	return r.answer
 }
 
+// ProctoringEvent returns the proctoring event repository
+func (r *PostgreSQLRepository) ProctoringEvent() repositories.ProctoringEventRepository {
+	return r.proctoringEvent
+}
+
+// GradingScheme returns the grading scheme repository
+func (r *PostgreSQLRepository) GradingScheme() repositories.GradingSchemeRepository {
+	return r.gradingScheme
+}
+
 // User returns the user repository
 func (r *PostgreSQLRepository) User() repositories.UserRepository {
 	return r.user
@@ -127,6 +168,31 @@ func (r *PostgreSQLRepository) Dashboard() repositories.DashboardRepository {
 	return r.dashboard
 }
 
+// Audit returns the audit log repository
+func (r *PostgreSQLRepository) Audit() repositories.AuditRepository {
+	return r.audit
+}
+
+// Analytics returns the analytics repository
+func (r *PostgreSQLRepository) Analytics() repositories.AnalyticsRepository {
+	return r.analytics
+}
+
+// ImportJob returns the import job repository
+func (r *PostgreSQLRepository) ImportJob() repositories.ImportJobRepository {
+	return r.importJob
+}
+
+// Notification returns the notification repository
+func (r *PostgreSQLRepository) Notification() repositories.NotificationRepository {
+	return r.notification
+}
+
+// Class returns the class and assignment repository
+func (r *PostgreSQLRepository) Class() repositories.ClassRepository {
+	return r.class
+}
+
 // WithTransaction executes a function within a database transaction
 func (r *PostgreSQLRepository) WithTransaction(ctx context.Context, fn func(repositories.Repository) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -141,14 +207,24 @@ func (r *PostgreSQLRepository) WithTransaction(ctx context.Context, fn func(repo
 		txRepo.assessment = NewAssessmentPostgreSQL(tx, r.redisClient)
 		txRepo.question = NewQuestionPostgreSQL(tx, r.redisClient)
 		txRepo.questionBank = NewQuestionBankRepository(tx)
+		txRepo.questionCategory = NewQuestionCategoryPostgreSQL(tx, r.redisClient)
+		txRepo.questionAttachment = NewQuestionAttachmentPostgreSQL(tx)
 		txRepo.assessmentQuestion = NewAssessmentQuestionPostgreSQL(tx, r.redisClient)
 		txRepo.attempt = NewAttemptPostgreSQL(tx, r.redisClient)
+		txRepo.proctoringEvent = NewProctoringEventPostgreSQL(tx)
+		txRepo.gradingScheme = NewGradingSchemePostgreSQL(tx)
+		txRepo.assessmentVersion = NewAssessmentVersionPostgreSQL(tx)
 
 		// User repository doesn't need transaction (it's external)
 		txRepo.user = r.user
 
 		// Dashboard repository with transaction
 		txRepo.dashboard = NewDashboardRepository(tx)
+		txRepo.audit = NewAuditPostgreSQL(tx)
+		txRepo.analytics = NewAnalyticsPostgreSQL(tx)
+		txRepo.importJob = NewImportJobPostgreSQL(tx)
+		txRepo.notification = NewNotificationPostgreSQL(tx)
+		txRepo.class = NewClassPostgreSQL(tx)
 
 		return fn(txRepo)
 	})
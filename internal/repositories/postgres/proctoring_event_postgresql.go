@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ProctoringEventPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewProctoringEventPostgreSQL(db *gorm.DB) repositories.ProctoringEventRepository {
+	return &ProctoringEventPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (p *ProctoringEventPostgreSQL) Create(ctx context.Context, tx *gorm.DB, event *models.ProctoringEvent) error {
+	db := p.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Attempt", "Question", "Reviewer").Create(event).Error; err != nil {
+		return handleDBError(err, "create proctoring event")
+	}
+	return nil
+}
+
+func (p *ProctoringEventPostgreSQL) CreateBatch(ctx context.Context, tx *gorm.DB, events []*models.ProctoringEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	db := p.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Attempt", "Question", "Reviewer").CreateInBatches(events, 100).Error; err != nil {
+		return handleDBError(err, "create proctoring events batch")
+	}
+	return nil
+}
+
+func (p *ProctoringEventPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ProctoringEvent, error) {
+	db := p.getDB(tx)
+	var event models.ProctoringEvent
+	if err := db.WithContext(ctx).First(&event, id).Error; err != nil {
+		return nil, handleDBError(err, "get proctoring event by id")
+	}
+	return &event, nil
+}
+
+func (p *ProctoringEventPostgreSQL) Update(ctx context.Context, tx *gorm.DB, event *models.ProctoringEvent) error {
+	db := p.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Attempt", "Question", "Reviewer").Save(event).Error; err != nil {
+		return handleDBError(err, "update proctoring event")
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (p *ProctoringEventPostgreSQL) GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint, filters repositories.ProctoringEventFilters) ([]*models.ProctoringEvent, int64, error) {
+	db := p.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.ProctoringEvent{}).
+		Where("proctoring_events.attempt_id = ?", attemptID)
+
+	return p.list(query, filters)
+}
+
+func (p *ProctoringEventPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, filters repositories.ProctoringEventFilters) ([]*models.ProctoringEvent, int64, error) {
+	db := p.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.ProctoringEvent{}).
+		Joins("JOIN assessment_attempts ON assessment_attempts.id = proctoring_events.attempt_id").
+		Where("assessment_attempts.assessment_id = ?", assessmentID)
+
+	return p.list(query, filters)
+}
+
+// ===== REVIEW OPERATIONS =====
+
+func (p *ProctoringEventPostgreSQL) UpdateReview(ctx context.Context, tx *gorm.DB, id uint, status string, notes *string, reviewerID string) error {
+	db := p.getDB(tx)
+
+	updates := map[string]interface{}{
+		"review_status": status,
+		"review_notes":  notes,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   time.Now(),
+	}
+	// An event sent back to pending has not been reviewed by anyone
+	if status == models.ProctoringReviewPending {
+		updates["reviewed_by"] = nil
+		updates["reviewed_at"] = nil
+	}
+
+	result := db.WithContext(ctx).Model(&models.ProctoringEvent{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return handleDBError(result.Error, "update proctoring event review")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "update proctoring event review")
+	}
+	return nil
+}
+
+// ===== STATISTICS =====
+
+func (p *ProctoringEventPostgreSQL) GetAttemptSummary(ctx context.Context, tx *gorm.DB, attemptID uint) (*repositories.ProctoringSummary, error) {
+	db := p.getDB(tx)
+
+	var rows []struct {
+		Type         models.ProctoringEventType
+		Severity     int
+		ReviewStatus string
+		Count        int
+	}
+	if err := db.WithContext(ctx).Model(&models.ProctoringEvent{}).
+		Select("type, severity, review_status, COUNT(*) AS count").
+		Where("attempt_id = ?", attemptID).
+		Group("type, severity, review_status").
+		Scan(&rows).Error; err != nil {
+		return nil, handleDBError(err, "get proctoring summary")
+	}
+
+	summary := &repositories.ProctoringSummary{
+		AttemptID:        attemptID,
+		EventsByType:     make(map[models.ProctoringEventType]int),
+		EventsBySeverity: make(map[int]int),
+	}
+	for _, row := range rows {
+		summary.TotalEvents += row.Count
+		summary.EventsByType[row.Type] += row.Count
+		summary.EventsBySeverity[row.Severity] += row.Count
+		if row.ReviewStatus == models.ProctoringReviewPending {
+			summary.PendingReview += row.Count
+		}
+		if row.Severity > summary.MaxSeverity {
+			summary.MaxSeverity = row.Severity
+		}
+	}
+
+	return summary, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (p *ProctoringEventPostgreSQL) list(query *gorm.DB, filters repositories.ProctoringEventFilters) ([]*models.ProctoringEvent, int64, error) {
+	var events []*models.ProctoringEvent
+	var total int64
+
+	query = p.applyFilters(query, filters)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, handleDBError(err, "count proctoring events")
+	}
+
+	query = p.applyPaginationAndSort(query, filters)
+
+	if err := query.Select("proctoring_events.*").Find(&events).Error; err != nil {
+		return nil, 0, handleDBError(err, "list proctoring events")
+	}
+
+	return events, total, nil
+}
+
+func (p *ProctoringEventPostgreSQL) applyFilters(query *gorm.DB, filters repositories.ProctoringEventFilters) *gorm.DB {
+	if filters.Type != nil {
+		query = query.Where("proctoring_events.type = ?", *filters.Type)
+	}
+	if filters.Severity != nil {
+		query = query.Where("proctoring_events.severity = ?", *filters.Severity)
+	}
+	if filters.MinSeverity != nil {
+		query = query.Where("proctoring_events.severity >= ?", *filters.MinSeverity)
+	}
+	if filters.ReviewStatus != nil {
+		query = query.Where("proctoring_events.review_status = ?", *filters.ReviewStatus)
+	}
+	if filters.DateFrom != nil {
+		query = query.Where("proctoring_events.created_at >= ?", *filters.DateFrom)
+	}
+	if filters.DateTo != nil {
+		query = query.Where("proctoring_events.created_at <= ?", *filters.DateTo)
+	}
+	return query
+}
+
+func (p *ProctoringEventPostgreSQL) applyPaginationAndSort(query *gorm.DB, filters repositories.ProctoringEventFilters) *gorm.DB {
+	// Whitelist allowed sort columns: map API keys to SQL identifiers
+	sortKeyToColumn := map[string]string{
+		"created_at":  "proctoring_events.created_at",
+		"severity":    "proctoring_events.severity",
+		"type":        "proctoring_events.type",
+		"time_offset": "proctoring_events.time_offset",
+	}
+
+	column, ok := sortKeyToColumn[filters.SortBy]
+	if !ok {
+		column = "proctoring_events.created_at"
+	}
+
+	order := "DESC"
+	if filters.SortOrder == "asc" || filters.SortOrder == "ASC" {
+		order = "ASC"
+	}
+
+	query = query.Order(fmt.Sprintf("%s %s", column, order))
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	return query
+}
+
+func (p *ProctoringEventPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return p.db
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuestionAttachmentPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewQuestionAttachmentPostgreSQL(db *gorm.DB) repositories.QuestionAttachmentRepository {
+	return &QuestionAttachmentPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (q *QuestionAttachmentPostgreSQL) Create(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error {
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Create(attachment).Error; err != nil {
+		return handleDBError(err, "create question attachment")
+	}
+	return nil
+}
+
+// CreateWithinLimit appends an attachment unless the question already has limit of them. The
+// question row stays locked until the insert, so parallel uploads cannot pass the limit together.
+func (q *QuestionAttachmentPostgreSQL) CreateWithinLimit(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment, limit int) (bool, error) {
+	db := q.getDB(tx)
+	created := false
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var question models.Question
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&question, attachment.QuestionID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.QuestionAttachment{}).Where("question_id = ?", attachment.QuestionID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(limit) {
+			return nil
+		}
+
+		attachment.Order = int(count)
+		if err := tx.Omit("Question").Create(attachment).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, handleDBError(err, "create question attachment")
+	}
+	return created, nil
+}
+
+func (q *QuestionAttachmentPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionAttachment, error) {
+	db := q.getDB(tx)
+	var attachment models.QuestionAttachment
+	if err := db.WithContext(ctx).First(&attachment, id).Error; err != nil {
+		return nil, handleDBError(err, "get question attachment by id")
+	}
+	return &attachment, nil
+}
+
+func (q *QuestionAttachmentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error {
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Save(attachment).Error; err != nil {
+		return handleDBError(err, "update question attachment")
+	}
+	return nil
+}
+
+func (q *QuestionAttachmentPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := q.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.QuestionAttachment{}, id)
+	if result.Error != nil {
+		return handleDBError(result.Error, "delete question attachment")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "delete question attachment")
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (q *QuestionAttachmentPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionAttachment, error) {
+	db := q.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order(`"order" ASC, id ASC`).
+		Find(&attachments).Error; err != nil {
+		return nil, handleDBError(err, "get question attachments")
+	}
+	return attachments, nil
+}
+
+func (q *QuestionAttachmentPostgreSQL) GetByQuestions(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]*models.QuestionAttachment, error) {
+	result := make(map[uint][]*models.QuestionAttachment, len(questionIDs))
+	if len(questionIDs) == 0 {
+		return result, nil
+	}
+
+	db := q.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("question_id IN ?", questionIDs).
+		Order(`question_id ASC, "order" ASC, id ASC`).
+		Find(&attachments).Error; err != nil {
+		return nil, handleDBError(err, "get attachments of questions")
+	}
+
+	for _, attachment := range attachments {
+		result[attachment.QuestionID] = append(result[attachment.QuestionID], attachment)
+	}
+	return result, nil
+}
+
+// ===== BULK OPERATIONS =====
+
+func (q *QuestionAttachmentPostgreSQL) CreateBatch(ctx context.Context, tx *gorm.DB, attachments []*models.QuestionAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").CreateInBatches(attachments, 100).Error; err != nil {
+		return handleDBError(err, "create question attachments batch")
+	}
+	return nil
+}
+
+func (q *QuestionAttachmentPostgreSQL) DeleteByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) error {
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Delete(&models.QuestionAttachment{}).Error; err != nil {
+		return handleDBError(err, "delete question attachments")
+	}
+	return nil
+}
+
+// ===== FILE MANAGEMENT =====
+
+// GetOrphanedAttachments returns attachments whose question no longer exists. Questions are
+// hard-deleted without touching their attachments, so these rows and their files are left behind.
+func (q *QuestionAttachmentPostgreSQL) GetOrphanedAttachments(ctx context.Context, tx *gorm.DB) ([]*models.QuestionAttachment, error) {
+	db := q.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("NOT EXISTS (SELECT 1 FROM questions WHERE questions.id = question_attachments.question_id)").
+		Order("id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, handleDBError(err, "get orphaned question attachments")
+	}
+	return attachments, nil
+}
+
+// UpdateOrder sets the display order of a question's attachments; ids of other questions are ignored
+func (q *QuestionAttachmentPostgreSQL) UpdateOrder(ctx context.Context, tx *gorm.DB, questionID uint, attachmentOrders []repositories.AttachmentOrder) error {
+	db := q.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range attachmentOrders {
+			if err := tx.Model(&models.QuestionAttachment{}).
+				Where("id = ? AND question_id = ?", item.AttachmentID, questionID).
+				Update("order", item.Order).Error; err != nil {
+				return handleDBError(err, "update question attachment order")
+			}
+		}
+		return nil
+	})
+}
+
+// ===== HELPER METHODS =====
+
+func (q *QuestionAttachmentPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return q.db
+}
@@ -330,7 +330,8 @@ func (r *questionBankRepository) GetBankQuestions(ctx context.Context, tx *gorm.
 		query = query.Where("q.difficulty = ?", *filters.Difficulty)
 	}
 	if filters.CategoryID != nil {
-		query = query.Where("q.category_id = ?", *filters.CategoryID)
+		clause, args := categoryFilterClause("q.category_id", *filters.CategoryID, filters.IncludeSubcategories)
+		query = query.Where(clause, args...)
 	}
 
 	// Count total
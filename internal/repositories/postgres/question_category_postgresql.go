@@ -0,0 +1,523 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// QuestionCategoryPostgreSQL stores the category tree as a materialized path of IDs
+// ("/1/4/9"), so subtree queries are a single prefix match.
+type QuestionCategoryPostgreSQL struct {
+	db           *gorm.DB
+	cacheManager *cache.CacheManager
+}
+
+func NewQuestionCategoryPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.QuestionCategoryRepository {
+	return &QuestionCategoryPostgreSQL{
+		db:           db,
+		cacheManager: cache.NewCacheManager(redisClient),
+	}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+// Create inserts the category and derives its level and path from the parent
+func (q *QuestionCategoryPostgreSQL) Create(ctx context.Context, tx *gorm.DB, category *models.QuestionCategory) error {
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Parent", "Children", "Questions", "Creator").Create(category).Error; err != nil {
+		return handleDBError(err, "create question category")
+	}
+	if err := q.UpdatePath(ctx, db, category.ID); err != nil {
+		return err
+	}
+
+	created, err := q.GetByID(ctx, db, category.ID)
+	if err != nil {
+		return err
+	}
+	category.Path, category.Level = created.Path, created.Level
+	return nil
+}
+
+func (q *QuestionCategoryPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionCategory, error) {
+	db := q.getDB(tx)
+	var category models.QuestionCategory
+	if err := db.WithContext(ctx).First(&category, id).Error; err != nil {
+		return nil, handleDBError(err, "get question category by id")
+	}
+	return &category, nil
+}
+
+func (q *QuestionCategoryPostgreSQL) GetByIDWithChildren(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionCategory, error) {
+	db := q.getDB(tx)
+	var category models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Preload("Children", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		First(&category, id).Error; err != nil {
+		return nil, handleDBError(err, "get question category with children")
+	}
+	return &category, nil
+}
+
+// Update saves the category's own fields; use MoveCategory to change its parent
+func (q *QuestionCategoryPostgreSQL) Update(ctx context.Context, tx *gorm.DB, category *models.QuestionCategory) error {
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{ID: category.ID}).
+		Select("name", "description", "color", "icon").
+		Updates(category).Error; err != nil {
+		return handleDBError(err, "update question category")
+	}
+	return nil
+}
+
+func (q *QuestionCategoryPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := q.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.QuestionCategory{}, id)
+	if result.Error != nil {
+		return handleDBError(result.Error, "delete question category")
+	}
+	if result.RowsAffected == 0 {
+		return handleDBError(gorm.ErrRecordNotFound, "delete question category")
+	}
+	return nil
+}
+
+// ===== HIERARCHY OPERATIONS =====
+
+func (q *QuestionCategoryPostgreSQL) GetByCreator(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.QuestionCategory, error) {
+	db := q.getDB(tx)
+	var categories []*models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Where("created_by = ?", creatorID).
+		Order("level ASC, name ASC").
+		Find(&categories).Error; err != nil {
+		return nil, handleDBError(err, "get question categories by creator")
+	}
+	return categories, nil
+}
+
+func (q *QuestionCategoryPostgreSQL) GetRootCategories(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.QuestionCategory, error) {
+	db := q.getDB(tx)
+	var categories []*models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Where("created_by = ? AND parent_id IS NULL", creatorID).
+		Order("name ASC").
+		Find(&categories).Error; err != nil {
+		return nil, handleDBError(err, "get root question categories")
+	}
+	return categories, nil
+}
+
+func (q *QuestionCategoryPostgreSQL) GetChildren(ctx context.Context, tx *gorm.DB, parentID uint) ([]*models.QuestionCategory, error) {
+	db := q.getDB(tx)
+	var categories []*models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Where("parent_id = ?", parentID).
+		Order("name ASC").
+		Find(&categories).Error; err != nil {
+		return nil, handleDBError(err, "get question category children")
+	}
+	return categories, nil
+}
+
+// GetHierarchy returns the creator's root categories with Children populated recursively
+func (q *QuestionCategoryPostgreSQL) GetHierarchy(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.QuestionCategory, error) {
+	categories, err := q.GetByCreator(ctx, tx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+// GetPath returns the ancestors of a category from the root down to the category itself
+func (q *QuestionCategoryPostgreSQL) GetPath(ctx context.Context, tx *gorm.DB, categoryID uint) ([]*models.QuestionCategory, error) {
+	category, err := q.GetByID(ctx, tx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	db := q.getDB(tx)
+	var path []*models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Where("id IN ?", categoryPathIDs(category.Path)).
+		Order("level ASC").
+		Find(&path).Error; err != nil {
+		return nil, handleDBError(err, "get question category path")
+	}
+	return path, nil
+}
+
+// ===== TREE OPERATIONS =====
+
+// MoveCategory re-parents a category and rewrites the path and level of its whole subtree
+func (q *QuestionCategoryPostgreSQL) MoveCategory(ctx context.Context, tx *gorm.DB, categoryID uint, newParentID *uint) error {
+	db := q.getDB(tx)
+
+	if err := q.ValidateHierarchy(ctx, db, categoryID, newParentID); err != nil {
+		return err
+	}
+
+	category, err := q.GetByID(ctx, db, categoryID)
+	if err != nil {
+		return err
+	}
+	oldPath, oldLevel := category.Path, category.Level
+
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("id = ?", categoryID).
+		Update("parent_id", newParentID).Error; err != nil {
+		return handleDBError(err, "move question category")
+	}
+
+	if err := q.UpdatePath(ctx, db, categoryID); err != nil {
+		return err
+	}
+
+	moved, err := q.GetByID(ctx, db, categoryID)
+	if err != nil {
+		return err
+	}
+
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("path LIKE ?", oldPath+"/%").
+		Updates(map[string]interface{}{
+			"path":  gorm.Expr("? || SUBSTRING(path FROM ?)", moved.Path, len(oldPath)+1),
+			"level": gorm.Expr("level + ?", moved.Level-oldLevel),
+		}).Error; err != nil {
+		return handleDBError(err, "update question category subtree")
+	}
+
+	return nil
+}
+
+func (q *QuestionCategoryPostgreSQL) GetDescendants(ctx context.Context, tx *gorm.DB, categoryID uint) ([]*models.QuestionCategory, error) {
+	category, err := q.GetByID(ctx, tx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	db := q.getDB(tx)
+	var descendants []*models.QuestionCategory
+	if err := db.WithContext(ctx).
+		Where("path LIKE ?", category.Path+"/%").
+		Order("level ASC, name ASC").
+		Find(&descendants).Error; err != nil {
+		return nil, handleDBError(err, "get question category descendants")
+	}
+	return descendants, nil
+}
+
+// UpdatePath recomputes the path and level of a single category from its parent
+func (q *QuestionCategoryPostgreSQL) UpdatePath(ctx context.Context, tx *gorm.DB, categoryID uint) error {
+	category, err := q.GetByID(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/%d", category.ID)
+	level := 0
+	if category.ParentID != nil {
+		parent, err := q.GetByID(ctx, tx, *category.ParentID)
+		if err != nil {
+			return err
+		}
+		path = fmt.Sprintf("%s/%d", parent.Path, category.ID)
+		level = parent.Level + 1
+	}
+
+	db := q.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("id = ?", categoryID).
+		Updates(map[string]interface{}{"path": path, "level": level}).Error; err != nil {
+		return handleDBError(err, "update question category path")
+	}
+	return nil
+}
+
+// ===== QUESTION ASSIGNMENT =====
+
+// ReassignQuestions moves every question of a category to another one and drops cached questions
+func (q *QuestionCategoryPostgreSQL) ReassignQuestions(ctx context.Context, tx *gorm.DB, fromCategoryID, toCategoryID uint) (int64, error) {
+	db := q.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Where("category_id = ?", fromCategoryID).
+		Update("category_id", toCategoryID)
+	if result.Error != nil {
+		return 0, handleDBError(result.Error, "reassign category questions")
+	}
+
+	if result.RowsAffected > 0 {
+		cache.SafeInvalidatePattern(ctx, q.cacheManager.Question, "id:*")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ===== VALIDATION =====
+
+func (q *QuestionCategoryPostgreSQL) ExistsByName(ctx context.Context, tx *gorm.DB, name string, creatorID string, parentID *uint) (bool, error) {
+	db := q.getDB(tx)
+	query := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("LOWER(name) = LOWER(?) AND created_by = ?", strings.TrimSpace(name), creatorID)
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, handleDBError(err, "check question category name")
+	}
+	return count > 0, nil
+}
+
+func (q *QuestionCategoryPostgreSQL) HasQuestions(ctx context.Context, tx *gorm.DB, id uint) (bool, error) {
+	db := q.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Where("category_id = ?", id).
+		Count(&count).Error; err != nil {
+		return false, handleDBError(err, "check question category questions")
+	}
+	return count > 0, nil
+}
+
+func (q *QuestionCategoryPostgreSQL) HasChildren(ctx context.Context, tx *gorm.DB, id uint) (bool, error) {
+	db := q.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("parent_id = ?", id).
+		Count(&count).Error; err != nil {
+		return false, handleDBError(err, "check question category children")
+	}
+	return count > 0, nil
+}
+
+// ValidateHierarchy rejects moving a category under itself or one of its descendants
+func (q *QuestionCategoryPostgreSQL) ValidateHierarchy(ctx context.Context, tx *gorm.DB, categoryID uint, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == categoryID {
+		return repositories.ErrCategoryHierarchyCycle
+	}
+
+	category, err := q.GetByID(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	parent, err := q.GetByID(ctx, tx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(parent.Path, category.Path+"/") {
+		return repositories.ErrCategoryHierarchyCycle
+	}
+	return nil
+}
+
+// ===== STATISTICS =====
+
+func (q *QuestionCategoryPostgreSQL) GetCategoryStats(ctx context.Context, tx *gorm.DB, categoryID uint) (*repositories.CategoryStats, error) {
+	category, err := q.GetByID(ctx, tx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	db := q.getDB(tx)
+	stats := &repositories.CategoryStats{
+		QuestionsByType: make(map[models.QuestionType]int),
+		QuestionsByDiff: make(map[models.DifficultyLevel]int),
+	}
+
+	subtree := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Select("id").
+		Where("id = ? OR path LIKE ?", categoryID, category.Path+"/%")
+
+	var byType []struct {
+		Type  models.QuestionType
+		Count int
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Select("type, COUNT(*) as count").
+		Where("category_id IN (?)", subtree).
+		Group("type").
+		Scan(&byType).Error; err != nil {
+		return nil, handleDBError(err, "get question category stats by type")
+	}
+	for _, row := range byType {
+		stats.QuestionsByType[row.Type] = row.Count
+		stats.QuestionCount += row.Count
+	}
+
+	var byDifficulty []struct {
+		Difficulty models.DifficultyLevel
+		Count      int
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Select("difficulty, COUNT(*) as count").
+		Where("category_id IN (?)", subtree).
+		Group("difficulty").
+		Scan(&byDifficulty).Error; err != nil {
+		return nil, handleDBError(err, "get question category stats by difficulty")
+	}
+	for _, row := range byDifficulty {
+		stats.QuestionsByDiff[row.Difficulty] = row.Count
+	}
+
+	var subcategories int64
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionCategory{}).
+		Where("path LIKE ?", category.Path+"/%").
+		Count(&subcategories).Error; err != nil {
+		return nil, handleDBError(err, "count question subcategories")
+	}
+	stats.SubcategoryCount = int(subcategories)
+
+	var usage int64
+	if err := db.WithContext(ctx).
+		Table("assessment_questions").
+		Joins("JOIN questions ON questions.id = assessment_questions.question_id").
+		Where("questions.category_id IN (?) AND assessment_questions.deleted_at IS NULL", subtree).
+		Count(&usage).Error; err != nil {
+		return nil, handleDBError(err, "count question category usage")
+	}
+	stats.TotalUsage = int(usage)
+
+	return stats, nil
+}
+
+// GetCategoriesWithCounts returns every category of the creator with its direct and subtree question counts
+func (q *QuestionCategoryPostgreSQL) GetCategoriesWithCounts(ctx context.Context, tx *gorm.DB, creatorID string) ([]*repositories.CategoryWithCount, error) {
+	categories, err := q.GetByCreator(ctx, tx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return []*repositories.CategoryWithCount{}, nil
+	}
+
+	ids := make([]uint, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+
+	db := q.getDB(tx)
+	var directCounts []struct {
+		CategoryID uint
+		Count      int
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Select("category_id, COUNT(*) as count").
+		Where("category_id IN ?", ids).
+		Group("category_id").
+		Scan(&directCounts).Error; err != nil {
+		return nil, handleDBError(err, "count questions per category")
+	}
+
+	direct := make(map[uint]int, len(directCounts))
+	for _, row := range directCounts {
+		direct[row.CategoryID] = row.Count
+	}
+
+	// Every category contributes its direct count to itself and all of its ancestors
+	total := make(map[uint]int, len(categories))
+	for _, category := range categories {
+		for _, ancestorID := range categoryPathIDs(category.Path) {
+			total[ancestorID] += direct[category.ID]
+		}
+	}
+
+	result := make([]*repositories.CategoryWithCount, len(categories))
+	for i, category := range categories {
+		category.QuestionCount = total[category.ID]
+		result[i] = &repositories.CategoryWithCount{
+			QuestionCategory: category,
+			QuestionCount:    total[category.ID],
+			DirectCount:      direct[category.ID],
+			TotalCount:       total[category.ID],
+		}
+	}
+
+	return result, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (q *QuestionCategoryPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return q.db
+}
+
+// categoryPathIDs parses a materialized path ("/1/4/9") into its category IDs
+func categoryPathIDs(path string) []uint {
+	var ids []uint
+	for _, part := range strings.Split(strings.Trim(path, "/"), "/") {
+		var id uint
+		if _, err := fmt.Sscanf(part, "%d", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// buildCategoryTree links categories (ordered by level) into trees and returns the roots
+func buildCategoryTree(categories []*models.QuestionCategory) []*models.QuestionCategory {
+	byID := make(map[uint]*models.QuestionCategory, len(categories))
+	for _, category := range categories {
+		category.Children = nil
+		byID[category.ID] = category
+	}
+
+	var roots []*models.QuestionCategory
+	for _, category := range categories {
+		if category.ParentID == nil || byID[*category.ParentID] == nil {
+			roots = append(roots, category)
+		}
+	}
+
+	// Children is a value slice, so attach bottom-up: deepest levels first
+	for i := len(categories) - 1; i >= 0; i-- {
+		category := categories[i]
+		if category.ParentID == nil {
+			continue
+		}
+		if parent := byID[*category.ParentID]; parent != nil {
+			parent.Children = append([]models.QuestionCategory{*category}, parent.Children...)
+		}
+	}
+
+	return roots
+}
+
+// categoryFilterClause matches a category column against a category, or against its whole subtree
+func categoryFilterClause(column string, categoryID uint, includeSubcategories bool) (string, []interface{}) {
+	if !includeSubcategories {
+		return column + " = ?", []interface{}{categoryID}
+	}
+	return column + " IN (SELECT c.id FROM question_categories c JOIN question_categories root ON root.id = ? " +
+		"WHERE c.id = root.id OR c.path LIKE root.path || '/%')", []interface{}{categoryID}
+}
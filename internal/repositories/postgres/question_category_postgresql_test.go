@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategoryFilterClause(t *testing.T) {
+	tests := []struct {
+		name                 string
+		includeSubcategories bool
+		wantClause           string
+	}{
+		{"category only", false, "q.category_id = ?"},
+		{"whole subtree", true, "q.category_id IN (SELECT c.id FROM question_categories c JOIN question_categories root ON root.id = ? " +
+			"WHERE c.id = root.id OR c.path LIKE root.path || '/%')"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clause, args := categoryFilterClause("q.category_id", 7, tt.includeSubcategories)
+			if clause != tt.wantClause {
+				t.Errorf("clause = %q, want %q", clause, tt.wantClause)
+			}
+			if want := []interface{}{uint(7)}; !reflect.DeepEqual(args, want) {
+				t.Errorf("args = %v, want %v", args, want)
+			}
+		})
+	}
+}
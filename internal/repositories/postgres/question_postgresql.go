@@ -326,7 +326,8 @@ func (q *QuestionPostgreSQL) GetRandomQuestions(ctx context.Context, tx *gorm.DB
 
 	// Apply filters
 	if filters.CategoryID != nil {
-		query = query.Where("category_id = ?", *filters.CategoryID)
+		clause, args := categoryFilterClause("category_id", *filters.CategoryID, filters.IncludeSubcategories)
+		query = query.Where(clause, args...)
 	}
 	if filters.Difficulty != nil {
 		query = query.Where("difficulty = ?", *filters.Difficulty)
@@ -365,9 +366,7 @@ func (q *QuestionPostgreSQL) GetQuestionBank(ctx context.Context, tx *gorm.DB, c
 		query = query.Where("difficulty = ?", *filters.Difficulty)
 	}
 	if len(filters.Tags) > 0 {
-		for _, tag := range filters.Tags {
-			query = query.Where("tags::text LIKE ?", "%\""+tag+"\"%")
-		}
+		query = query.Where("tags @> ?::jsonb", tagsContainment(filters.Tags))
 	}
 
 	// Count total records
@@ -394,9 +393,9 @@ func (q *QuestionPostgreSQL) GetByTags(ctx context.Context, tx *gorm.DB, tags []
 	db := q.getDB(tx)
 	query := db.WithContext(ctx).Model(&models.Question{})
 
-	// Apply tag filters using JSONB operations
-	for _, tag := range tags {
-		query = query.Where("tags::text LIKE ?", "%\""+tag+"\"%")
+	// Apply tag filters using JSONB containment, served by the GIN index on tags
+	if len(tags) > 0 {
+		query = query.Where("tags @> ?::jsonb", tagsContainment(tags))
 	}
 
 	// Apply additional filters
@@ -748,15 +747,14 @@ func (q *QuestionPostgreSQL) applyQuestionFilters(query *gorm.DB, filters reposi
 		query = query.Where("difficulty = ?", *filters.Difficulty)
 	}
 	if filters.CategoryID != nil {
-		query = query.Where("category_id = ?", *filters.CategoryID)
+		clause, args := categoryFilterClause("category_id", *filters.CategoryID, filters.IncludeSubcategories)
+		query = query.Where(clause, args...)
 	}
 	if filters.CreatedBy != nil {
 		query = query.Where("created_by = ?", *filters.CreatedBy)
 	}
 	if len(filters.Tags) > 0 {
-		for _, tag := range filters.Tags {
-			query = query.Where("tags::text LIKE ?", "%\""+tag+"\"%")
-		}
+		query = query.Where("tags @> ?::jsonb", tagsContainment(filters.Tags))
 	}
 
 	return query
@@ -811,3 +809,9 @@ func (q *QuestionPostgreSQL) IsExistInAttempt(ctx context.Context, tx *gorm.DB,
 
 	return count > 0, nil
 }
+
+// tagsContainment encodes tags as a JSON array for a "tags @> ?" filter, which matches questions having all of them
+func tagsContainment(tags []string) string {
+	encoded, _ := json.Marshal(tags)
+	return string(encoded)
+}
@@ -66,6 +66,9 @@ func (h *SharedHelpers) ApplyAssessmentFilters(query *gorm.DB, filters repositor
 	if filters.CreatedBy != nil {
 		query = query.Where("created_by = ?", *filters.CreatedBy)
 	}
+	if filters.AssignedTo != nil {
+		query = query.Where("assessments.id IN ("+assignedAssessmentIDsSQL+")", *filters.AssignedTo, *filters.AssignedTo)
+	}
 	if filters.DateFrom != nil {
 		query = query.Where("created_at >= ?", *filters.DateFrom)
 	}
@@ -89,6 +92,9 @@ func (h *SharedHelpers) ApplyAttemptFilters(query *gorm.DB, filters repositories
 	if filters.DateTo != nil {
 		query = query.Where("created_at <= ?", *filters.DateTo)
 	}
+	if filters.SessionFlagged != nil {
+		query = query.Where("session_flagged = ?", *filters.SessionFlagged)
+	}
 
 	return query
 }
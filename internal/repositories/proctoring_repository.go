@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProctoringEventRepository interface for proctoring event operations
+type ProctoringEventRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, event *models.ProctoringEvent) error
+	CreateBatch(ctx context.Context, tx *gorm.DB, events []*models.ProctoringEvent) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ProctoringEvent, error)
+	Update(ctx context.Context, tx *gorm.DB, event *models.ProctoringEvent) error
+
+	// Query operations
+	GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint, filters ProctoringEventFilters) ([]*models.ProctoringEvent, int64, error)
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, filters ProctoringEventFilters) ([]*models.ProctoringEvent, int64, error)
+
+	// Review operations
+	UpdateReview(ctx context.Context, tx *gorm.DB, id uint, status string, notes *string, reviewerID string) error
+
+	// Statistics
+	GetAttemptSummary(ctx context.Context, tx *gorm.DB, attemptID uint) (*ProctoringSummary, error)
+}
+
+// ===== ADDITIONAL STRUCTS =====
+
+type ProctoringSummary struct {
+	AttemptID        uint                               `json:"attempt_id"`
+	TotalEvents      int                                `json:"total_events"`
+	PendingReview    int                                `json:"pending_review"`
+	MaxSeverity      int                                `json:"max_severity"`
+	EventsByType     map[models.ProctoringEventType]int `json:"events_by_type"`
+	EventsBySeverity map[int]int                        `json:"events_by_severity"`
+}
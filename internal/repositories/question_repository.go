@@ -80,6 +80,9 @@ type QuestionCategoryRepository interface {
 	GetDescendants(ctx context.Context, tx *gorm.DB, categoryID uint) ([]*models.QuestionCategory, error)
 	UpdatePath(ctx context.Context, tx *gorm.DB, categoryID uint) error
 
+	// Question assignment
+	ReassignQuestions(ctx context.Context, tx *gorm.DB, fromCategoryID, toCategoryID uint) (int64, error)
+
 	// Validation
 	ExistsByName(ctx context.Context, tx *gorm.DB, name string, creatorID string, parentID *uint) (bool, error)
 	HasQuestions(ctx context.Context, tx *gorm.DB, id uint) (bool, error)
@@ -95,6 +98,7 @@ type QuestionCategoryRepository interface {
 type QuestionAttachmentRepository interface {
 	// Basic CRUD operations
 	Create(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error
+	CreateWithinLimit(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment, limit int) (bool, error) // False when the question already has limit attachments
 	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionAttachment, error)
 	Update(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error
 	Delete(ctx context.Context, tx *gorm.DB, id uint) error
@@ -7,6 +7,7 @@ type Repository interface {
 	// Assessment domain
 	Assessment() AssessmentRepository
 	AssessmentSettings() AssessmentSettingsRepository
+	AssessmentVersion() AssessmentVersionRepository
 
 	// Question domain
 	Question() QuestionRepository
@@ -20,6 +21,10 @@ type Repository interface {
 	// Attempt domain
 	Attempt() AttemptRepository
 	Answer() AnswerRepository
+	ProctoringEvent() ProctoringEventRepository
+
+	// Grading domain
+	GradingScheme() GradingSchemeRepository
 
 	// User domain (read-only for assessment service)
 	User() UserRepository
@@ -27,6 +32,21 @@ type Repository interface {
 	// Dashboard domain
 	Dashboard() DashboardRepository
 
+	// Audit domain
+	Audit() AuditRepository
+
+	// Analytics domain
+	Analytics() AnalyticsRepository
+
+	// Import/export domain
+	ImportJob() ImportJobRepository
+
+	// Notification domain
+	Notification() NotificationRepository
+
+	// Enrollment domain
+	Class() ClassRepository
+
 	// Transaction support
 	WithTransaction(ctx context.Context, fn func(Repository) error) error
 
@@ -6,6 +6,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrCategoryHierarchyCycle is returned when a category would be moved under itself or one of its descendants
+var ErrCategoryHierarchyCycle = errors.New("category cannot be moved under itself or its descendants")
+
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, gorm.ErrRecordNotFound)
 }
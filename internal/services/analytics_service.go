@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type analyticsService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewAnalyticsService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AnalyticsService {
+	return &analyticsService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// extremeGroupRatio is the share of attempts in the upper and lower groups used for item analysis
+const extremeGroupRatio = 0.27
+
+// ===== RECALCULATION =====
+
+// RecalculateAssessment rebuilds the assessment analytics and the analytics of every question in it
+func (s *analyticsService) RecalculateAssessment(ctx context.Context, assessmentID uint) (*AssessmentAnalyticsResponse, error) {
+	s.logger.Info("Recalculating assessment analytics", "assessment_id", assessmentID)
+
+	results, err := s.repo.Analytics().GetAttemptResults(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempt results: %w", err)
+	}
+
+	analytics := buildAssessmentAnalytics(assessmentID, results)
+	if err := s.repo.Analytics().UpsertAssessmentAnalytics(ctx, s.db, analytics); err != nil {
+		return nil, fmt.Errorf("failed to save assessment analytics: %w", err)
+	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	response := &AssessmentAnalyticsResponse{
+		Analytics: analytics,
+		Questions: make([]*models.QuestionAnalytics, 0, len(assessmentQuestions)),
+	}
+	for _, aq := range assessmentQuestions {
+		questionAnalytics, err := s.RecalculateQuestion(ctx, aq.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		response.Questions = append(response.Questions, questionAnalytics)
+	}
+
+	s.logger.Info("Assessment analytics recalculated",
+		"assessment_id", assessmentID,
+		"attempts", analytics.TotalAttempts,
+		"questions", len(response.Questions))
+
+	return response, nil
+}
+
+// RecalculateQuestion rebuilds the item analysis of a question across every graded attempt that contains it
+func (s *analyticsService) RecalculateQuestion(ctx context.Context, questionID uint) (*models.QuestionAnalytics, error) {
+	question, err := s.repo.Question().GetByID(ctx, s.db, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	responses, err := s.repo.Analytics().GetQuestionResponses(ctx, s.db, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question responses: %w", err)
+	}
+
+	analytics := buildQuestionAnalytics(question, responses)
+	if err := s.repo.Analytics().UpsertQuestionAnalytics(ctx, s.db, analytics); err != nil {
+		return nil, fmt.Errorf("failed to save question analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
+// ===== QUERIES =====
+
+// GetAssessmentAnalytics returns stored analytics, calculating them on first access
+func (s *analyticsService) GetAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsResponse, error) {
+	if err := s.checkAssessmentAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	analytics, err := s.repo.Analytics().GetAssessmentAnalytics(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return s.RecalculateAssessment(ctx, assessmentID)
+		}
+		return nil, fmt.Errorf("failed to get assessment analytics: %w", err)
+	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	questionIDs := make([]uint, len(assessmentQuestions))
+	for i, aq := range assessmentQuestions {
+		questionIDs[i] = aq.QuestionID
+	}
+
+	questions, err := s.repo.Analytics().GetQuestionAnalyticsByIDs(ctx, s.db, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question analytics: %w", err)
+	}
+
+	return &AssessmentAnalyticsResponse{
+		Analytics: analytics,
+		Questions: questions,
+	}, nil
+}
+
+func (s *analyticsService) GetQuestionAnalytics(ctx context.Context, questionID uint, userID string) (*models.QuestionAnalytics, error) {
+	if err := s.checkQuestionAccess(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+
+	analytics, err := s.repo.Analytics().GetQuestionAnalytics(ctx, s.db, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return s.RecalculateQuestion(ctx, questionID)
+		}
+		return nil, fmt.Errorf("failed to get question analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
+func (s *analyticsService) RefreshAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsResponse, error) {
+	if err := s.checkAssessmentAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+	return s.RecalculateAssessment(ctx, assessmentID)
+}
+
+func (s *analyticsService) RefreshQuestionAnalytics(ctx context.Context, questionID uint, userID string) (*models.QuestionAnalytics, error) {
+	if err := s.checkQuestionAccess(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+	return s.RecalculateQuestion(ctx, questionID)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *analyticsService) checkAssessmentAccess(ctx context.Context, assessmentID uint, userID string) error {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, assessmentID, "assessment", "view_analytics", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *analyticsService) checkQuestionAccess(ctx context.Context, questionID uint, userID string) error {
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := questionService.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, questionID, "question", "view_analytics", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func buildAssessmentAnalytics(assessmentID uint, results []repositories.AttemptResult) *models.AssessmentAnalytics {
+	now := time.Now()
+	analytics := &models.AssessmentAnalytics{
+		AssessmentID:     assessmentID,
+		TotalAttempts:    len(results),
+		LastCalculatedAt: now,
+		UpdatedAt:        now,
+	}
+
+	var scores []float64
+	var times []float64
+	for _, result := range results {
+		switch result.Status {
+		case models.AttemptCompleted, models.AttemptTimeOut:
+			analytics.CompletedAttempts++
+		case models.AttemptAbandoned:
+			analytics.AbandonedAttempts++
+		}
+
+		// Only fully graded attempts contribute to score statistics
+		if !result.IsGraded {
+			continue
+		}
+		scores = append(scores, result.Percentage)
+		times = append(times, float64(result.TimeSpent))
+		if result.Passed {
+			analytics.PassedCount++
+		} else {
+			analytics.FailedCount++
+		}
+	}
+
+	if len(scores) > 0 {
+		analytics.AverageScore = roundTo(mean(scores), 2)
+		analytics.MedianScore = roundTo(median(scores), 2)
+		analytics.HighestScore = slicesMax(scores)
+		analytics.LowestScore = slicesMin(scores)
+		analytics.StandardDeviation = roundTo(stdDev(scores), 2)
+		analytics.AverageTimeSpent = int(math.Round(mean(times)))
+		analytics.MedianTimeSpent = int(math.Round(median(times)))
+		analytics.PassRate = roundTo(float64(analytics.PassedCount)/float64(len(scores)), 4)
+	}
+
+	analytics.ScoreDistribution, _ = json.Marshal(scoreDistribution(scores))
+	analytics.TimeDistribution, _ = json.Marshal(timeDistribution(times))
+
+	return analytics
+}
+
+func buildQuestionAnalytics(question *models.Question, responses []repositories.QuestionResponse) *models.QuestionAnalytics {
+	now := time.Now()
+	analytics := &models.QuestionAnalytics{
+		QuestionID:       question.ID,
+		TotalResponses:   len(responses),
+		LastCalculatedAt: now,
+		UpdatedAt:        now,
+	}
+
+	if len(responses) > 0 {
+		var scoreSum float64
+		var timeSum int
+		correct := make([]bool, len(responses))
+		totals := make([]float64, len(responses))
+		for i, response := range responses {
+			correct[i] = isResponseCorrect(response)
+			totals[i] = response.AttemptPercentage
+			if correct[i] {
+				analytics.CorrectResponses++
+			}
+			scoreSum += response.Score
+			timeSum += response.TimeSpent
+		}
+
+		analytics.DifficultyIndex = roundTo(float64(analytics.CorrectResponses)/float64(len(responses)), 4)
+		analytics.DiscriminationIndex = roundTo(pointBiserial(correct, totals), 4)
+		analytics.AverageScore = roundTo(scoreSum/float64(len(responses)), 2)
+		analytics.AverageTimeSpent = timeSum / len(responses)
+
+		top, bottom := extremeGroupCorrect(correct, totals)
+		analytics.TopQuartileCorrect = roundTo(top, 4)
+		analytics.BottomQuartileCorrect = roundTo(bottom, 4)
+	}
+
+	if question.Type == models.MultipleChoice {
+		analytics.OptionStats, _ = json.Marshal(multipleChoiceOptionStats(question, responses))
+	}
+
+	return analytics
+}
+
+// isResponseCorrect treats an answer as correct when marked so, or when it earned full points
+func isResponseCorrect(response repositories.QuestionResponse) bool {
+	if response.IsCorrect != nil {
+		return *response.IsCorrect
+	}
+	return response.MaxScore > 0 && response.Score >= float64(response.MaxScore)
+}
+
+// pointBiserial correlates a dichotomous item result with the total score:
+// r = (M1 - M0) / s * sqrt(p * q), with s the population standard deviation of the totals
+func pointBiserial(correct []bool, totals []float64) float64 {
+	var correctTotals, incorrectTotals []float64
+	for i, c := range correct {
+		if c {
+			correctTotals = append(correctTotals, totals[i])
+		} else {
+			incorrectTotals = append(incorrectTotals, totals[i])
+		}
+	}
+
+	sd := stdDev(totals)
+	if len(correctTotals) == 0 || len(incorrectTotals) == 0 || sd == 0 {
+		return 0
+	}
+
+	p := float64(len(correctTotals)) / float64(len(totals))
+	return (mean(correctTotals) - mean(incorrectTotals)) / sd * math.Sqrt(p*(1-p))
+}
+
+// extremeGroupCorrect returns the share of correct responses in the upper and lower 27% of attempts by total score
+func extremeGroupCorrect(correct []bool, totals []float64) (float64, float64) {
+	order := make([]int, len(totals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return totals[order[i]] > totals[order[j]] })
+
+	groupSize := int(math.Ceil(float64(len(order)) * extremeGroupRatio))
+	if groupSize == 0 {
+		return 0, 0
+	}
+
+	share := func(indexes []int) float64 {
+		count := 0
+		for _, i := range indexes {
+			if correct[i] {
+				count++
+			}
+		}
+		return float64(count) / float64(len(indexes))
+	}
+
+	return share(order[:groupSize]), share(order[len(order)-groupSize:])
+}
+
+func multipleChoiceOptionStats(question *models.Question, responses []repositories.QuestionResponse) []models.OptionStat {
+	var content models.MultipleChoiceContent
+	if err := json.Unmarshal(question.Content, &content); err != nil {
+		return nil
+	}
+
+	correctSet := make(map[string]bool, len(content.CorrectAnswers))
+	for _, id := range content.CorrectAnswers {
+		correctSet[id] = true
+	}
+
+	counts := make(map[string]int, len(content.Options))
+	for _, response := range responses {
+		for _, selected := range parseSelectedOptions(response.Answer) {
+			counts[selected]++
+		}
+	}
+
+	stats := make([]models.OptionStat, 0, len(content.Options))
+	for _, option := range content.Options {
+		stat := models.OptionStat{
+			OptionID:       option.ID,
+			OptionText:     option.Text,
+			SelectionCount: counts[option.ID],
+			IsCorrect:      correctSet[option.ID],
+		}
+		if len(responses) > 0 {
+			stat.SelectionRate = roundTo(float64(stat.SelectionCount)/float64(len(responses)), 4)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
+
+// parseSelectedOptions accepts both the multi-select ([]string) and single-select (string) answer formats
+func parseSelectedOptions(answer []byte) []string {
+	var selected []string
+	if err := json.Unmarshal(answer, &selected); err == nil {
+		return selected
+	}
+	var single string
+	if err := json.Unmarshal(answer, &single); err == nil {
+		return []string{single}
+	}
+	return nil
+}
+
+func scoreDistribution(percentages []float64) []models.ScoreBucket {
+	buckets := make([]models.ScoreBucket, 10)
+	for i := range buckets {
+		if i == 9 {
+			buckets[i].Range = "90-100"
+		} else {
+			buckets[i].Range = fmt.Sprintf("%d-%d", i*10, i*10+9)
+		}
+	}
+	for _, p := range percentages {
+		index := int(p / 10)
+		if index > 9 {
+			index = 9
+		}
+		if index < 0 {
+			index = 0
+		}
+		buckets[index].Count++
+	}
+	return buckets
+}
+
+func timeDistribution(seconds []float64) []models.TimeBucket {
+	labels := []string{"0-5 min", "5-10 min", "10-15 min", "15-30 min", "30-60 min", "60+ min"}
+	limits := []float64{5 * 60, 10 * 60, 15 * 60, 30 * 60, 60 * 60}
+
+	buckets := make([]models.TimeBucket, len(labels))
+	for i, label := range labels {
+		buckets[i].Range = label
+	}
+	for _, t := range seconds {
+		index := len(limits)
+		for i, limit := range limits {
+			if t < limit {
+				index = i
+				break
+			}
+		}
+		buckets[index].Count++
+	}
+	return buckets
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// stdDev returns the population standard deviation
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+func slicesMax(values []float64) float64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		result = math.Max(result, v)
+	}
+	return result
+}
+
+func slicesMin(values []float64) float64 {
+	result := values[0]
+	for _, v := range values[1:] {
+		result = math.Min(result, v)
+	}
+	return result
+}
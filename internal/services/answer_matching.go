@@ -0,0 +1,219 @@
+package services
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// defaultFuzzyThreshold is the minimum similarity for fuzzy partial credit
+const defaultFuzzyThreshold = 0.8
+
+// numericAnswerPattern splits a typed number from its unit, e.g. "9,81 m/s2"
+var numericAnswerPattern = regexp.MustCompile(`^([-+]?(?:\d+(?:[.,]\d+)?|[.,]\d+)(?:[eE][-+]?\d+)?)\s*(.*)$`)
+
+// answerMatcher compares typed answers with the accepted answers and rules of a short answer
+// question or a blank
+type answerMatcher struct {
+	rules          []models.AnswerRule
+	caseSensitive  bool
+	foldDiacritics bool
+	fuzzy          bool
+	fuzzyThreshold float64
+}
+
+func newAnswerMatcher(accepted []string, rules []models.AnswerRule, caseSensitive, foldDiacritics, fuzzy bool, threshold *float64) answerMatcher {
+	matcher := answerMatcher{
+		rules:          make([]models.AnswerRule, 0, len(accepted)+len(rules)),
+		caseSensitive:  caseSensitive,
+		foldDiacritics: foldDiacritics,
+		fuzzy:          fuzzy,
+		fuzzyThreshold: defaultFuzzyThreshold,
+	}
+	for _, answer := range accepted {
+		matcher.rules = append(matcher.rules, models.AnswerRule{Type: models.AnswerMatchText, Value: answer})
+	}
+	matcher.rules = append(matcher.rules, rules...)
+	if threshold != nil {
+		matcher.fuzzyThreshold = *threshold
+	}
+	return matcher
+}
+
+// match returns the share of credit earned by answer and whether it matched a rule exactly.
+// Only text rules are considered for fuzzy partial credit.
+func (m answerMatcher) match(answer string) (float64, bool) {
+	normalized := m.normalize(answer)
+
+	for _, rule := range m.rules {
+		if m.matchRule(rule, answer, normalized) {
+			return 1.0, true
+		}
+	}
+
+	if !m.fuzzy || normalized == "" {
+		return 0.0, false
+	}
+
+	bestMatch := 0.0
+	for _, rule := range m.rules {
+		if rule.Type != "" && rule.Type != models.AnswerMatchText {
+			continue
+		}
+		for _, candidate := range append([]string{rule.Value}, rule.Synonyms...) {
+			bestMatch = math.Max(bestMatch, stringSimilarity(normalized, m.normalize(candidate)))
+		}
+	}
+
+	if bestMatch >= m.fuzzyThreshold {
+		return bestMatch, false
+	}
+	return 0.0, false
+}
+
+func (m answerMatcher) matchRule(rule models.AnswerRule, answer, normalized string) bool {
+	switch rule.Type {
+	case models.AnswerMatchRegex:
+		pattern, err := compileAnswerPattern(m.fold(rule.Value), m.caseSensitive)
+		if err != nil {
+			return false
+		}
+		return pattern.MatchString(normalized)
+
+	case models.AnswerMatchNumeric:
+		return matchNumeric(rule, answer)
+
+	default:
+		for _, candidate := range append([]string{rule.Value}, rule.Synonyms...) {
+			if candidate != "" && normalized == m.normalize(candidate) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// normalize puts text in NFC, collapses whitespace and applies case and diacritic folding
+func (m answerMatcher) normalize(text string) string {
+	text = strings.Join(strings.Fields(m.fold(norm.NFC.String(text))), " ")
+	if !m.caseSensitive {
+		text = strings.ToLower(text)
+	}
+	return text
+}
+
+func (m answerMatcher) fold(text string) string {
+	if !m.foldDiacritics {
+		return text
+	}
+	return foldDiacritics(text)
+}
+
+// compileAnswerPattern anchors pattern so it must match the whole answer
+func compileAnswerPattern(pattern string, caseSensitive bool) (*regexp.Regexp, error) {
+	flags := ""
+	if !caseSensitive {
+		flags = "(?i)"
+	}
+	return regexp.Compile(flags + `^(?:` + norm.NFC.String(pattern) + `)$`)
+}
+
+// foldDiacritics strips combining marks, so "Hà Nội" becomes "Ha Noi". Vietnamese đ has no
+// decomposition and is mapped separately.
+func foldDiacritics(text string) string {
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, text)
+	if err != nil {
+		return text
+	}
+	return strings.NewReplacer("đ", "d", "Đ", "D").Replace(folded)
+}
+
+// matchNumeric accepts a number within the rule's tolerance
+func matchNumeric(rule models.AnswerRule, answer string) bool {
+	expected, err := parseNumber(rule.Value)
+	if err != nil {
+		return false
+	}
+
+	quantity, ok := parseQuantity(answer)
+	if !ok {
+		return false
+	}
+	value, ok := quantity.convertTo(rule.Unit, rule.UnitRequired, rule.UnitFactors)
+	if !ok {
+		return false
+	}
+
+	return withinTolerance(value, expected, rule.AbsoluteTolerance, rule.RelativeTolerance)
+}
+
+// numericQuantity is a typed number split from its unit
+type numericQuantity struct {
+	Value  float64
+	Number string // As typed, for counting significant figures
+	Unit   string
+}
+
+func parseQuantity(answer string) (numericQuantity, bool) {
+	parts := numericAnswerPattern.FindStringSubmatch(strings.TrimSpace(norm.NFC.String(answer)))
+	if parts == nil {
+		return numericQuantity{}, false
+	}
+	value, err := parseNumber(parts[1])
+	if err != nil {
+		return numericQuantity{}, false
+	}
+	return numericQuantity{Value: value, Number: parts[1], Unit: normalizeUnit(parts[2])}, true
+}
+
+// convertTo returns the value in unit. Answers in one of the other accepted units are converted;
+// a missing unit is accepted unless one is required.
+func (q numericQuantity) convertTo(unit string, unitRequired bool, factors map[string]float64) (float64, bool) {
+	switch {
+	case q.Unit == "":
+		return q.Value, !unitRequired || unit == ""
+	case q.Unit == normalizeUnit(unit):
+		return q.Value, true
+	default:
+		factor, ok := unitFactor(factors, q.Unit)
+		return q.Value * factor, ok
+	}
+}
+
+// parseNumber accepts a decimal comma as well as a decimal point
+func parseNumber(text string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(strings.TrimSpace(text), ",", ".", 1), 64)
+}
+
+func normalizeUnit(unit string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(unit)), "")
+}
+
+func unitFactor(factors map[string]float64, unit string) (float64, bool) {
+	for name, factor := range factors {
+		if normalizeUnit(name) == unit {
+			return factor, true
+		}
+	}
+	return 0, false
+}
+
+func withinTolerance(value, expected float64, absolute, relative *float64) bool {
+	difference := math.Abs(value - expected)
+	if absolute == nil && relative == nil {
+		return difference <= 1e-9*math.Max(1, math.Abs(expected))
+	}
+	if absolute != nil && difference <= *absolute {
+		return true
+	}
+	return relative != nil && difference <= *relative*math.Abs(expected)
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// SystemUserID is recorded as the actor of changes made by background jobs
+const SystemUserID = "system"
+
+const lifecycleSchedulerLockKey = "assessment-lifecycle-scheduler"
+
+type LifecycleSchedulerConfig struct {
+	Enabled         bool
+	Interval        time.Duration   // how often due transitions and reminders are looked up
+	BatchSize       int             // scheduled publishes handled per run
+	ReminderOffsets []time.Duration // remind students this long before the due date, e.g. 24h and 1h
+}
+
+// assessmentLifecycleScheduler publishes drafts at their AvailableFrom time, expires active
+// assessments past their due date and sends due-date reminders. Only the replica holding the
+// Redis lease runs it.
+type assessmentLifecycleScheduler struct {
+	repo        repositories.Repository
+	db          *gorm.DB
+	logger      *slog.Logger
+	assessments AssessmentService
+	notifier    NotificationEventService
+	config      LifecycleSchedulerConfig
+	job         *periodicJob
+}
+
+func newAssessmentLifecycleScheduler(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, assessments AssessmentService, cacheManager *cache.CacheManager, notifier NotificationEventService, config LifecycleSchedulerConfig) *assessmentLifecycleScheduler {
+	offsets := make([]time.Duration, 0, len(config.ReminderOffsets))
+	for _, offset := range config.ReminderOffsets {
+		if offset > 0 {
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	config.ReminderOffsets = offsets
+
+	w := &assessmentLifecycleScheduler{
+		repo:        repo,
+		db:          db,
+		logger:      logger,
+		assessments: assessments,
+		notifier:    notifier,
+		config:      config,
+	}
+	lock := cacheManager.NewLeaderLock(lifecycleSchedulerLockKey, 3*config.Interval)
+	w.job = newPeriodicJob(lifecycleSchedulerLockKey, config.Interval, lock, logger, w.Run)
+	return w
+}
+
+// Start runs the scheduler periodically until Shutdown is called
+func (w *assessmentLifecycleScheduler) Start() {
+	w.job.Start()
+}
+
+// Run performs one pass: scheduled publishes, expiries, then reminders
+func (w *assessmentLifecycleScheduler) Run(ctx context.Context) {
+	now := time.Now()
+
+	if err := w.publishScheduled(ctx, now); err != nil && ctx.Err() == nil {
+		w.logger.Error("Failed to publish scheduled assessments", "error", err)
+	}
+	if err := w.expireOverdue(ctx); err != nil && ctx.Err() == nil {
+		w.logger.Error("Failed to expire overdue assessments", "error", err)
+	}
+	if err := w.sendReminders(ctx, now); err != nil && ctx.Err() == nil {
+		w.logger.Error("Failed to send due date reminders", "error", err)
+	}
+}
+
+func (w *assessmentLifecycleScheduler) publishScheduled(ctx context.Context, now time.Time) error {
+	assessments, err := w.repo.Assessment().GetScheduledForPublish(ctx, w.db, now, w.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get scheduled assessments: %w", err)
+	}
+
+	for _, assessment := range assessments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := w.assessments.ApplyScheduledStatus(ctx, assessment.ID, models.StatusActive, "Scheduled publish")
+		if err == nil {
+			continue
+		}
+
+		// Not publishable as is (no questions, due date passed): drop the schedule instead of
+		// retrying every run, the teacher can publish by hand once it is fixed
+		var businessErr *BusinessRuleError
+		if !errors.As(err, &businessErr) {
+			w.logger.Error("Failed to publish scheduled assessment", "assessment_id", assessment.ID, "error", err)
+			continue
+		}
+
+		w.logger.Warn("Scheduled assessment cannot be published, schedule cleared",
+			"assessment_id", assessment.ID,
+			"reason", businessErr.Message)
+		assessment.AvailableFrom = nil
+		if err := w.repo.Assessment().Update(ctx, w.db, assessment); err != nil {
+			w.logger.Error("Failed to clear assessment schedule", "assessment_id", assessment.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (w *assessmentLifecycleScheduler) expireOverdue(ctx context.Context) error {
+	assessments, err := w.repo.Assessment().GetExpiredAssessments(ctx, w.db)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue assessments: %w", err)
+	}
+
+	for _, assessment := range assessments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := w.assessments.ApplyScheduledStatus(ctx, assessment.ID, models.StatusExpired, "Due date passed"); err != nil {
+			w.logger.Error("Failed to expire assessment", "assessment_id", assessment.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// sendReminders notifies students of assessments due within a reminder offset. Each offset fires
+// once per due date; when an assessment is already inside a smaller offset the larger ones are
+// skipped, so a student never gets the 24h and the 1h reminder at the same time.
+func (w *assessmentLifecycleScheduler) sendReminders(ctx context.Context, now time.Time) error {
+	if len(w.config.ReminderOffsets) == 0 || w.notifier == nil {
+		return nil
+	}
+
+	horizon := w.config.ReminderOffsets[len(w.config.ReminderOffsets)-1]
+	assessments, err := w.repo.Assessment().GetDueWithin(ctx, w.db, now, now.Add(horizon))
+	if err != nil {
+		return fmt.Errorf("failed to get assessments due soon: %w", err)
+	}
+
+	for _, assessment := range assessments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		remaining := assessment.DueDate.Sub(now)
+		for i, offset := range w.config.ReminderOffsets {
+			if remaining > offset {
+				continue
+			}
+			if err := w.sendReminder(ctx, assessment.ID, remaining, w.config.ReminderOffsets[i:]); err != nil {
+				w.logger.Error("Failed to send due date reminder", "assessment_id", assessment.ID, "offset", offset, "error", err)
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// sendReminder sends the reminder of offsets[0] and records it together with the larger offsets it
+// replaces. The records are committed only once the notification is out, so a failed send is
+// retried on the next run.
+func (w *assessmentLifecycleScheduler) sendReminder(ctx context.Context, assessmentID uint, remaining time.Duration, offsets []time.Duration) error {
+	return w.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		claimed, err := txRepo.Assessment().MarkReminderSent(ctx, nil, assessmentID, int(offsets[0].Minutes()))
+		if err != nil {
+			return fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if !claimed {
+			return nil
+		}
+
+		for _, larger := range offsets[1:] {
+			if _, err := txRepo.Assessment().MarkReminderSent(ctx, nil, assessmentID, int(larger.Minutes())); err != nil {
+				return fmt.Errorf("failed to record reminder: %w", err)
+			}
+		}
+
+		notifyCtx, cancel := context.WithTimeout(ctx, notificationTimeout)
+		defer cancel()
+		return w.notifier.NotifyAssessmentExpiring(notifyCtx, assessmentID, int(math.Ceil(remaining.Hours())))
+	})
+}
+
+// Shutdown stops the scheduler loop, waits for a running pass and gives up the lease
+func (w *assessmentLifecycleScheduler) Shutdown(ctx context.Context) error {
+	return w.job.Shutdown(ctx)
+}
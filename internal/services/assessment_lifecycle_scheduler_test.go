@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// scheduleRepository keeps assessments and sent reminders in memory
+type scheduleRepository struct {
+	repositories.Repository
+	assessments *scheduleAssessmentRepository
+}
+
+func (r *scheduleRepository) Assessment() repositories.AssessmentRepository { return r.assessments }
+
+// WithTransaction drops the reminders recorded by fn when it fails, like a rollback
+func (r *scheduleRepository) WithTransaction(ctx context.Context, fn func(repositories.Repository) error) error {
+	saved := map[uint]map[int]bool{}
+	for id, offsets := range r.assessments.reminders {
+		saved[id] = map[int]bool{}
+		for offset := range offsets {
+			saved[id][offset] = true
+		}
+	}
+
+	if err := fn(r); err != nil {
+		r.assessments.reminders = saved
+		return err
+	}
+	return nil
+}
+
+type scheduleAssessmentRepository struct {
+	repositories.AssessmentRepository
+
+	now         time.Time
+	assessments []*models.Assessment
+	reminders   map[uint]map[int]bool
+	updated     map[uint]*models.Assessment
+}
+
+func (r *scheduleAssessmentRepository) GetScheduledForPublish(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]*models.Assessment, error) {
+	var due []*models.Assessment
+	for _, a := range r.assessments {
+		if a.Status == models.StatusDraft && a.AvailableFrom != nil && !a.AvailableFrom.After(before) {
+			due = append(due, a)
+		}
+	}
+	return due, nil
+}
+
+func (r *scheduleAssessmentRepository) GetExpiredAssessments(ctx context.Context, tx *gorm.DB) ([]*models.Assessment, error) {
+	var expired []*models.Assessment
+	for _, a := range r.assessments {
+		if a.Status == models.StatusActive && a.DueDate != nil && a.DueDate.Before(r.now) {
+			expired = append(expired, a)
+		}
+	}
+	return expired, nil
+}
+
+func (r *scheduleAssessmentRepository) GetDueWithin(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*models.Assessment, error) {
+	var due []*models.Assessment
+	for _, a := range r.assessments {
+		if a.Status == models.StatusActive && a.DueDate != nil && a.DueDate.After(from) && !a.DueDate.After(to) {
+			due = append(due, a)
+		}
+	}
+	return due, nil
+}
+
+func (r *scheduleAssessmentRepository) MarkReminderSent(ctx context.Context, tx *gorm.DB, assessmentID uint, offsetMinutes int) (bool, error) {
+	if r.reminders[assessmentID] == nil {
+		r.reminders[assessmentID] = map[int]bool{}
+	}
+	if r.reminders[assessmentID][offsetMinutes] {
+		return false, nil
+	}
+	r.reminders[assessmentID][offsetMinutes] = true
+	return true, nil
+}
+
+func (r *scheduleAssessmentRepository) Update(ctx context.Context, tx *gorm.DB, assessment *models.Assessment) error {
+	saved := *assessment
+	r.updated[assessment.ID] = &saved
+	return nil
+}
+
+// scheduleAssessmentService applies status changes unless an error is set for the assessment
+type scheduleAssessmentService struct {
+	AssessmentService
+	errs    map[uint]error
+	applied map[uint]models.AssessmentStatus
+}
+
+func (s *scheduleAssessmentService) ApplyScheduledStatus(ctx context.Context, id uint, status models.AssessmentStatus, reason string) error {
+	if err := s.errs[id]; err != nil {
+		return err
+	}
+	s.applied[id] = status
+	return nil
+}
+
+// reminderNotifier records the hours remaining of the expiry reminders sent, or fails with err
+type reminderNotifier struct {
+	NotificationEventService
+	err  error
+	sent []int
+}
+
+func (n *reminderNotifier) NotifyAssessmentExpiring(ctx context.Context, assessmentID uint, hoursRemaining int) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, hoursRemaining)
+	return nil
+}
+
+func newTestScheduler(now time.Time, assessments []*models.Assessment, errs map[uint]error) (*assessmentLifecycleScheduler, *scheduleAssessmentRepository, *scheduleAssessmentService) {
+	repo := &scheduleAssessmentRepository{
+		now:         now,
+		assessments: assessments,
+		reminders:   map[uint]map[int]bool{},
+		updated:     map[uint]*models.Assessment{},
+	}
+	service := &scheduleAssessmentService{errs: errs, applied: map[uint]models.AssessmentStatus{}}
+	scheduler := &assessmentLifecycleScheduler{
+		repo:        &scheduleRepository{assessments: repo},
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		assessments: service,
+		config:      LifecycleSchedulerConfig{BatchSize: 100, ReminderOffsets: []time.Duration{time.Hour, 24 * time.Hour}},
+	}
+	return scheduler, repo, service
+}
+
+func TestAssessmentLifecycleScheduler_PublishScheduled(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		assessment  models.Assessment
+		err         error
+		wantApplied bool
+		wantCleared bool
+	}{
+		{"due schedule is published", models.Assessment{Status: models.StatusDraft, AvailableFrom: &past}, nil, true, false},
+		{"schedule due exactly now is published", models.Assessment{Status: models.StatusDraft, AvailableFrom: &now}, nil, true, false},
+		{"future schedule waits", models.Assessment{Status: models.StatusDraft, AvailableFrom: &future}, nil, false, false},
+		{"unpublishable draft has its schedule cleared", models.Assessment{Status: models.StatusDraft, AvailableFrom: &past},
+			NewBusinessRuleError("has_questions", "assessment has no questions", nil), false, true},
+		{"other errors are retried next run", models.Assessment{Status: models.StatusDraft, AvailableFrom: &past},
+			errors.New("connection reset"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := tt.assessment
+			assessment.ID = 1
+			scheduler, repo, service := newTestScheduler(now, []*models.Assessment{&assessment}, map[uint]error{1: tt.err})
+
+			if err := scheduler.publishScheduled(context.Background(), now); err != nil {
+				t.Fatalf("publishScheduled() error = %v", err)
+			}
+
+			status, applied := service.applied[1]
+			if applied != tt.wantApplied || (applied && status != models.StatusActive) {
+				t.Errorf("published = %v (%s), want %v", applied, status, tt.wantApplied)
+			}
+			updated, cleared := repo.updated[1]
+			if cleared != tt.wantCleared || (cleared && updated.AvailableFrom != nil) {
+				t.Errorf("schedule cleared = %v, want %v", cleared, tt.wantCleared)
+			}
+		})
+	}
+}
+
+func TestAssessmentLifecycleScheduler_ExpireOverdue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	scheduler, _, service := newTestScheduler(now, []*models.Assessment{
+		{ID: 1, Status: models.StatusActive, DueDate: &past},
+		{ID: 2, Status: models.StatusActive, DueDate: &future},
+		{ID: 3, Status: models.StatusActive},
+	}, nil)
+
+	if err := scheduler.expireOverdue(context.Background()); err != nil {
+		t.Fatalf("expireOverdue() error = %v", err)
+	}
+
+	want := map[uint]models.AssessmentStatus{1: models.StatusExpired}
+	if !reflect.DeepEqual(service.applied, want) {
+		t.Errorf("expired = %v, want %v", service.applied, want)
+	}
+}
+
+func TestAssessmentLifecycleScheduler_SendReminders(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		dueIn         time.Duration
+		alreadySent   []int
+		sendErr       error
+		wantSent      []int // hours remaining of the reminders sent
+		wantReminders []int
+	}{
+		{"outside every window", 30 * time.Hour, nil, nil, nil, nil},
+		{"inside the 24h window", 20 * time.Hour, nil, nil, []int{20}, []int{1440}},
+		{"24h reminder already sent", 20 * time.Hour, []int{1440}, nil, nil, []int{1440}},
+		{"inside the 1h window skips the 24h reminder", 30 * time.Minute, nil, nil, []int{1}, []int{60, 1440}},
+		{"1h reminder after the 24h one", 30 * time.Minute, []int{1440}, nil, []int{1}, []int{60, 1440}},
+		{"both reminders already sent", 30 * time.Minute, []int{60, 1440}, nil, nil, []int{60, 1440}},
+		{"failed send is retried next run", 30 * time.Minute, []int{1440}, errors.New("broker unavailable"), nil, []int{1440}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dueDate := now.Add(tt.dueIn)
+			scheduler, repo, _ := newTestScheduler(now, []*models.Assessment{{ID: 1, Status: models.StatusActive, DueDate: &dueDate}}, nil)
+			for _, offset := range tt.alreadySent {
+				repo.MarkReminderSent(context.Background(), nil, 1, offset)
+			}
+
+			notifier := &reminderNotifier{err: tt.sendErr}
+			scheduler.notifier = notifier
+
+			if err := scheduler.sendReminders(context.Background(), now); err != nil {
+				t.Fatalf("sendReminders() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(notifier.sent, tt.wantSent) {
+				t.Errorf("reminders sent = %v, want %v", notifier.sent, tt.wantSent)
+			}
+
+			var reminders []int
+			for offset := range repo.reminders[1] {
+				reminders = append(reminders, offset)
+			}
+			sort.Ints(reminders)
+			if !reflect.DeepEqual(reminders, tt.wantReminders) {
+				t.Errorf("reminders recorded = %v, want %v", reminders, tt.wantReminders)
+			}
+		})
+	}
+}
@@ -15,18 +15,22 @@ import (
 type assessmentService struct {
 	repo            repositories.Repository
 	questionService QuestionService
+	audit           AuditService
 	db              *gorm.DB
 	logger          *slog.Logger
 	validator       *validator.Validator
+	notifier        NotificationEventService
 }
 
-func NewAssessmentService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AssessmentService {
+func NewAssessmentService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, notifier NotificationEventService) AssessmentService {
 	return &assessmentService{
 		repo:            repo,
 		db:              db,
 		logger:          logger,
 		validator:       validator,
 		questionService: NewQuestionService(repo, db, logger, validator),
+		audit:           NewAuditService(repo, db, logger, validator),
+		notifier:        notifier,
 	}
 }
 
@@ -59,16 +63,17 @@ func (s *assessmentService) Create(ctx context.Context, req *CreateAssessmentReq
 	err = s.withTx(ctx, func(tx *gorm.DB) error {
 		// Create assessment
 		assessment = &models.Assessment{
-			Title:        req.Title,
-			Description:  req.Description,
-			Duration:     req.Duration,
-			Status:       models.StatusDraft,
-			PassingScore: req.PassingScore,
-			MaxAttempts:  req.MaxAttempts,
-			TimeWarning:  300, // Default 5 minutes
-			DueDate:      req.DueDate,
-			CreatedBy:    creatorID,
-			Version:      1,
+			Title:         req.Title,
+			Description:   req.Description,
+			Duration:      req.Duration,
+			Status:        models.StatusDraft,
+			PassingScore:  req.PassingScore,
+			MaxAttempts:   req.MaxAttempts,
+			TimeWarning:   300, // Default 5 minutes
+			DueDate:       req.DueDate,
+			AvailableFrom: req.AvailableFrom,
+			CreatedBy:     creatorID,
+			Version:       1,
 		}
 
 		if req.TimeWarning != nil {
@@ -101,6 +106,15 @@ func (s *assessmentService) Create(ctx context.Context, req *CreateAssessmentReq
 
 	s.logger.Info("Assessment created successfully", "assessment_id", assessment.ID)
 
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditAssessmentCreated,
+		UserID:      creatorID,
+		TargetType:  "assessment",
+		TargetID:    &assessment.ID,
+		Description: fmt.Sprintf("Assessment '%s' created", assessment.Title),
+		After:       assessmentAuditSnapshot(assessment),
+	})
+
 	// Return response
 	return s.GetByIDWithDetails(ctx, assessment.ID, creatorID)
 }
@@ -180,6 +194,8 @@ func (s *assessmentService) Update(ctx context.Context, id uint, req *UpdateAsse
 		return nil, err
 	}
 
+	before := assessmentAuditSnapshot(assessment)
+
 	// Begin transaction at service layer
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Apply updates
@@ -213,6 +229,21 @@ func (s *assessmentService) Update(ctx context.Context, id uint, req *UpdateAsse
 
 	s.logger.Info("Assessment updated successfully", "assessment_id", id)
 
+	// Published content changed, new attempts get a new version
+	if assessment.Status == models.StatusActive {
+		s.snapshotVersion(ctx, id, userID, VersionReasonUpdated)
+	}
+
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditAssessmentUpdated,
+		UserID:      userID,
+		TargetType:  "assessment",
+		TargetID:    &id,
+		Description: fmt.Sprintf("Assessment '%s' updated", assessment.Title),
+		Before:      before,
+		After:       assessmentAuditSnapshot(assessment),
+	})
+
 	// Return updated assessment
 	return s.GetByIDWithDetails(ctx, id, userID)
 }
@@ -229,12 +260,29 @@ func (s *assessmentService) Delete(ctx context.Context, id uint, userID string)
 		return NewPermissionError(userID, id, "assessment", "delete", "not owner or assessment has attempts")
 	}
 
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssessmentNotFound
+		}
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
 	// Soft delete
 	if err := s.repo.Assessment().Delete(ctx, s.db, id); err != nil {
 		return fmt.Errorf("failed to delete assessment: %w", err)
 	}
 
 	s.logger.Info("Assessment deleted successfully", "assessment_id", id)
+
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditAssessmentDeleted,
+		UserID:      userID,
+		TargetType:  "assessment",
+		TargetID:    &id,
+		Description: fmt.Sprintf("Assessment '%s' deleted", assessment.Title),
+		Before:      assessmentAuditSnapshot(assessment),
+	})
 	return nil
 }
 
@@ -250,9 +298,10 @@ func (s *assessmentService) List(ctx context.Context, filters repositories.Asses
 	// Apply role-based filtering
 	switch userRole {
 	case models.RoleStudent:
-		// Students: only Active assessments that haven't expired
+		// Students: only Active assessments assigned to them that haven't expired
 		activeStatus := models.StatusActive
 		filters.Status = &activeStatus
+		filters.AssignedTo = &userID
 
 	case models.RoleTeacher:
 		// Teachers: only their own assessments
@@ -339,9 +388,10 @@ func (s *assessmentService) Search(ctx context.Context, query string, filters re
 	// Apply role-based filtering (same as List)
 	switch userRole {
 	case models.RoleStudent:
-		// Students: only Active assessments that haven't expired
+		// Students: only Active assessments assigned to them that haven't expired
 		activeStatus := models.StatusActive
 		filters.Status = &activeStatus
+		filters.AssignedTo = &userID
 
 	case models.RoleTeacher:
 		// Teachers: only their own assessments
@@ -422,12 +472,43 @@ func (s *assessmentService) UpdateStatus(ctx context.Context, id uint, req *Upda
 		return fmt.Errorf("failed to get assessment: %w", err)
 	}
 
+	return s.changeStatus(ctx, assessment, req, userID)
+}
+
+// ApplyScheduledStatus performs a status transition on behalf of the lifecycle scheduler.
+// Ownership checks are skipped, the transition rules still apply.
+func (s *assessmentService) ApplyScheduledStatus(ctx context.Context, id uint, status models.AssessmentStatus, reason string) error {
+	s.logger.Info("Applying scheduled assessment status", "assessment_id", id, "new_status", status)
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssessmentNotFound
+		}
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	if assessment.Status == status {
+		return nil // Already applied, e.g. published by hand
+	}
+
+	return s.changeStatus(ctx, assessment, &UpdateStatusRequest{
+		Status: status,
+		Reason: &reason,
+	}, SystemUserID)
+}
+
+// changeStatus validates and persists a status transition, then records the audit entry and notifies students
+func (s *assessmentService) changeStatus(ctx context.Context, assessment *models.Assessment, req *UpdateStatusRequest, userID string) error {
+	id := assessment.ID
+
 	// Validate status transition
 	if err := s.validateStatusTransition(ctx, assessment, req.Status); err != nil {
 		return err
 	}
 
 	// Update status
+	previousStatus := assessment.Status
 	assessment.Status = req.Status
 	assessment.UpdatedAt = time.Now()
 
@@ -440,6 +521,42 @@ func (s *assessmentService) UpdateStatus(ctx context.Context, id uint, req *Upda
 		"new_status", req.Status,
 		"reason", req.Reason)
 
+	if req.Status == models.StatusActive && previousStatus != models.StatusActive {
+		s.snapshotVersion(ctx, id, userID, VersionReasonPublished)
+	}
+
+	eventType := models.AuditAssessmentUpdated
+	if req.Status == models.StatusActive {
+		eventType = models.AuditAssessmentPublished
+	}
+	metadata := map[string]interface{}{}
+	if req.Reason != nil {
+		metadata["reason"] = *req.Reason
+	}
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   eventType,
+		UserID:      userID,
+		TargetType:  "assessment",
+		TargetID:    &id,
+		Description: fmt.Sprintf("Assessment '%s' status changed from %s to %s", assessment.Title, previousStatus, req.Status),
+		Before:      map[string]interface{}{"status": previousStatus},
+		After:       map[string]interface{}{"status": req.Status},
+		Metadata:    metadata,
+	})
+
+	if previousStatus != req.Status {
+		switch req.Status {
+		case models.StatusActive:
+			notifyAsync(s.notifier, s.logger, "assessment_published", func(ctx context.Context, notifier NotificationEventService) error {
+				return notifier.NotifyAssessmentPublished(ctx, id)
+			})
+		case models.StatusExpired:
+			notifyAsync(s.notifier, s.logger, "assessment_expired", func(ctx context.Context, notifier NotificationEventService) error {
+				return notifier.NotifyAssessmentExpired(ctx, id)
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -825,6 +942,14 @@ func (s *assessmentService) UpdateAssessmentQuestion(ctx context.Context, assess
 		return fmt.Errorf("failed to check if question exists in assessment: %w", err)
 	}
 
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if validationErrors := validateQuestionMarking("negative_marking_value", settings, assessmentQuestion, req); len(validationErrors) > 0 {
+		return validationErrors
+	}
+
 	// Validate total points would not exceed 100 (excluding current question's points)
 	if err := s.validateTotalPoints(ctx, nil, assessmentID, req.Points, questionID); err != nil {
 		return fmt.Errorf("points validation failed: %w", err)
@@ -837,6 +962,7 @@ func (s *assessmentService) UpdateAssessmentQuestion(ctx context.Context, assess
 	if req.TimeLimit != nil {
 		assessmentQuestion.TimeLimit = req.TimeLimit
 	}
+	applyNegativeMarkingOverride(assessmentQuestion, req)
 
 	if err := s.repo.AssessmentQuestion().Update(ctx, s.db, assessmentQuestion); err != nil {
 		return fmt.Errorf("failed to update assessment question: %w", err)
@@ -908,14 +1034,21 @@ func (s *assessmentService) UpdateAssessmentQuestionBatch(ctx context.Context, a
 			return fmt.Errorf("failed to get current total points: %w", err)
 		}
 
+		settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, tx, assessmentID)
+		if err != nil {
+			return fmt.Errorf("failed to get assessment settings: %w", err)
+		}
+
 		// Calculate new total by subtracting old points and adding new points for each question
 		updatedQuestionPoints := make(map[uint]int)
-		for _, req := range reqs {
+		var validationErrors ValidationErrors
+		for i, req := range reqs {
 			// Get current points for this question
 			assessmentQuestion, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, tx, assessmentID, req.QuestionId)
 			if err != nil {
 				return fmt.Errorf("failed to get assessment question (question_id: %d): %w", req.QuestionId, err)
 			}
+			validationErrors = append(validationErrors, validateQuestionMarking(fmt.Sprintf("[%d].negative_marking_value", i), settings, assessmentQuestion, &req)...)
 
 			// Track old and new points
 			oldPoints := 0
@@ -927,6 +1060,9 @@ func (s *assessmentService) UpdateAssessmentQuestionBatch(ctx context.Context, a
 
 			updatedQuestionPoints[req.QuestionId] = req.Points
 		}
+		if len(validationErrors) > 0 {
+			return validationErrors
+		}
 
 		// Validate new total
 		if currentTotal > 100 {
@@ -948,6 +1084,7 @@ func (s *assessmentService) UpdateAssessmentQuestionBatch(ctx context.Context, a
 			if req.TimeLimit != nil {
 				assessmentQuestion.TimeLimit = req.TimeLimit
 			}
+			applyNegativeMarkingOverride(assessmentQuestion, &req)
 			// Save
 			if err := s.repo.AssessmentQuestion().Update(ctx, tx, assessmentQuestion); err != nil {
 				return fmt.Errorf("failed to update assessment question (question_id: %d): %w", req.QuestionId, err)
@@ -38,11 +38,9 @@ func (s *assessmentService) CanAccess(ctx context.Context, assessmentID uint, us
 		return true, nil
 	}
 
-	// Students can access active assessments they're enrolled in
+	// Students can access active assessments assigned to them or their classes
 	if userRole == models.RoleStudent && assessment.Status == models.StatusActive {
-		// TODO: Check if student is enrolled in assessment/course
-		// For now, allow all students to access active assessments
-		return true, nil
+		return s.repo.Class().IsAssigned(ctx, s.db, assessmentID, userID)
 	}
 
 	return false, nil
@@ -154,10 +152,13 @@ func (s *assessmentService) CanTake(ctx context.Context, assessmentID uint, user
 		return false, nil
 	}
 
-	// TODO: Check enrollment/assignment status
-	// For now, allow all students to take active assessments
+	// Check enrollment: the assessment must be assigned to the student or one of their classes
+	assigned, err := s.repo.Class().IsAssigned(ctx, s.db, assessmentID, userID)
+	if err != nil {
+		return false, err
+	}
 
-	return true, nil
+	return assigned, nil
 }
 
 // ===== HELPER FUNCTIONS =====
@@ -212,6 +213,10 @@ func (s *assessmentService) buildAssessmentSettings(assessmentID uint, req *Asse
 		AllowScreenReader:           false,
 		FontSizeAdjustment:          0,
 		HighContrastMode:            false,
+		RequireSubmitConfirmation:   false,
+		NegativeMarking:             models.NegativeMarkingNone,
+		NegativeMarkingValue:        0,
+		ConfidenceMarking:           false,
 	}
 
 	// Apply provided settings
@@ -244,6 +249,9 @@ func (s *assessmentService) applyAssessmentUpdates(assessment *models.Assessment
 	if req.DueDate != nil {
 		assessment.DueDate = req.DueDate
 	}
+	if req.AvailableFrom != nil {
+		assessment.AvailableFrom = req.AvailableFrom
+	}
 
 	assessment.Version += 1
 	assessment.UpdatedAt = time.Now()
@@ -286,6 +294,46 @@ func (s *assessmentService) applySettingsUpdates(settings *models.AssessmentSett
 	if req.HighContrastMode != nil {
 		settings.HighContrastMode = *req.HighContrastMode
 	}
+	if req.RequireSubmitConfirmation != nil {
+		settings.RequireSubmitConfirmation = *req.RequireSubmitConfirmation
+	}
+	if req.NegativeMarking != nil {
+		settings.NegativeMarking = *req.NegativeMarking
+	}
+	if req.NegativeMarkingValue != nil {
+		settings.NegativeMarkingValue = *req.NegativeMarkingValue
+	}
+	if req.ConfidenceMarking != nil {
+		settings.ConfidenceMarking = *req.ConfidenceMarking
+	}
+}
+
+// applyNegativeMarkingOverride stores the negative marking a question uses instead of the assessment's
+func applyNegativeMarkingOverride(assessmentQuestion *models.AssessmentQuestion, req *UpdateAssessmentQuestionRequest) {
+	if req.NegativeMarking != nil {
+		assessmentQuestion.NegativeMarking = req.NegativeMarking
+	}
+	if req.NegativeMarkingValue != nil {
+		assessmentQuestion.NegativeMarkingValue = req.NegativeMarkingValue
+	}
+}
+
+// validateNegativeMarking checks that a fraction deduction is at most the question points. mode and
+// value are the marking that applies once the update is saved, not just what the request sends.
+func validateNegativeMarking(field string, mode models.NegativeMarkingMode, value float64) ValidationErrors {
+	if mode != models.NegativeMarkingFraction || value <= 1 {
+		return nil
+	}
+	return ValidationErrors{*NewValidationError(field, "fraction deductions must be between 0 and 1", value)}
+}
+
+// validateQuestionMarking validates the marking of a question with req applied to its override,
+// falling back to the assessment settings for what the question inherits
+func validateQuestionMarking(field string, settings *models.AssessmentSettings, assessmentQuestion *models.AssessmentQuestion, req *UpdateAssessmentQuestionRequest) ValidationErrors {
+	updated := *assessmentQuestion
+	applyNegativeMarkingOverride(&updated, req)
+	marking := mergeMarking(settings, &updated)
+	return validateNegativeMarking(field, marking.NegativeMarking, marking.NegativeMarkingValue)
 }
 
 func (s *assessmentService) addQuestionsToAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, questions []AssessmentQuestionRequest, userID string) error {
@@ -318,6 +366,16 @@ func (s *assessmentService) validateCreateRequest(ctx context.Context, req *Crea
 		errors = append(errors, *NewValidationError("due_date", "must be in the future", req.DueDate))
 	}
 
+	// Validate publish schedule
+	if req.AvailableFrom != nil && req.DueDate != nil && !req.AvailableFrom.Before(*req.DueDate) {
+		errors = append(errors, *NewValidationError("available_from", "must be before the due date", req.AvailableFrom))
+	}
+
+	if req.Settings != nil {
+		settings := s.buildAssessmentSettings(0, req.Settings)
+		errors = append(errors, validateNegativeMarking("settings.negative_marking_value", settings.NegativeMarking, settings.NegativeMarkingValue)...)
+	}
+
 	// Validate questions if provided
 	if len(req.Questions) > 0 {
 		orderMap := make(map[int]bool)
@@ -377,6 +435,26 @@ func (s *assessmentService) validateUpdateRequest(ctx context.Context, req *Upda
 		errors = append(errors, *NewValidationError("due_date", "must be in the future", req.DueDate))
 	}
 
+	// Validate publish schedule against the resulting dates; it only applies while the assessment is a draft
+	if req.AvailableFrom != nil {
+		dueDate := assessment.DueDate
+		if req.DueDate != nil {
+			dueDate = req.DueDate
+		}
+		if assessment.Status != models.StatusDraft {
+			errors = append(errors, *NewValidationError("available_from", "can only be scheduled for draft assessments", req.AvailableFrom))
+		} else if dueDate != nil && !req.AvailableFrom.Before(*dueDate) {
+			errors = append(errors, *NewValidationError("available_from", "must be before the due date", req.AvailableFrom))
+		}
+	}
+
+	// Validate negative marking against the stored settings the request is merged into
+	if req.Settings != nil {
+		settings := assessment.Settings
+		s.applySettingsUpdates(&settings, req.Settings)
+		errors = append(errors, validateNegativeMarking("settings.negative_marking_value", settings.NegativeMarking, settings.NegativeMarkingValue)...)
+	}
+
 	// Business rule: Cannot change certain fields if assessment has attempts
 	if assessment.Status != models.StatusDraft {
 		hasAttempts, err := s.repo.Assessment().HasAttempts(ctx, s.db, assessment.ID)
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
 	"gorm.io/gorm"
@@ -15,6 +16,7 @@ func TestNewAssessmentService(t *testing.T) {
 		db        *gorm.DB
 		logger    *slog.Logger
 		validator *validator.Validator
+		notifier  NotificationEventService
 	}
 	tests := []struct {
 		name string
@@ -25,7 +27,36 @@ func TestNewAssessmentService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			NewAssessmentService(tt.args.repo, tt.args.db, tt.args.logger, tt.args.validator)
+			NewAssessmentService(tt.args.repo, tt.args.db, tt.args.logger, tt.args.validator, tt.args.notifier)
+		})
+	}
+}
+
+func TestValidateQuestionMarking(t *testing.T) {
+	fraction, fixed := models.NegativeMarkingFraction, models.NegativeMarkingFixed
+	half, five := 0.5, 5.0
+	settings := &models.AssessmentSettings{NegativeMarking: models.NegativeMarkingFraction, NegativeMarkingValue: 0.25}
+
+	tests := []struct {
+		name      string
+		settings  *models.AssessmentSettings
+		question  models.AssessmentQuestion
+		req       UpdateAssessmentQuestionRequest
+		wantValid bool
+	}{
+		{"inherited marking", settings, models.AssessmentQuestion{}, UpdateAssessmentQuestionRequest{}, true},
+		{"value within the inherited fraction mode", settings, models.AssessmentQuestion{}, UpdateAssessmentQuestionRequest{NegativeMarkingValue: &half}, true},
+		{"value over the inherited fraction mode", settings, models.AssessmentQuestion{}, UpdateAssessmentQuestionRequest{NegativeMarkingValue: &five}, false},
+		{"value over the stored fraction mode", &models.AssessmentSettings{}, models.AssessmentQuestion{NegativeMarking: &fraction}, UpdateAssessmentQuestionRequest{NegativeMarkingValue: &five}, false},
+		{"switching to fraction mode over a stored value", &models.AssessmentSettings{}, models.AssessmentQuestion{NegativeMarkingValue: &five}, UpdateAssessmentQuestionRequest{NegativeMarking: &fraction}, false},
+		{"fixed mode override", settings, models.AssessmentQuestion{}, UpdateAssessmentQuestionRequest{NegativeMarking: &fixed, NegativeMarkingValue: &five}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateQuestionMarking("negative_marking_value", tt.settings, &tt.question, &tt.req)
+			if valid := len(errs) == 0; valid != tt.wantValid {
+				t.Errorf("validateQuestionMarking() = %v, want valid %v", errs, tt.wantValid)
+			}
 		})
 	}
 }
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Reasons recorded with an assessment version
+const (
+	VersionReasonPublished      = "published"
+	VersionReasonUpdated        = "updated"
+	VersionReasonAttemptStarted = "attempt_started"
+)
+
+// ===== VERSION QUERIES =====
+
+func (s *assessmentService) ListVersions(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentVersion, error) {
+	if err := s.checkVersionAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.AssessmentVersion().ListByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessment versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (s *assessmentService) GetVersion(ctx context.Context, assessmentID uint, version int, userID string) (*models.AssessmentVersion, error) {
+	if err := s.checkVersionAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getVersion(ctx, assessmentID, version)
+}
+
+// DiffVersions lists what changed between two versions of an assessment
+func (s *assessmentService) DiffVersions(ctx context.Context, assessmentID uint, fromVersion, toVersion int, userID string) (*AssessmentVersionDiff, error) {
+	if err := s.checkVersionAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	from, err := s.getVersion(ctx, assessmentID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getVersion(ctx, assessmentID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	fromSnapshot, err := decodeSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := decodeSnapshot(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSnapshots(fromSnapshot, toSnapshot)
+	diff.AssessmentID = assessmentID
+	diff.FromVersion = fromVersion
+	diff.ToVersion = toVersion
+
+	return diff, nil
+}
+
+// checkVersionAccess allows admins and the owning teacher; versions include the answer keys
+func (s *assessmentService) checkVersionAccess(ctx context.Context, assessmentID uint, userID string) error {
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userRole == models.RoleTeacher || userRole == models.RoleAdmin {
+		canAccess, err := s.CanAccess(ctx, assessmentID, userID)
+		if err != nil {
+			return err
+		}
+		if canAccess {
+			return nil
+		}
+	}
+
+	return NewPermissionError(userID, assessmentID, "assessment", "view_versions", "not owner or insufficient permissions")
+}
+
+func (s *assessmentService) getVersion(ctx context.Context, assessmentID uint, version int) (*models.AssessmentVersion, error) {
+	assessmentVersion, err := s.repo.AssessmentVersion().GetByVersion(ctx, s.db, assessmentID, version)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment version: %w", err)
+	}
+	return assessmentVersion, nil
+}
+
+// ===== SNAPSHOTS =====
+
+// snapshotVersion records a version after a change was committed. Failures are only logged,
+// starting an attempt takes the snapshot again.
+func (s *assessmentService) snapshotVersion(ctx context.Context, assessmentID uint, userID, reason string) {
+	version, err := snapshotAssessment(ctx, s.repo, s.db, assessmentID, userID, reason)
+	if err != nil {
+		s.logger.Error("Failed to snapshot assessment version",
+			"assessment_id", assessmentID,
+			"reason", reason,
+			"error", err)
+		return
+	}
+
+	s.logger.Info("Assessment version recorded",
+		"assessment_id", assessmentID,
+		"version", version.Version,
+		"reason", reason)
+}
+
+// snapshotAssessment stores the current content of an assessment as a new version, unless it matches
+// the latest version, and returns the version matching the current content. Edits to questions are
+// picked up by the next snapshot, at the latest when a student starts an attempt.
+func snapshotAssessment(ctx context.Context, repo repositories.Repository, db *gorm.DB, assessmentID uint, createdBy, reason string) (*models.AssessmentVersion, error) {
+	assessment, err := repo.Assessment().GetByID(ctx, db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	snapshot, err := buildAssessmentSnapshot(ctx, repo, db, assessment)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode assessment snapshot: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	latest, err := repo.AssessmentVersion().GetLatest(ctx, db, assessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get latest assessment version: %w", err)
+	}
+	if latest != nil && latest.Checksum == checksum {
+		return latest, nil
+	}
+
+	// Versions follow Assessment.Version, which assessment updates already increment
+	version := max(assessment.Version, 1)
+	if latest != nil && latest.Version >= version {
+		version = latest.Version + 1
+	}
+
+	assessmentVersion := &models.AssessmentVersion{
+		AssessmentID: assessmentID,
+		Version:      version,
+		Snapshot:     data,
+		Checksum:     checksum,
+		Reason:       reason,
+		CreatedBy:    createdBy,
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := repo.AssessmentVersion().Create(ctx, tx, assessmentVersion); err != nil {
+			return err
+		}
+		if _, err := repo.Assessment().RaiseVersion(ctx, tx, assessmentID, version); err != nil {
+			return fmt.Errorf("failed to update assessment version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Another request may have stored the same content first
+		if current, latestErr := repo.AssessmentVersion().GetLatest(ctx, db, assessmentID); latestErr == nil && current.Checksum == checksum {
+			return current, nil
+		}
+		return nil, fmt.Errorf("failed to create assessment version: %w", err)
+	}
+
+	return assessmentVersion, nil
+}
+
+func buildAssessmentSnapshot(ctx context.Context, repo repositories.Repository, db *gorm.DB, assessment *models.Assessment) (*models.AssessmentSnapshot, error) {
+	assessmentQuestions, err := repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, db, assessment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	questionIDs := make([]uint, len(assessmentQuestions))
+	for i, aq := range assessmentQuestions {
+		questionIDs[i] = aq.QuestionID
+	}
+	questions, err := repo.Question().GetByIDs(ctx, db, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	questionsByID := make(map[uint]*models.Question, len(questions))
+	for _, question := range questions {
+		questionsByID[question.ID] = question
+	}
+
+	snapshot := &models.AssessmentSnapshot{
+		Title:        assessment.Title,
+		Description:  assessment.Description,
+		Duration:     assessment.Duration,
+		PassingScore: assessment.PassingScore,
+		MaxAttempts:  assessment.MaxAttempts,
+		Questions:    make([]models.AssessmentQuestionSnapshot, 0, len(assessmentQuestions)),
+
+		ConfidenceMarking: assessment.Settings.ConfidenceMarking,
+	}
+
+	for _, aq := range assessmentQuestions {
+		question, ok := questionsByID[aq.QuestionID]
+		if !ok {
+			continue
+		}
+
+		points := question.Points
+		if aq.Points != nil {
+			points = *aq.Points
+		}
+
+		questionSnapshot := models.AssessmentQuestionSnapshot{
+			QuestionID:  question.ID,
+			Order:       aq.Order,
+			Points:      points,
+			Required:    aq.Required,
+			Type:        question.Type,
+			Text:        question.Text,
+			Content:     question.Content,
+			Answer:      question.Answer,
+			Difficulty:  question.Difficulty,
+			Explanation: question.Explanation,
+		}
+		if marking := resolveMarking(&assessment.Settings, aq); marking.NegativeMarking != models.NegativeMarkingNone {
+			questionSnapshot.NegativeMarking = marking.NegativeMarking
+			questionSnapshot.NegativeMarkingValue = marking.NegativeMarkingValue
+		}
+
+		snapshot.TotalPoints += points
+		snapshot.Questions = append(snapshot.Questions, questionSnapshot)
+	}
+
+	return snapshot, nil
+}
+
+// getPinnedSnapshot returns the snapshot an attempt was started on, nil for attempts without one
+func getPinnedSnapshot(ctx context.Context, repo repositories.Repository, db *gorm.DB, attempt *models.AssessmentAttempt) (*models.AssessmentSnapshot, error) {
+	if attempt.AssessmentVersion == 0 {
+		return nil, nil
+	}
+
+	assessmentVersion, err := repo.AssessmentVersion().GetByVersion(ctx, db, attempt.AssessmentID, attempt.AssessmentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment version %d of attempt %d: %w", attempt.AssessmentVersion, attempt.ID, err)
+	}
+
+	return decodeSnapshot(assessmentVersion)
+}
+
+func decodeSnapshot(assessmentVersion *models.AssessmentVersion) (*models.AssessmentSnapshot, error) {
+	var snapshot models.AssessmentSnapshot
+	if err := json.Unmarshal(assessmentVersion.Snapshot, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode assessment version %d: %w", assessmentVersion.Version, err)
+	}
+	return &snapshot, nil
+}
+
+// ===== DIFF =====
+
+func diffSnapshots(from, to *models.AssessmentSnapshot) *AssessmentVersionDiff {
+	diff := &AssessmentVersionDiff{
+		Fields:           []FieldChange{},
+		AddedQuestions:   []models.AssessmentQuestionSnapshot{},
+		RemovedQuestions: []models.AssessmentQuestionSnapshot{},
+		ChangedQuestions: []QuestionVersionChange{},
+	}
+
+	diff.Fields = appendChange(diff.Fields, "title", from.Title, to.Title)
+	diff.Fields = appendChange(diff.Fields, "description", stringValue(from.Description), stringValue(to.Description))
+	diff.Fields = appendChange(diff.Fields, "duration", from.Duration, to.Duration)
+	diff.Fields = appendChange(diff.Fields, "passing_score", from.PassingScore, to.PassingScore)
+	diff.Fields = appendChange(diff.Fields, "max_attempts", from.MaxAttempts, to.MaxAttempts)
+	diff.Fields = appendChange(diff.Fields, "total_points", from.TotalPoints, to.TotalPoints)
+
+	for _, previous := range from.Questions {
+		current, ok := to.Question(previous.QuestionID)
+		if !ok {
+			diff.RemovedQuestions = append(diff.RemovedQuestions, previous)
+			continue
+		}
+
+		var changes []FieldChange
+		changes = appendChange(changes, "order", previous.Order, current.Order)
+		changes = appendChange(changes, "points", previous.Points, current.Points)
+		changes = appendChange(changes, "required", previous.Required, current.Required)
+		changes = appendChange(changes, "type", previous.Type, current.Type)
+		changes = appendChange(changes, "text", previous.Text, current.Text)
+		changes = appendChange(changes, "difficulty", previous.Difficulty, current.Difficulty)
+		changes = appendChange(changes, "explanation", stringValue(previous.Explanation), stringValue(current.Explanation))
+		if !sameJSON(previous.Content, current.Content) {
+			changes = append(changes, FieldChange{Field: "content", From: json.RawMessage(previous.Content), To: json.RawMessage(current.Content)})
+		}
+		if !sameJSON(previous.Answer, current.Answer) {
+			changes = append(changes, FieldChange{Field: "answer", From: json.RawMessage(previous.Answer), To: json.RawMessage(current.Answer)})
+		}
+
+		if len(changes) > 0 {
+			diff.ChangedQuestions = append(diff.ChangedQuestions, QuestionVersionChange{
+				QuestionID: previous.QuestionID,
+				Changes:    changes,
+			})
+		}
+	}
+
+	for _, current := range to.Questions {
+		if _, ok := from.Question(current.QuestionID); !ok {
+			diff.AddedQuestions = append(diff.AddedQuestions, current)
+		}
+	}
+
+	return diff
+}
+
+func appendChange[T comparable](changes []FieldChange, field string, from, to T) []FieldChange {
+	if from == to {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, From: from, To: to})
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	question := func(id uint, points int, content string) models.AssessmentQuestionSnapshot {
+		return models.AssessmentQuestionSnapshot{QuestionID: id, Order: int(id), Points: points, Type: models.TrueFalse, Text: "Q", Content: datatypes.JSON(content)}
+	}
+	base := models.AssessmentSnapshot{
+		Title:       "Quiz",
+		Duration:    30,
+		TotalPoints: 10,
+		Questions:   []models.AssessmentQuestionSnapshot{question(1, 5, `{"correct_answer": true}`), question(2, 5, `{"correct_answer": false}`)},
+	}
+
+	tests := []struct {
+		name        string
+		change      func(s *models.AssessmentSnapshot)
+		wantFields  []string
+		wantAdded   []uint
+		wantRemoved []uint
+		wantChanged map[uint][]string
+	}{
+		{"same content", func(s *models.AssessmentSnapshot) {}, nil, nil, nil, nil},
+		{"reformatted content is not a change", func(s *models.AssessmentSnapshot) {
+			s.Questions[0].Content = datatypes.JSON(`{"correct_answer":true}`)
+		}, nil, nil, nil, nil},
+		{"assessment fields", func(s *models.AssessmentSnapshot) {
+			s.Title = "Final quiz"
+			s.Duration = 45
+		}, []string{"title", "duration"}, nil, nil, nil},
+		{"question added and removed", func(s *models.AssessmentSnapshot) {
+			s.Questions = []models.AssessmentQuestionSnapshot{s.Questions[0], question(3, 5, `{}`)}
+		}, nil, []uint{3}, []uint{2}, nil},
+		{"question points and answer key", func(s *models.AssessmentSnapshot) {
+			s.TotalPoints = 12
+			s.Questions[1].Points = 7
+			s.Questions[1].Content = datatypes.JSON(`{"correct_answer": true}`)
+		}, []string{"total_points"}, nil, nil, map[uint][]string{2: {"points", "content"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := base
+			to.Questions = append([]models.AssessmentQuestionSnapshot(nil), base.Questions...)
+			tt.change(&to)
+
+			diff := diffSnapshots(&base, &to)
+
+			var fields []string
+			for _, change := range diff.Fields {
+				fields = append(fields, change.Field)
+			}
+			var added, removed []uint
+			for _, q := range diff.AddedQuestions {
+				added = append(added, q.QuestionID)
+			}
+			for _, q := range diff.RemovedQuestions {
+				removed = append(removed, q.QuestionID)
+			}
+			var changed map[uint][]string
+			for _, q := range diff.ChangedQuestions {
+				if changed == nil {
+					changed = map[uint][]string{}
+				}
+				for _, change := range q.Changes {
+					changed[q.QuestionID] = append(changed[q.QuestionID], change.Field)
+				}
+			}
+
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("changed fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(added, tt.wantAdded) || !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("added %v, removed %v, want %v, %v", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("changed questions = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+}
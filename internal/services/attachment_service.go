@@ -0,0 +1,410 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttachmentService manages files attached to questions
+type AttachmentService interface {
+	// Attachment management
+	Upload(ctx context.Context, questionID uint, file io.Reader, filename string, req *AttachmentMetadataRequest, userID string) (*models.QuestionAttachment, error)
+	List(ctx context.Context, questionID uint, userID string) ([]*models.QuestionAttachment, error)
+	Update(ctx context.Context, questionID, attachmentID uint, req *AttachmentMetadataRequest, userID string) (*models.QuestionAttachment, error)
+	Delete(ctx context.Context, questionID, attachmentID uint, userID string) error
+	Reorder(ctx context.Context, questionID uint, req *ReorderAttachmentsRequest, userID string) ([]*models.QuestionAttachment, error)
+
+	// Maintenance
+	CleanupOrphans(ctx context.Context) (int, error)
+	StartCleanup()
+	Shutdown(ctx context.Context) error
+}
+
+// AttachmentConfig limits uploads and controls the orphan cleanup job
+type AttachmentConfig struct {
+	MaxFileSize     int64 // bytes
+	MaxPerQuestion  int
+	ThumbnailSize   int           // longest edge of generated image thumbnails, in pixels
+	CleanupInterval time.Duration // how often attachments of deleted questions are removed
+}
+
+// attachmentType describes an accepted upload, keyed by its sniffed MIME type
+type attachmentType struct {
+	fileType  string
+	extension string
+}
+
+// allowedAttachmentTypes lists the MIME types accepted for upload. SVG is deliberately
+// missing: it can carry scripts and would be served from our origin.
+var allowedAttachmentTypes = map[string]attachmentType{
+	"image/jpeg":      {fileType: "image", extension: ".jpg"},
+	"image/png":       {fileType: "image", extension: ".png"},
+	"image/gif":       {fileType: "image", extension: ".gif"},
+	"image/webp":      {fileType: "image", extension: ".webp"},
+	"application/pdf": {fileType: "document", extension: ".pdf"},
+	"audio/mpeg":      {fileType: "audio", extension: ".mp3"},
+	"audio/wave":      {fileType: "audio", extension: ".wav"},
+	"application/ogg": {fileType: "audio", extension: ".ogg"},
+	"video/mp4":       {fileType: "video", extension: ".mp4"},
+	"video/webm":      {fileType: "video", extension: ".webm"},
+}
+
+type attachmentService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	storage   storage.Storage
+	logger    *slog.Logger
+	validator *validator.Validator
+	config    AttachmentConfig
+	cleanup   *periodicJob // orphan cleanup, run by the replica holding the Redis lease
+}
+
+const attachmentCleanupLockKey = "attachment-cleanup"
+
+func NewAttachmentService(repo repositories.Repository, db *gorm.DB, fileStorage storage.Storage, logger *slog.Logger, validator *validator.Validator, cacheManager *cache.CacheManager, config AttachmentConfig) AttachmentService {
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = 10 << 20 // 10 MB
+	}
+	if config.MaxPerQuestion <= 0 {
+		config.MaxPerQuestion = 20
+	}
+	if config.ThumbnailSize <= 0 {
+		config.ThumbnailSize = 320
+	}
+	if config.CleanupInterval <= 0 {
+		config.CleanupInterval = time.Hour
+	}
+
+	s := &attachmentService{
+		repo:      repo,
+		db:        db,
+		storage:   fileStorage,
+		logger:    logger,
+		validator: validator,
+		config:    config,
+	}
+	lock := cacheManager.NewLeaderLock(attachmentCleanupLockKey, 3*config.CleanupInterval)
+	s.cleanup = newPeriodicJob(attachmentCleanupLockKey, config.CleanupInterval, lock, logger, func(ctx context.Context) {
+		if _, err := s.CleanupOrphans(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Attachment cleanup failed", "error", err)
+		}
+	})
+	return s
+}
+
+// ===== ATTACHMENT MANAGEMENT =====
+
+func (s *attachmentService) Upload(ctx context.Context, questionID uint, file io.Reader, filename string, req *AttachmentMetadataRequest, userID string) (*models.QuestionAttachment, error) {
+	s.logger.Info("Uploading question attachment", "question_id", questionID, "user_id", userID, "filename", filename)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.checkQuestionEdit(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.QuestionAttachment().GetByQuestion(ctx, s.db, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question attachments: %w", err)
+	}
+	if len(existing) >= s.config.MaxPerQuestion {
+		return nil, ErrAttachmentLimitReached
+	}
+
+	// Read one byte past the limit to detect oversized files without trusting the declared size
+	data, err := io.ReadAll(io.LimitReader(file, s.config.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if int64(len(data)) > s.config.MaxFileSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if len(data) == 0 {
+		return nil, NewValidationError("file", "file is empty", filename)
+	}
+
+	// The client's Content-Type is ignored; the stored type is sniffed from the content
+	mimeType := http.DetectContentType(data)
+	fileInfo, ok := allowedAttachmentTypes[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, mimeType)
+	}
+
+	key := fmt.Sprintf("questions/%d/%s%s", questionID, uuid.NewString(), fileInfo.extension)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), mimeType); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	attachment := &models.QuestionAttachment{
+		QuestionID:  questionID,
+		FileName:    sanitizeAttachmentName(filename, fileInfo.extension),
+		FileType:    fileInfo.fileType,
+		FileSize:    int64(len(data)),
+		MimeType:    mimeType,
+		StoragePath: key,
+		URL:         s.storage.URL(key),
+		Alt:         req.Alt,
+		Caption:     req.Caption,
+	}
+
+	if fileInfo.fileType == "image" {
+		attachment.ThumbnailURL = s.storeThumbnail(ctx, attachment, data)
+	}
+
+	// The check above only saves storing a file that cannot be attached; parallel uploads are
+	// held to the limit here
+	created, err := s.repo.QuestionAttachment().CreateWithinLimit(ctx, s.db, attachment, s.config.MaxPerQuestion)
+	if err != nil {
+		s.removeAttachmentFiles(ctx, attachment)
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+	if !created {
+		s.removeAttachmentFiles(ctx, attachment)
+		return nil, ErrAttachmentLimitReached
+	}
+
+	s.logger.Info("Question attachment uploaded",
+		"attachment_id", attachment.ID,
+		"question_id", questionID,
+		"mime_type", mimeType,
+		"size", attachment.FileSize)
+
+	return attachment, nil
+}
+
+func (s *attachmentService) List(ctx context.Context, questionID uint, userID string) ([]*models.QuestionAttachment, error) {
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := questionService.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "read", "not owner or insufficient permissions")
+	}
+
+	attachments, err := s.repo.QuestionAttachment().GetByQuestion(ctx, s.db, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (s *attachmentService) Update(ctx context.Context, questionID, attachmentID uint, req *AttachmentMetadataRequest, userID string) (*models.QuestionAttachment, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.checkQuestionEdit(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.getQuestionAttachment(ctx, questionID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Alt != nil {
+		attachment.Alt = req.Alt
+	}
+	if req.Caption != nil {
+		attachment.Caption = req.Caption
+	}
+
+	if err := s.repo.QuestionAttachment().Update(ctx, s.db, attachment); err != nil {
+		return nil, fmt.Errorf("failed to update attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) Delete(ctx context.Context, questionID, attachmentID uint, userID string) error {
+	s.logger.Info("Deleting question attachment", "question_id", questionID, "attachment_id", attachmentID, "user_id", userID)
+
+	if err := s.checkQuestionEdit(ctx, questionID, userID); err != nil {
+		return err
+	}
+
+	attachment, err := s.getQuestionAttachment(ctx, questionID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.QuestionAttachment().Delete(ctx, s.db, attachmentID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAttachmentNotFound
+		}
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	// The record is gone, so a failed file delete only leaves an unreferenced file behind
+	s.removeAttachmentFiles(ctx, attachment)
+
+	return nil
+}
+
+func (s *attachmentService) Reorder(ctx context.Context, questionID uint, req *ReorderAttachmentsRequest, userID string) ([]*models.QuestionAttachment, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.checkQuestionEdit(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.QuestionAttachment().GetByQuestion(ctx, s.db, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question attachments: %w", err)
+	}
+
+	belongs := make(map[uint]bool, len(existing))
+	for _, attachment := range existing {
+		belongs[attachment.ID] = true
+	}
+	for _, item := range req.Orders {
+		if !belongs[item.AttachmentID] {
+			return nil, NewValidationError("orders", "attachment does not belong to this question", item.AttachmentID)
+		}
+	}
+
+	if err := s.repo.QuestionAttachment().UpdateOrder(ctx, s.db, questionID, req.Orders); err != nil {
+		return nil, fmt.Errorf("failed to reorder attachments: %w", err)
+	}
+
+	return s.repo.QuestionAttachment().GetByQuestion(ctx, s.db, questionID)
+}
+
+// ===== MAINTENANCE =====
+
+// CleanupOrphans removes attachments of deleted questions together with their files
+func (s *attachmentService) CleanupOrphans(ctx context.Context) (int, error) {
+	orphans, err := s.repo.QuestionAttachment().GetOrphanedAttachments(ctx, s.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get orphaned attachments: %w", err)
+	}
+
+	removed := 0
+	for _, attachment := range orphans {
+		if err := s.repo.QuestionAttachment().Delete(ctx, s.db, attachment.ID); err != nil && !repositories.IsNotFoundError(err) {
+			s.logger.Error("Failed to delete orphaned attachment", "attachment_id", attachment.ID, "error", err)
+			continue
+		}
+		s.removeAttachmentFiles(ctx, attachment)
+		removed++
+	}
+
+	if removed > 0 {
+		s.logger.Info("Removed orphaned attachments", "count", removed)
+	}
+	return removed, nil
+}
+
+// StartCleanup runs CleanupOrphans periodically until Shutdown is called
+func (s *attachmentService) StartCleanup() {
+	s.cleanup.Start()
+}
+
+func (s *attachmentService) Shutdown(ctx context.Context) error {
+	if err := s.cleanup.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop attachment cleanup: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *attachmentService) checkQuestionEdit(ctx context.Context, questionID uint, userID string) error {
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canEdit, err := questionService.CanEdit(ctx, questionID, userID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return err
+	}
+	if !canEdit {
+		return NewPermissionError(userID, questionID, "question", "update_attachments", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *attachmentService) getQuestionAttachment(ctx context.Context, questionID, attachmentID uint) (*models.QuestionAttachment, error) {
+	attachment, err := s.repo.QuestionAttachment().GetByID(ctx, s.db, attachmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	if attachment.QuestionID != questionID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// storeThumbnail generates and stores a thumbnail for an image. Thumbnails are best effort:
+// formats the standard library cannot decode, such as WebP, are kept without one.
+func (s *attachmentService) storeThumbnail(ctx context.Context, attachment *models.QuestionAttachment, data []byte) *string {
+	thumbnail, err := generateThumbnail(data, attachment.MimeType, s.config.ThumbnailSize)
+	if err != nil {
+		s.logger.Warn("Failed to generate thumbnail", "storage_path", attachment.StoragePath, "error", err)
+		return nil
+	}
+
+	key, contentType := thumbnailKey(attachment.StoragePath, attachment.MimeType)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), contentType); err != nil {
+		s.logger.Warn("Failed to store thumbnail", "storage_path", attachment.StoragePath, "error", err)
+		return nil
+	}
+
+	url := s.storage.URL(key)
+	return &url
+}
+
+func (s *attachmentService) removeAttachmentFiles(ctx context.Context, attachment *models.QuestionAttachment) {
+	if err := s.storage.Delete(ctx, attachment.StoragePath); err != nil {
+		s.logger.Warn("Failed to delete attachment file", "storage_path", attachment.StoragePath, "error", err)
+	}
+
+	if attachment.ThumbnailURL != nil {
+		key, _ := thumbnailKey(attachment.StoragePath, attachment.MimeType)
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Warn("Failed to delete thumbnail file", "storage_path", key, "error", err)
+		}
+	}
+}
+
+// sanitizeAttachmentName keeps the base name of the uploaded file for display, with the
+// extension of the sniffed type
+func sanitizeAttachmentName(filename, extension string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+	if runes := []rune(name); len(runes) > 200 {
+		name = string(runes[:200])
+	}
+	return name + extension
+}
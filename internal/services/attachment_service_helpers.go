@@ -0,0 +1,119 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+
+	// Registers the GIF decoder for image.Decode
+	_ "image/gif"
+)
+
+// maxThumbnailSourcePixels guards against decompression bombs: larger images are stored without a thumbnail
+const maxThumbnailSourcePixels = 40_000_000
+
+// thumbnailKey derives the storage key and content type of an attachment's thumbnail.
+// JPEG photos keep JPEG thumbnails; everything else becomes PNG to preserve transparency.
+func thumbnailKey(storagePath, mimeType string) (string, string) {
+	base := strings.TrimSuffix(storagePath, path.Ext(storagePath)) + "_thumb"
+	if mimeType == "image/jpeg" {
+		return base + ".jpg", "image/jpeg"
+	}
+	return base + ".png", "image/png"
+}
+
+// generateThumbnail scales an image down so its longest edge is at most maxSize pixels
+func generateThumbnail(data []byte, mimeType string, maxSize int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxThumbnailSourcePixels {
+		return nil, fmt.Errorf("image too large for thumbnail: %dx%d", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	thumbnail := scaleImage(src, maxSize)
+
+	var buf bytes.Buffer
+	if mimeType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(&buf, thumbnail)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scaleImage downsamples with a box filter: every target pixel is the average of the
+// source pixels it covers. Images already within maxSize are returned unchanged.
+func scaleImage(src image.Image, maxSize int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return src
+	}
+
+	dstWidth, dstHeight := maxSize, maxSize
+	if width >= height {
+		dstHeight = height * maxSize / width
+	} else {
+		dstWidth = width * maxSize / height
+	}
+	if dstWidth < 1 {
+		dstWidth = 1
+	}
+	if dstHeight < 1 {
+		dstHeight = 1
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := bounds.Min.Y + (y+1)*height/dstHeight
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := bounds.Min.X + (x+1)*width/dstWidth
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+// attachmentRepository serves one question owned by "teacher" and keeps its attachments in memory
+type attachmentRepository struct {
+	repositories.Repository
+	attachments *questionAttachmentRepository
+}
+
+func (r *attachmentRepository) User() repositories.UserRepository         { return teacherUsers{} }
+func (r *attachmentRepository) Question() repositories.QuestionRepository { return ownedQuestions{} }
+func (r *attachmentRepository) QuestionAttachment() repositories.QuestionAttachmentRepository {
+	return r.attachments
+}
+
+type teacherUsers struct{ repositories.UserRepository }
+
+func (teacherUsers) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return &models.User{ID: id, Role: models.RoleTeacher}, nil
+}
+
+type ownedQuestions struct {
+	repositories.QuestionRepository
+}
+
+func (ownedQuestions) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Question, error) {
+	return &models.Question{ID: id, CreatedBy: "teacher"}, nil
+}
+
+type questionAttachmentRepository struct {
+	repositories.QuestionAttachmentRepository
+
+	attachments []*models.QuestionAttachment
+	// filledMeanwhile makes CreateWithinLimit find the question full, as after a parallel upload
+	filledMeanwhile bool
+}
+
+func (r *questionAttachmentRepository) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionAttachment, error) {
+	return r.attachments, nil
+}
+
+func (r *questionAttachmentRepository) CreateWithinLimit(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment, limit int) (bool, error) {
+	if r.filledMeanwhile || len(r.attachments) >= limit {
+		return false, nil
+	}
+	attachment.Order = len(r.attachments)
+	r.attachments = append(r.attachments, attachment)
+	return true, nil
+}
+
+// memoryStorage keeps stored objects in a map
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, storage.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) URL(key string) string { return "/uploads/" + key }
+
+func encodeTestImage(t *testing.T, width, height int, format string) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, nil)
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	pngData := encodeTestImage(t, 640, 320, "png")
+	pdfData := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n")
+	svgData := []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)
+
+	tests := []struct {
+		name            string
+		filename        string
+		data            []byte
+		existing        int
+		filledMeanwhile bool
+		wantErr         bool
+		wantErrIs       error // nil for validation errors
+		wantMimeType    string
+		wantThumbnail   bool
+	}{
+		{name: "image with thumbnail", filename: "diagram.png", data: pngData, wantMimeType: "image/png", wantThumbnail: true},
+		{name: "type is sniffed, not taken from the name", filename: "diagram.pdf", data: pngData, wantMimeType: "image/png", wantThumbnail: true},
+		{name: "document", filename: "sheet.pdf", data: pdfData, wantMimeType: "application/pdf"},
+		{name: "svg is rejected", filename: "diagram.svg", data: svgData, wantErr: true, wantErrIs: ErrAttachmentTypeNotAllowed},
+		{name: "text disguised as an image", filename: "notes.png", data: []byte("just some notes"), wantErr: true, wantErrIs: ErrAttachmentTypeNotAllowed},
+		{name: "over the size limit", filename: "large.png", data: make([]byte, 64<<10+1), wantErr: true, wantErrIs: ErrAttachmentTooLarge},
+		{name: "empty file", filename: "empty.png", data: nil, wantErr: true},
+		{name: "question already full", filename: "diagram.png", data: pngData, existing: 3, wantErr: true, wantErrIs: ErrAttachmentLimitReached},
+		{name: "question filled by a parallel upload", filename: "diagram.png", data: pngData, filledMeanwhile: true, wantErr: true, wantErrIs: ErrAttachmentLimitReached},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachments := &questionAttachmentRepository{filledMeanwhile: tt.filledMeanwhile}
+			for i := 0; i < tt.existing; i++ {
+				attachments.attachments = append(attachments.attachments, &models.QuestionAttachment{ID: uint(i + 1)})
+			}
+			fileStorage := &memoryStorage{objects: map[string][]byte{}}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			service := NewAttachmentService(&attachmentRepository{attachments: attachments}, nil, fileStorage, logger, validator.New(), cache.NewCacheManager(nil),
+				AttachmentConfig{MaxFileSize: 64 << 10, MaxPerQuestion: 3, ThumbnailSize: 100})
+
+			attachment, err := service.Upload(context.Background(), 7, bytes.NewReader(tt.data), tt.filename, &AttachmentMetadataRequest{}, "teacher")
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) || tt.wantErrIs == nil && !errors.As(err, &validationErr) {
+					t.Fatalf("Upload() error = %v, want %v", err, tt.wantErrIs)
+				}
+				if len(fileStorage.objects) > 0 {
+					t.Errorf("%d files left in storage after a rejected upload", len(fileStorage.objects))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+
+			if attachment.MimeType != tt.wantMimeType {
+				t.Errorf("MimeType = %s, want %s", attachment.MimeType, tt.wantMimeType)
+			}
+			if _, ok := fileStorage.objects[attachment.StoragePath]; !ok {
+				t.Errorf("file %s not stored", attachment.StoragePath)
+			}
+			if (attachment.ThumbnailURL != nil) != tt.wantThumbnail {
+				t.Errorf("ThumbnailURL = %v, want thumbnail %v", attachment.ThumbnailURL, tt.wantThumbnail)
+			}
+			wantObjects := 1
+			if tt.wantThumbnail {
+				wantObjects = 2
+			}
+			if len(fileStorage.objects) != wantObjects {
+				t.Errorf("stored objects = %d, want %d", len(fileStorage.objects), wantObjects)
+			}
+		})
+	}
+}
+
+// withImageSize rewrites the dimensions in a PNG header, so a tiny file claims to be a huge image
+func withImageSize(t *testing.T, data []byte, width, height uint32) []byte {
+	t.Helper()
+
+	patched := bytes.Clone(data)
+	// Signature (8) + chunk length (4), then the IHDR type and data, followed by its CRC
+	ihdr := patched[12:29]
+	binary.BigEndian.PutUint32(ihdr[4:8], width)
+	binary.BigEndian.PutUint32(ihdr[8:12], height)
+	binary.BigEndian.PutUint32(patched[29:33], crc32.ChecksumIEEE(ihdr))
+	return patched
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		mimeType   string
+		wantWidth  int
+		wantHeight int
+		wantFormat string
+		wantErr    bool
+	}{
+		{"wide image", encodeTestImage(t, 640, 320, "png"), "image/png", 100, 50, "png", false},
+		{"tall photo stays jpeg", encodeTestImage(t, 200, 400, "jpeg"), "image/jpeg", 50, 100, "jpeg", false},
+		{"small image keeps its size", encodeTestImage(t, 80, 40, "png"), "image/png", 80, 40, "png", false},
+		{"not an image", []byte("%PDF-1.4"), "image/png", 0, 0, "", true},
+		{"decompression bomb", withImageSize(t, encodeTestImage(t, 1, 1, "png"), 10000, 10000), "image/png", 0, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail, err := generateThumbnail(tt.data, tt.mimeType, 100)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("generateThumbnail() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generateThumbnail() error = %v", err)
+			}
+
+			cfg, format, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+			if err != nil {
+				t.Fatalf("thumbnail is not an image: %v", err)
+			}
+			if cfg.Width != tt.wantWidth || cfg.Height != tt.wantHeight {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("thumbnail format = %s, want %s", format, tt.wantFormat)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+// memoryAttemptRepository keeps one attempt's answers in memory
+type memoryAttemptRepository struct {
+	repositories.Repository
+	answers *memoryAnswerRepository
+}
+
+func (r *memoryAttemptRepository) Answer() repositories.AnswerRepository { return r.answers }
+
+// memoryAnswerRepository stores answers by question. Like the Postgres implementation, the history
+// keeps the newest maxEntries.
+type memoryAnswerRepository struct {
+	repositories.AnswerRepository
+	answers map[uint]*models.StudentAnswer
+	history map[uint][]repositories.AnswerHistoryEntry
+}
+
+func newMemoryAttemptRepository() *memoryAttemptRepository {
+	return &memoryAttemptRepository{answers: &memoryAnswerRepository{
+		answers: map[uint]*models.StudentAnswer{},
+		history: map[uint][]repositories.AnswerHistoryEntry{},
+	}}
+}
+
+func (r *memoryAnswerRepository) GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (*models.StudentAnswer, error) {
+	answer, ok := r.answers[questionID]
+	if !ok {
+		return nil, fmt.Errorf("answer not found: %w", gorm.ErrRecordNotFound)
+	}
+	saved := *answer
+	return &saved, nil
+}
+
+func (r *memoryAnswerRepository) Create(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error {
+	answer.ID = uint(len(r.answers) + 1)
+	saved := *answer
+	r.answers[answer.QuestionID] = &saved
+	return nil
+}
+
+func (r *memoryAnswerRepository) Update(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error {
+	saved := *answer
+	r.answers[answer.QuestionID] = &saved
+	return nil
+}
+
+func (r *memoryAnswerRepository) UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, entry repositories.AnswerHistoryEntry, maxEntries int) error {
+	history := append(r.history[id], entry)
+	if len(history) > maxEntries {
+		history = history[len(history)-maxEntries:]
+	}
+	r.history[id] = history
+	return nil
+}
+
+func newMemoryAttemptService(repo repositories.Repository, history AnswerHistoryConfig) *attemptService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAttemptService(repo, nil, logger, validator.New(), nil, nil, history).(*attemptService)
+}
+
+func TestAnswerHistory(t *testing.T) {
+	repo := newMemoryAttemptRepository()
+	service := newMemoryAttemptService(repo, AnswerHistoryConfig{MaxEntries: 3})
+
+	startedAt := time.Now().Add(-time.Minute)
+	attempt := &models.AssessmentAttempt{ID: 1, StartedAt: &startedAt}
+	ctx := utils.WithRequestInfo(context.Background(), utils.RequestInfo{IPAddress: "10.0.0.1", UserAgent: "test"})
+
+	changes := []struct {
+		req   SubmitAnswerRequest
+		final bool
+	}{
+		{SubmitAnswerRequest{QuestionID: 7, AnswerData: "a"}, false},
+		{SubmitAnswerRequest{QuestionID: 7, AnswerData: "a"}, false}, // unchanged, not recorded
+		{SubmitAnswerRequest{QuestionID: 7, AnswerData: "b"}, false},
+		{SubmitAnswerRequest{QuestionID: 7, Omit: true}, false},
+		{SubmitAnswerRequest{QuestionID: 7, AnswerData: "c"}, true},
+	}
+	for _, change := range changes {
+		if err := service.updateAttemptAnswer(ctx, nil, attempt, change.req, change.final); err != nil {
+			t.Fatalf("updateAttemptAnswer() error = %v", err)
+		}
+	}
+
+	history := repo.answers.history[repo.answers.answers[7].ID]
+
+	// Four changes, of which the oldest falls outside the cap
+	var actions []string
+	for _, entry := range history {
+		actions = append(actions, entry.Action)
+	}
+	wantActions := []string{repositories.AnswerActionUpdated, repositories.AnswerActionOmitted, repositories.AnswerActionSubmitted}
+	if !reflect.DeepEqual(actions, wantActions) {
+		t.Fatalf("history actions = %v, want %v", actions, wantActions)
+	}
+
+	updated := history[0]
+	if string(updated.PreviousAnswer) != `"a"` || string(updated.Answer) != `"b"` {
+		t.Errorf("updated entry = %s -> %s, want \"a\" -> \"b\"", updated.PreviousAnswer, updated.Answer)
+	}
+	if updated.IPAddress != "10.0.0.1" || updated.UserAgent != "test" {
+		t.Errorf("updated entry client = %s %s, want 10.0.0.1 test", updated.IPAddress, updated.UserAgent)
+	}
+	if updated.OffsetSeconds < 60 {
+		t.Errorf("updated entry offset = %ds, want at least 60s", updated.OffsetSeconds)
+	}
+	if submitted := history[2]; len(submitted.PreviousAnswer) != 0 {
+		t.Errorf("entry after omit has previous answer %s, want none", submitted.PreviousAnswer)
+	}
+}
+
+func TestAnswerHistoryDefaultCap(t *testing.T) {
+	service := newMemoryAttemptService(newMemoryAttemptRepository(), AnswerHistoryConfig{})
+	if service.history.MaxEntries != defaultAnswerHistoryEntries {
+		t.Errorf("MaxEntries = %d, want %d", service.history.MaxEntries, defaultAnswerHistoryEntries)
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// reviewRepository adds the attempt, its assessment and questions to the in-memory answers
+type reviewRepository struct {
+	*memoryAttemptRepository
+	attempt     *models.AssessmentAttempt
+	assessment  *models.Assessment
+	questions   []*models.AssessmentQuestion
+	attemptRepo *reviewAttemptRepository
+
+	// readStatus, when set, is the status requests read, e.g. from before the sweeper closed the attempt
+	readStatus models.AttemptStatus
+}
+
+type reviewAttemptRepository struct {
+	repositories.AttemptRepository
+	repo *reviewRepository
+}
+
+type reviewAssessmentRepository struct {
+	repositories.AssessmentRepository
+	repo *reviewRepository
+}
+
+type reviewQuestionRepository struct {
+	repositories.AssessmentQuestionRepository
+	repo *reviewRepository
+}
+
+func newReviewRepository(requireConfirmation bool, questions ...*models.AssessmentQuestion) *reviewRepository {
+	endedAt := time.Now().Add(time.Hour)
+	repo := &reviewRepository{
+		memoryAttemptRepository: newMemoryAttemptRepository(),
+		attempt:                 &models.AssessmentAttempt{ID: 1, AssessmentID: 1, StudentID: "student", Status: models.AttemptInProgress, EndedAt: &endedAt},
+		assessment:              &models.Assessment{ID: 1, Settings: models.AssessmentSettings{RequireSubmitConfirmation: requireConfirmation}},
+		questions:               questions,
+	}
+	repo.attemptRepo = &reviewAttemptRepository{repo: repo}
+	return repo
+}
+
+func (r *reviewRepository) Attempt() repositories.AttemptRepository { return r.attemptRepo }
+func (r *reviewRepository) Assessment() repositories.AssessmentRepository {
+	return &reviewAssessmentRepository{repo: r}
+}
+func (r *reviewRepository) AssessmentQuestion() repositories.AssessmentQuestionRepository {
+	return &reviewQuestionRepository{repo: r}
+}
+
+func (r *reviewAttemptRepository) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentAttempt, error) {
+	attempt := *r.repo.attempt
+	if r.repo.readStatus != "" {
+		attempt.Status = r.repo.readStatus
+	}
+	return &attempt, nil
+}
+
+func (r *reviewAttemptRepository) UpdateNavigation(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex int, isReview bool) (bool, error) {
+	if r.repo.attempt.Status != models.AttemptInProgress {
+		return false, nil
+	}
+	r.repo.attempt.CurrentQuestionIndex = currentQuestionIndex
+	r.repo.attempt.IsReview = isReview
+	return true, nil
+}
+
+func (r *reviewAssessmentRepository) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Assessment, error) {
+	return r.repo.assessment, nil
+}
+
+func (r *reviewQuestionRepository) GetByAssessmentOrdered(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentQuestion, error) {
+	return r.repo.questions, nil
+}
+
+func (r *reviewQuestionRepository) GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) (*models.AssessmentQuestion, error) {
+	for _, aq := range r.repo.questions {
+		if aq.QuestionID == questionID {
+			return aq, nil
+		}
+	}
+	return nil, fmt.Errorf("question not in assessment: %w", gorm.ErrRecordNotFound)
+}
+
+func (r *memoryAnswerRepository) GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.StudentAnswer, error) {
+	answers := make([]*models.StudentAnswer, 0, len(r.answers))
+	for _, answer := range r.answers {
+		answers = append(answers, answer)
+	}
+	return answers, nil
+}
+
+func (r *memoryAnswerRepository) FlagAnswer(ctx context.Context, tx *gorm.DB, id uint, flagged bool) error {
+	for _, answer := range r.answers {
+		if answer.ID == id {
+			answer.Flagged = flagged
+			return nil
+		}
+	}
+	return fmt.Errorf("answer not found: %w", gorm.ErrRecordNotFound)
+}
+
+func TestFlagQuestionAndReview(t *testing.T) {
+	repo := newReviewRepository(false,
+		&models.AssessmentQuestion{QuestionID: 10},
+		&models.AssessmentQuestion{QuestionID: 11},
+	)
+	service := newMemoryAttemptService(repo, AnswerHistoryConfig{})
+	ctx := context.Background()
+
+	if err := service.updateAttemptAnswer(ctx, nil, repo.attempt, SubmitAnswerRequest{QuestionID: 10, AnswerData: "a"}, false); err != nil {
+		t.Fatalf("updateAttemptAnswer() error = %v", err)
+	}
+
+	// Flagging works on answered and not yet answered questions, but only those of the attempt
+	for _, questionID := range []uint{10, 11} {
+		if err := service.FlagQuestion(ctx, 1, &FlagQuestionRequest{QuestionID: questionID, Flagged: true}, "student"); err != nil {
+			t.Fatalf("FlagQuestion(%d) error = %v", questionID, err)
+		}
+	}
+	if err := service.FlagQuestion(ctx, 1, &FlagQuestionRequest{QuestionID: 99, Flagged: true}, "student"); err != ErrQuestionNotFound {
+		t.Errorf("FlagQuestion(99) error = %v, want %v", err, ErrQuestionNotFound)
+	}
+	if err := service.FlagQuestion(ctx, 1, &FlagQuestionRequest{QuestionID: 10, Flagged: true}, "other"); err == nil {
+		t.Error("FlagQuestion() by another student succeeded")
+	}
+
+	summary, err := service.EnterReview(ctx, 1, "student")
+	if err != nil {
+		t.Fatalf("EnterReview() error = %v", err)
+	}
+	if !summary.IsReview || !repo.attempt.IsReview {
+		t.Error("EnterReview() did not switch the attempt to review mode")
+	}
+	if summary.FlaggedCount != 2 || summary.AnsweredCount != 1 {
+		t.Errorf("summary flagged %d, answered %d, want 2, 1", summary.FlaggedCount, summary.AnsweredCount)
+	}
+
+	// Going back to a question leaves review mode
+	if err := service.NavigateToQuestion(ctx, 1, &NavigateAttemptRequest{QuestionIndex: 1}, "student"); err != nil {
+		t.Fatalf("NavigateToQuestion() error = %v", err)
+	}
+	if repo.attempt.IsReview || repo.attempt.CurrentQuestionIndex != 1 {
+		t.Errorf("after navigating IsReview = %v, question index = %d, want false, 1", repo.attempt.IsReview, repo.attempt.CurrentQuestionIndex)
+	}
+}
+
+func TestNavigationAfterTimeout(t *testing.T) {
+	repo := newReviewRepository(false, &models.AssessmentQuestion{QuestionID: 10}, &models.AssessmentQuestion{QuestionID: 11})
+	service := newMemoryAttemptService(repo, AnswerHistoryConfig{})
+
+	// The request read the attempt in progress, then the sweeper timed it out
+	repo.readStatus = models.AttemptInProgress
+	repo.attempt.Status = models.AttemptTimeOut
+
+	if _, err := service.EnterReview(context.Background(), 1, "student"); err != ErrAttemptNotActive {
+		t.Errorf("EnterReview() error = %v, want %v", err, ErrAttemptNotActive)
+	}
+	if err := service.NavigateToQuestion(context.Background(), 1, &NavigateAttemptRequest{QuestionIndex: 1}, "student"); err != ErrAttemptNotActive {
+		t.Errorf("NavigateToQuestion() error = %v, want %v", err, ErrAttemptNotActive)
+	}
+	if repo.attempt.Status != models.AttemptTimeOut || repo.attempt.IsReview || repo.attempt.CurrentQuestionIndex != 0 {
+		t.Errorf("timed out attempt was changed: %+v", repo.attempt)
+	}
+}
+
+func TestReviewSummary(t *testing.T) {
+	tests := []struct {
+		name                string
+		requireConfirmation bool
+		answered            []uint
+		want                AttemptReviewSummary
+	}{
+		{"all answered", true, []uint{10, 11, 12}, AttemptReviewSummary{AnsweredCount: 3}},
+		{"optional question unanswered", true, []uint{10, 11}, AttemptReviewSummary{AnsweredCount: 2, UnansweredCount: 1}},
+		{"required question unanswered", true, []uint{10, 12}, AttemptReviewSummary{AnsweredCount: 2, UnansweredCount: 1, UnansweredRequiredCount: 1, RequiresSubmitConfirm: true}},
+		{"confirmation not required", false, []uint{12}, AttemptReviewSummary{AnsweredCount: 1, UnansweredCount: 2, UnansweredRequiredCount: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newReviewRepository(tt.requireConfirmation,
+				&models.AssessmentQuestion{QuestionID: 10, Required: true},
+				&models.AssessmentQuestion{QuestionID: 11, Required: true},
+				&models.AssessmentQuestion{QuestionID: 12},
+			)
+			service := newMemoryAttemptService(repo, AnswerHistoryConfig{})
+			for _, questionID := range tt.answered {
+				if err := service.updateAttemptAnswer(context.Background(), nil, repo.attempt, SubmitAnswerRequest{QuestionID: questionID, AnswerData: "a"}, false); err != nil {
+					t.Fatalf("updateAttemptAnswer() error = %v", err)
+				}
+			}
+
+			summary, err := service.GetReviewSummary(context.Background(), 1, "student")
+			if err != nil {
+				t.Fatalf("GetReviewSummary() error = %v", err)
+			}
+
+			got := AttemptReviewSummary{
+				AnsweredCount:           summary.AnsweredCount,
+				UnansweredCount:         summary.UnansweredCount,
+				UnansweredRequiredCount: summary.UnansweredRequiredCount,
+				RequiresSubmitConfirm:   summary.RequiresSubmitConfirm,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetReviewSummary() = %+v, want %+v", got, tt.want)
+			}
+			if summary.TotalQuestions != 3 || len(summary.Questions) != 3 || summary.Questions[2].QuestionID != 12 {
+				t.Errorf("summary questions = %+v, want the 3 questions in order", summary.Questions)
+			}
+		})
+	}
+}
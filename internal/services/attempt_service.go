@@ -14,21 +14,37 @@ import (
 	"gorm.io/gorm"
 )
 
+// defaultAnswerHistoryEntries caps an answer's history when no limit is configured
+const defaultAnswerHistoryEntries = 50
+
+// AnswerHistoryConfig controls the log of answer changes kept for integrity reviews
+type AnswerHistoryConfig struct {
+	MaxEntries int // oldest entries beyond this are dropped, defaults to 50
+}
+
 type attemptService struct {
 	repo         repositories.Repository
 	db           *gorm.DB
 	logger       *slog.Logger
 	validator    *validator.Validator
 	cacheManager *cache.CacheManager
+	notifier     NotificationEventService
+	history      AnswerHistoryConfig
 }
 
-func NewAttemptService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, cacheManager *cache.CacheManager) AttemptService {
+func NewAttemptService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, cacheManager *cache.CacheManager, notifier NotificationEventService, history AnswerHistoryConfig) AttemptService {
+	if history.MaxEntries <= 0 {
+		history.MaxEntries = defaultAnswerHistoryEntries
+	}
+
 	return &attemptService{
 		repo:         repo,
 		db:           db,
 		logger:       logger,
 		validator:    validator,
 		cacheManager: cacheManager,
+		notifier:     notifier,
+		history:      history,
 	}
 }
 
@@ -68,23 +84,44 @@ func (s *attemptService) Start(ctx context.Context, req *StartAttemptRequest, st
 		return nil, err
 	}
 
+	client := clientFromRequest(ctx, req.ClientSessionInfo)
+
 	if currentAttempt != nil && currentAttempt.Status == models.AttemptInProgress {
 		s.logger.Info("Resuming existing attempt", "attempt_id", currentAttempt.ID)
+		if _, err := s.trackSession(ctx, currentAttempt.AssessmentAttempt, client); err != nil {
+			s.logger.Warn("Failed to track attempt session", "attempt_id", currentAttempt.ID, "error", err)
+		}
 		return currentAttempt, nil
 	}
 
+	// Pin the attempt to the current content so later edits do not change what it is graded against.
+	// The version records a change made by the teacher, not by the student starting the attempt.
+	version, err := snapshotAssessment(ctx, s.repo, s.db, req.AssessmentID, SystemUserID, VersionReasonAttemptStarted)
+	if err != nil {
+		return nil, err
+	}
+
 	// Begin transaction
 	var attempt *models.AssessmentAttempt
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// Create new attempt
 		currentTime := time.Now()
 		attempt = &models.AssessmentAttempt{
-			AssessmentID:  req.AssessmentID,
-			StudentID:     studentID,
-			Status:        models.AttemptInProgress,
-			StartedAt:     &currentTime,
-			TimeRemaining: assessment.Duration * 60, // Convert minutes to seconds
+			AssessmentID:      req.AssessmentID,
+			StudentID:         studentID,
+			Status:            models.AttemptInProgress,
+			StartedAt:         &currentTime,
+			TimeRemaining:     assessment.Duration * 60, // Convert minutes to seconds
+			AssessmentVersion: version.Version,
+			IPAddress:         stringPtrOrNil(client.IPAddress),
+			UserAgent:         stringPtrOrNil(client.UserAgent),
+		}
+
+		sessionData, err := newAttemptSessionData(client, currentTime)
+		if err != nil {
+			return err
 		}
+		attempt.SessionData = sessionData
 
 		// Calculate end time (Duration is in minutes, convert to time)
 		endTime := attempt.StartedAt.Add(time.Duration(assessment.Duration) * time.Minute)
@@ -131,6 +168,11 @@ func (s *attemptService) Start(ctx context.Context, req *StartAttemptRequest, st
 		"assessment_id", req.AssessmentID,
 		"student_id", studentID)
 
+	attemptID := attempt.ID
+	notifyAsync(s.notifier, s.logger, "attempt_started", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyAttemptStarted(ctx, attemptID)
+	})
+
 	// Return attempt with questions
 	return s.GetByIDWithDetails(ctx, attempt.ID, studentID)
 }
@@ -209,11 +251,33 @@ func (s *attemptService) Submit(ctx context.Context, req *SubmitAttemptRequest,
 		return nil, ErrAttemptTimeExpired
 	}
 
+	// Require an explicit confirmation before leaving required questions unanswered
+	if req.EndReason != models.AttemptEndReasonTimeout && !req.ConfirmUnanswered {
+		assessment, err := s.repo.Assessment().GetByID(ctx, s.db, attempt.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assessment: %w", err)
+		}
+		if assessment.Settings.RequireSubmitConfirmation {
+			unanswered, err := s.unansweredRequiredQuestions(ctx, attempt, req.Answers)
+			if err != nil {
+				return nil, err
+			}
+			if len(unanswered) > 0 {
+				return nil, NewBusinessRuleError("AT-UNANSWERED-REQUIRED-QUESTIONS",
+					"required questions are unanswered, confirm to submit anyway",
+					map[string]interface{}{
+						"attempt_id":              attempt.ID,
+						"unanswered_question_ids": unanswered,
+					})
+			}
+		}
+	}
+
 	// Begin transaction
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// Update all answers
 		for _, answerReq := range req.Answers {
-			if err := s.updateAttemptAnswer(ctx, tx, req.AttemptID, answerReq, studentID); err != nil {
+			if err := s.updateAttemptAnswer(ctx, tx, attempt, answerReq, true); err != nil {
 				return fmt.Errorf("failed to update answer for question %d: %w", answerReq.QuestionID, err)
 			}
 		}
@@ -228,9 +292,15 @@ func (s *attemptService) Submit(ctx context.Context, req *SubmitAttemptRequest,
 			attempt.EndReason = &req.EndReason
 		}
 
-		if err = s.repo.Attempt().Update(ctx, tx, attempt); err != nil {
+		// Only the columns submit changes, and only while the attempt is in progress, so a submit
+		// racing the timeout sweeper cannot reopen or regrade a timed out attempt
+		submitted, err := s.repo.Attempt().MarkSubmitted(ctx, tx, attempt)
+		if err != nil {
 			return fmt.Errorf("failed to update attempt: %w", err)
 		}
+		if !submitted {
+			return ErrAttemptNotActive
+		}
 
 		return nil
 	})
@@ -246,8 +316,12 @@ func (s *attemptService) Submit(ctx context.Context, req *SubmitAttemptRequest,
 	// Clean up randomization seeds from cache
 	s.deleteSeedsFromCache(ctx, req.AttemptID)
 
+	notifyAsync(s.notifier, s.logger, "attempt_submitted", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyAttemptSubmitted(ctx, req.AttemptID)
+	})
+
 	// Auto-grade if possible
-	gradingService := NewGradingService(s.db, s.repo, s.logger, s.validator)
+	gradingService := NewGradingService(s.db, s.repo, s.logger, s.validator, s.notifier)
 	if _, err := gradingService.AutoGradeAttempt(context.Background(), req.AttemptID); err != nil {
 		s.logger.Error("Failed to auto-grade attempt", "attempt_id", req.AttemptID, "error", err)
 	}
@@ -296,7 +370,7 @@ func (s *attemptService) SubmitAnswer(ctx context.Context, attemptID uint, req *
 	}
 
 	// Update answer
-	if err := s.updateAttemptAnswer(ctx, s.db, attemptID, *req, studentID); err != nil {
+	if err := s.updateAttemptAnswer(ctx, s.db, attempt, *req, false); err != nil {
 		return fmt.Errorf("failed to update answer: %w", err)
 	}
 
@@ -416,7 +490,7 @@ func (s *attemptService) GetByStudent(ctx context.Context, studentID string, fil
 
 func (s *attemptService) GetByAssessment(ctx context.Context, assessmentID uint, filters repositories.AttemptFilters, userID string) ([]*AttemptResponse, int64, error) {
 	// Check if user can access assessment attempts
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
 	if err != nil {
 		return nil, 0, err
@@ -1,16 +1,19 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	cryptoRand "crypto/rand"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	mathRand "math/rand"
+	"reflect"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -77,7 +80,7 @@ func (s *attemptService) ExtendTime(ctx context.Context, attemptID uint, minutes
 	}
 
 	// Check if user can access the assessment
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, attempt.AssessmentID, userID)
 	if err != nil {
 		return err
@@ -91,14 +94,18 @@ func (s *attemptService) ExtendTime(ctx context.Context, attemptID uint, minutes
 		return ErrAttemptNotActive
 	}
 
-	// Extend time
+	// Extend time, unless the timeout sweeper closed the attempt meanwhile
 	if attempt.EndedAt != nil {
 		newEndTime := attempt.EndedAt.Add(time.Duration(minutes) * time.Minute)
 		attempt.EndedAt = &newEndTime
-	}
 
-	if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
-		return fmt.Errorf("failed to extend attempt time: %w", err)
+		extended, err := s.repo.Attempt().ExtendEndTime(ctx, nil, attempt.ID, *attempt.EndedAt)
+		if err != nil {
+			return fmt.Errorf("failed to extend attempt time: %w", err)
+		}
+		if !extended {
+			return ErrAttemptNotActive
+		}
 	}
 
 	s.logger.Info("Attempt time extended successfully",
@@ -122,21 +129,24 @@ func (s *attemptService) HandleTimeout(ctx context.Context, attemptID uint) erro
 		return nil // Already handled
 	}
 
-	// Update attempt status to timeout
-	attempt.Status = models.AttemptTimeOut
-	timeoutReason := models.AttemptEndReasonTimeout
-	attempt.EndReason = &timeoutReason
-	attempt.CompletedAt = timePtr(time.Now())
-
-	if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
+	// Update attempt status to timeout, unless a submit or the timeout sweeper got there first
+	updated, err := s.repo.Attempt().MarkTimedOut(ctx, nil, attemptID, time.Now())
+	if err != nil {
 		return fmt.Errorf("failed to update attempt status: %w", err)
 	}
+	if !updated {
+		return nil // Already handled
+	}
 
 	s.logger.Info("Attempt timeout handled successfully", "attempt_id", attemptID)
 
+	notifyAsync(s.notifier, s.logger, "attempt_submitted", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyAttemptSubmitted(ctx, attemptID)
+	})
+
 	// Auto-grade timed out attempt
 	go func() {
-		gradingService := NewGradingService(s.db, s.repo, s.logger, s.validator)
+		gradingService := NewGradingService(s.db, s.repo, s.logger, s.validator, s.notifier)
 		if _, err := gradingService.AutoGradeAttempt(context.Background(), attemptID); err != nil {
 			s.logger.Error("Failed to auto-grade timed out attempt", "attempt_id", attemptID, "error", err)
 		}
@@ -148,8 +158,8 @@ func (s *attemptService) HandleTimeout(ctx context.Context, attemptID uint) erro
 // ===== VALIDATION =====
 
 func (s *attemptService) CanStart(ctx context.Context, assessmentID uint, studentID string) (bool, error) {
-	// Check if assessment is available for taking
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	// Check if assessment is available for taking (status, due date and enrollment)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canTake, err := assessmentService.CanTake(ctx, assessmentID, studentID)
 	if err != nil {
 		return false, err
@@ -222,11 +232,368 @@ func (s *attemptService) IsAttemptActive(ctx context.Context, attemptID uint) (b
 	return true, nil
 }
 
+// ===== SESSION TRACKING =====
+
+// maxSessionChanges caps the device/network changes kept in an attempt's session data
+const maxSessionChanges = 20
+
+// Heartbeat records the client the student is working from and returns the remaining time
+func (s *attemptService) Heartbeat(ctx context.Context, attemptID uint, req *AttemptHeartbeatRequest, studentID string) (*AttemptHeartbeatResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.getActiveAttempt(ctx, attemptID, studentID, "heartbeat")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.trackSession(ctx, attempt, clientFromRequest(ctx, req.ClientSessionInfo)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &AttemptHeartbeatResponse{
+		AttemptID:  attempt.ID,
+		ServerTime: now,
+	}
+	if attempt.EndedAt != nil {
+		response.TimeRemaining = max(int(attempt.EndedAt.Sub(now).Seconds()), 0)
+	}
+
+	return response, nil
+}
+
+// trackSession stores the client in the attempt's session data. When the device fingerprint or IP
+// address differs from the last seen client, the attempt is flagged and a proctoring event is recorded.
+// It returns whether such a change was detected.
+func (s *attemptService) trackSession(ctx context.Context, attempt *models.AssessmentAttempt, client models.AttemptClient) (bool, error) {
+	now := time.Now()
+
+	var (
+		data    datatypes.JSON
+		changed []string
+	)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Heartbeats and page loads of one attempt arrive in parallel; each sees the previous one's update
+		stored, err := s.repo.Attempt().LockSessionData(ctx, tx, attempt.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get session data: %w", err)
+		}
+
+		var session models.AttemptSession
+		if !isEmptyJSON(stored) {
+			if err := json.Unmarshal(stored, &session); err != nil {
+				s.logger.Warn("Discarding unreadable attempt session data", "attempt_id", attempt.ID, "error", err)
+				session = models.AttemptSession{}
+			}
+		}
+
+		if session.FirstSeenAt.IsZero() {
+			data, err = newAttemptSessionData(client, now)
+			if err != nil {
+				return err
+			}
+			if err := s.repo.Attempt().UpdateSessionData(ctx, tx, attempt.ID, data); err != nil {
+				return fmt.Errorf("failed to update session data: %w", err)
+			}
+			return nil
+		}
+
+		changed = sessionChanges(session.AttemptClient, client)
+		var change models.AttemptSessionChange
+		if len(changed) > 0 {
+			change = models.AttemptSessionChange{
+				DetectedAt: now,
+				Fields:     changed,
+				Previous:   session.AttemptClient,
+				Current:    client,
+			}
+			session.Changes = append(session.Changes, change)
+			if len(session.Changes) > maxSessionChanges {
+				session.Changes = session.Changes[len(session.Changes)-maxSessionChanges:]
+			}
+		}
+
+		session.AttemptClient = mergeClient(session.AttemptClient, client)
+		session.LastSeenAt = now
+		session.Heartbeats++
+
+		data, err = json.Marshal(session)
+		if err != nil {
+			return fmt.Errorf("failed to encode session data: %w", err)
+		}
+		if err := s.repo.Attempt().UpdateSessionData(ctx, tx, attempt.ID, data); err != nil {
+			return fmt.Errorf("failed to update session data: %w", err)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err := s.repo.Attempt().FlagSession(ctx, tx, attempt.ID); err != nil {
+			return fmt.Errorf("failed to flag attempt session: %w", err)
+		}
+		event, err := buildSessionChangeEvent(attempt, change)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.ProctoringEvent().Create(ctx, tx, event); err != nil {
+			return fmt.Errorf("failed to record session change: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	attempt.SessionData = data
+	if len(changed) > 0 {
+		attempt.SessionFlagged = true
+		s.logger.Warn("Attempt session changed device or network",
+			"attempt_id", attempt.ID,
+			"student_id", attempt.StudentID,
+			"fields", changed)
+	}
+
+	return len(changed) > 0, nil
+}
+
+// clientFromRequest combines the device info reported by the client with the request's IP address and user agent
+func clientFromRequest(ctx context.Context, info ClientSessionInfo) models.AttemptClient {
+	client := models.AttemptClient{
+		ScreenResolution:  info.ScreenResolution,
+		DeviceFingerprint: info.DeviceFingerprint,
+	}
+	if requestInfo, ok := utils.RequestInfoFromContext(ctx); ok {
+		client.IPAddress = requestInfo.IPAddress
+		client.UserAgent = requestInfo.UserAgent
+	}
+	return client
+}
+
+func newAttemptSessionData(client models.AttemptClient, now time.Time) (datatypes.JSON, error) {
+	data, err := json.Marshal(models.AttemptSession{
+		AttemptClient: client,
+		FirstSeenAt:   now,
+		LastSeenAt:    now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode session data: %w", err)
+	}
+	return data, nil
+}
+
+// sessionChanges lists the identifying fields that differ; a field the client did not send is not a change
+func sessionChanges(previous, current models.AttemptClient) []string {
+	var changed []string
+	if previous.IPAddress != "" && current.IPAddress != "" && previous.IPAddress != current.IPAddress {
+		changed = append(changed, "ip_address")
+	}
+	if previous.DeviceFingerprint != "" && current.DeviceFingerprint != "" && previous.DeviceFingerprint != current.DeviceFingerprint {
+		changed = append(changed, "device_fingerprint")
+	}
+	return changed
+}
+
+// mergeClient keeps previously known values for fields the current request left empty
+func mergeClient(previous, current models.AttemptClient) models.AttemptClient {
+	if current.IPAddress == "" {
+		current.IPAddress = previous.IPAddress
+	}
+	if current.UserAgent == "" {
+		current.UserAgent = previous.UserAgent
+	}
+	if current.ScreenResolution == "" {
+		current.ScreenResolution = previous.ScreenResolution
+	}
+	if current.DeviceFingerprint == "" {
+		current.DeviceFingerprint = previous.DeviceFingerprint
+	}
+	return current
+}
+
+func buildSessionChangeEvent(attempt *models.AssessmentAttempt, change models.AttemptSessionChange) (*models.ProctoringEvent, error) {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode session change: %w", err)
+	}
+
+	timeOffset := 0
+	if attempt.StartedAt != nil && change.DetectedAt.After(*attempt.StartedAt) {
+		timeOffset = int(change.DetectedAt.Sub(*attempt.StartedAt).Seconds())
+	}
+
+	return &models.ProctoringEvent{
+		AttemptID:    attempt.ID,
+		Type:         models.EventSessionChange,
+		Data:         data,
+		Severity:     defaultEventSeverity[models.EventSessionChange],
+		TimeOffset:   timeOffset,
+		UserAgent:    change.Current.UserAgent,
+		IPAddress:    change.Current.IPAddress,
+		ReviewStatus: models.ProctoringReviewPending,
+		CreatedAt:    change.DetectedAt,
+	}, nil
+}
+
+func stringPtrOrNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// ===== FLAGGING AND REVIEW =====
+
+// FlagQuestion marks or unmarks a question of the student's in-progress attempt for review
+func (s *attemptService) FlagQuestion(ctx context.Context, attemptID uint, req *FlagQuestionRequest, studentID string) error {
+	if err := s.validator.Validate(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.getActiveAttempt(ctx, attemptID, studentID, "flag_question")
+	if err != nil {
+		return err
+	}
+
+	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, s.db, attempt.ID, req.QuestionID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return fmt.Errorf("failed to get answer: %w", err)
+		}
+
+		// Answers are created when the attempt starts; a missing one means the question is not part of it
+		if _, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, s.db, attempt.AssessmentID, req.QuestionID); err != nil {
+			return ErrQuestionNotFound
+		}
+		answer = &models.StudentAnswer{
+			AttemptID:  attempt.ID,
+			QuestionID: req.QuestionID,
+			Flagged:    req.Flagged,
+		}
+		if err := s.repo.Answer().Create(ctx, s.db, answer); err != nil {
+			return fmt.Errorf("failed to create answer: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.repo.Answer().FlagAnswer(ctx, s.db, answer.ID, req.Flagged); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EnterReview switches the attempt to review mode and returns the pre-submit summary
+func (s *attemptService) EnterReview(ctx context.Context, attemptID uint, studentID string) (*AttemptReviewSummary, error) {
+	attempt, err := s.getActiveAttempt(ctx, attemptID, studentID, "review")
+	if err != nil {
+		return nil, err
+	}
+
+	if !attempt.IsReview {
+		attempt.IsReview = true
+		updated, err := s.repo.Attempt().UpdateNavigation(ctx, s.db, attempt.ID, attempt.CurrentQuestionIndex, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enter review mode: %w", err)
+		}
+		if !updated {
+			return nil, ErrAttemptNotActive
+		}
+	}
+
+	return s.buildReviewSummary(ctx, attempt)
+}
+
+// GetReviewSummary returns the answered/unanswered/flagged summary of the student's attempt
+func (s *attemptService) GetReviewSummary(ctx context.Context, attemptID uint, studentID string) (*AttemptReviewSummary, error) {
+	attempt, err := s.getActiveAttempt(ctx, attemptID, studentID, "review")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildReviewSummary(ctx, attempt)
+}
+
+// NavigateToQuestion moves the student to a question, leaving review mode
+func (s *attemptService) NavigateToQuestion(ctx context.Context, attemptID uint, req *NavigateAttemptRequest, studentID string) error {
+	if err := s.validator.Validate(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.getActiveAttempt(ctx, attemptID, studentID, "navigate")
+	if err != nil {
+		return err
+	}
+
+	if attempt.TotalQuestions > 0 && req.QuestionIndex >= attempt.TotalQuestions {
+		return NewBusinessRuleError("AT-INVALID-QUESTION-INDEX", "question index is out of range",
+			map[string]interface{}{
+				"question_index":  req.QuestionIndex,
+				"total_questions": attempt.TotalQuestions,
+			})
+	}
+
+	updated, err := s.repo.Attempt().UpdateNavigation(ctx, s.db, attempt.ID, req.QuestionIndex, false)
+	if err != nil {
+		return fmt.Errorf("failed to update current question: %w", err)
+	}
+	if !updated {
+		return ErrAttemptNotActive
+	}
+
+	return nil
+}
+
+// ===== ANSWER HISTORY =====
+
+// GetAnswerHistory returns the change log of an answer in the attempt to teachers of the assessment
+func (s *attemptService) GetAnswerHistory(ctx context.Context, attemptID, answerID uint, userID string) ([]repositories.AnswerHistoryEntry, error) {
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_answer_history", "insufficient permissions")
+	}
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canAccess, err := s.canAccessAttempt(ctx, attempt, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_answer_history", "not owner or insufficient permissions")
+	}
+
+	answer, err := s.repo.Answer().GetByID(ctx, s.db, answerID)
+	if err != nil {
+		return nil, ErrAnswerNotFound
+	}
+	if answer.AttemptID != attemptID {
+		return nil, ErrAnswerNotFound
+	}
+
+	history, err := s.repo.Answer().GetAnswerHistory(ctx, s.db, answerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer history: %w", err)
+	}
+
+	return history, nil
+}
+
 // ===== STATISTICS =====
 
 func (s *attemptService) GetStats(ctx context.Context, assessmentID uint, userID string) (*repositories.AttemptStats, error) {
 	// Check access permission
-	assessmentService := NewAssessmentService(s.repo, nil, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, nil, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
 	if err != nil {
 		return nil, err
@@ -271,13 +638,159 @@ func (s *attemptService) canAccessAttempt(ctx context.Context, attempt *models.A
 
 	// Teachers/Admins can access attempts for their assessments
 	if userRole == models.RoleTeacher {
-		assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+		assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 		return assessmentService.CanAccess(ctx, attempt.AssessmentID, userID)
 	}
 
 	return false, nil
 }
 
+// getActiveAttempt loads an attempt the student owns and can still work on
+func (s *attemptService) getActiveAttempt(ctx context.Context, attemptID uint, studentID, action string) (*models.AssessmentAttempt, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	if attempt.StudentID != studentID {
+		return nil, NewPermissionError(studentID, attemptID, "attempt", action, "not owned by student")
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if attempt.EndedAt != nil && time.Now().After(*attempt.EndedAt) {
+		return nil, ErrAttemptTimeExpired
+	}
+
+	return attempt, nil
+}
+
+// attemptQuestionOrder returns the assessment questions in the order the student sees them
+func (s *attemptService) attemptQuestionOrder(ctx context.Context, attempt *models.AssessmentAttempt, assessment *models.Assessment) ([]*models.AssessmentQuestion, error) {
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	if assessment != nil && assessment.Settings.RandomizeQuestions {
+		if seed, found := s.getSeedFromCache(ctx, attempt.ID, "question"); found {
+			assessmentQuestions = seededShuffle(assessmentQuestions, seed)
+		}
+	}
+
+	return assessmentQuestions, nil
+}
+
+// unansweredRequiredQuestions lists required questions without an answer, counting the answers
+// about to be saved as answered
+func (s *attemptService) unansweredRequiredQuestions(ctx context.Context, attempt *models.AssessmentAttempt, pending []SubmitAnswerRequest) ([]uint, error) {
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	answered, err := s.answeredQuestions(ctx, attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range pending {
+		if req.AnswerData != nil || req.Omit {
+			answered[req.QuestionID] = true
+		}
+	}
+
+	var unanswered []uint
+	for _, aq := range assessmentQuestions {
+		if aq.Required && !answered[aq.QuestionID] {
+			unanswered = append(unanswered, aq.QuestionID)
+		}
+	}
+	return unanswered, nil
+}
+
+// answeredQuestions returns the questions of the attempt that have a saved answer
+func (s *attemptService) answeredQuestions(ctx context.Context, attemptID uint) (map[uint]bool, error) {
+	answers, err := s.repo.Answer().GetByAttempt(ctx, s.db, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	answered := make(map[uint]bool, len(answers))
+	for _, answer := range answers {
+		// Omitting a question is a deliberate answer
+		if !isEmptyJSON(answer.Answer) || answer.Omitted {
+			answered[answer.QuestionID] = true
+		}
+	}
+	return answered, nil
+}
+
+func (s *attemptService) buildReviewSummary(ctx context.Context, attempt *models.AssessmentAttempt) (*AttemptReviewSummary, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	assessmentQuestions, err := s.attemptQuestionOrder(ctx, attempt, assessment)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, s.db, attempt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	answersByQuestion := make(map[uint]*models.StudentAnswer, len(answers))
+	for _, answer := range answers {
+		answersByQuestion[answer.QuestionID] = answer
+	}
+
+	summary := &AttemptReviewSummary{
+		AttemptID:            attempt.ID,
+		IsReview:             attempt.IsReview,
+		CurrentQuestionIndex: attempt.CurrentQuestionIndex,
+		TotalQuestions:       len(assessmentQuestions),
+		Questions:            make([]ReviewQuestionStatus, 0, len(assessmentQuestions)),
+	}
+
+	for i, aq := range assessmentQuestions {
+		status := ReviewQuestionStatus{
+			Index:      i,
+			QuestionID: aq.QuestionID,
+			Required:   aq.Required,
+		}
+		if answer, ok := answersByQuestion[aq.QuestionID]; ok {
+			status.Answered = !isEmptyJSON(answer.Answer)
+			status.Flagged = answer.Flagged
+		}
+
+		if status.Answered {
+			summary.AnsweredCount++
+		} else {
+			summary.UnansweredCount++
+			if status.Required {
+				summary.UnansweredRequiredCount++
+			}
+		}
+		if status.Flagged {
+			summary.FlaggedCount++
+		}
+		summary.Questions = append(summary.Questions, status)
+	}
+
+	summary.RequiresSubmitConfirm = assessment.Settings.RequireSubmitConfirmation && summary.UnansweredRequiredCount > 0
+
+	if attempt.EndedAt != nil {
+		remaining := max(int(time.Until(*attempt.EndedAt).Seconds()), 0)
+		summary.TimeRemaining = &remaining
+	}
+
+	return summary, nil
+}
+
 func (s *attemptService) buildAttemptResponse(ctx context.Context, attempt *models.AssessmentAttempt, userID string, includeQuestions bool) *AttemptResponse {
 	response := &AttemptResponse{
 		AssessmentAttempt: attempt,
@@ -293,7 +806,7 @@ func (s *attemptService) buildAttemptResponse(ctx context.Context, attempt *mode
 	response.IsPendingGrade = !attempt.IsGraded && attempt.Status == models.AttemptCompleted
 	// Include questions if requested and user is the student
 	if includeQuestions && attempt.StudentID == userID {
-		questions, err := s.getAttemptQuestions(ctx, attempt.AssessmentID)
+		questions, err := s.getAttemptQuestions(ctx, attempt)
 		if err != nil {
 			s.logger.Error("Failed to get attempt questions", "attempt_id", attempt.ID, "error", err)
 		} else {
@@ -369,9 +882,18 @@ func (s *attemptService) buildAttemptResponse(ctx context.Context, attempt *mode
 	return response
 }
 
-func (s *attemptService) getAttemptQuestions(ctx context.Context, assessmentId uint) ([]QuestionForAttempt, error) {
+func (s *attemptService) getAttemptQuestions(ctx context.Context, attempt *models.AssessmentAttempt) ([]QuestionForAttempt, error) {
+	// Attempts pinned to a version show the questions as they were when the attempt started
+	snapshot, err := getPinnedSnapshot(ctx, s.repo, s.db, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		return s.applyAttemptVariants(ctx, attempt, snapshotAttemptQuestions(snapshot))
+	}
+
 	// Get assessment questions with answers
-	assessmentQuestions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, assessmentId)
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, attempt.AssessmentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
 	}
@@ -386,7 +908,29 @@ func (s *attemptService) getAttemptQuestions(ctx context.Context, assessmentId u
 		}
 	}
 
-	return questions, nil
+	return s.applyAttemptVariants(ctx, attempt, questions)
+}
+
+func snapshotAttemptQuestions(snapshot *models.AssessmentSnapshot) []QuestionForAttempt {
+	questions := make([]QuestionForAttempt, len(snapshot.Questions))
+	for i, sq := range snapshot.Questions {
+		questions[i] = QuestionForAttempt{
+			Question: &models.Question{
+				ID:          sq.QuestionID,
+				Type:        sq.Type,
+				Text:        sq.Text,
+				Points:      sq.Points,
+				Order:       sq.Order,
+				Content:     sq.Content,
+				Answer:      sq.Answer,
+				Difficulty:  sq.Difficulty,
+				Explanation: sq.Explanation,
+			},
+			IsFirst: i == 0,
+			IsLast:  i == len(snapshot.Questions)-1,
+		}
+	}
+	return questions
 }
 
 func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt, assessment *models.Assessment) error {
@@ -396,6 +940,12 @@ func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.
 		return fmt.Errorf("failed to get assessment questions: %w", err)
 	}
 
+	// Templated questions get their own variant for this attempt
+	variants, err := s.drawAttemptVariants(ctx, tx, attempt)
+	if err != nil {
+		return err
+	}
+
 	// Create empty answers for all questions
 	answers := make([]*models.StudentAnswer, len(assessmentQuestions))
 	for i, aq := range assessmentQuestions {
@@ -403,6 +953,7 @@ func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.
 			AttemptID:  attempt.ID,
 			QuestionID: aq.QuestionID,
 			Answer:     nil, // Empty initially
+			Variant:    variants[aq.QuestionID],
 			Flagged:    false,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
@@ -417,14 +968,16 @@ func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.
 	return nil
 }
 
-func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, attemptID uint, req SubmitAnswerRequest, studentID string) error {
+// updateAttemptAnswer saves a student's answer and records the change in the answer history.
+// final marks changes made by the final submit of the attempt.
+func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt, req SubmitAnswerRequest, final bool) error {
 	// Get existing answer
-	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, tx, attemptID, req.QuestionID)
+	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, tx, attempt.ID, req.QuestionID)
 	if err != nil {
 		if repositories.IsNotFoundError(err) {
 			// Create new answer if doesn't exist
 			answer = &models.StudentAnswer{
-				AttemptID:  attemptID,
+				AttemptID:  attempt.ID,
 				QuestionID: req.QuestionID,
 			}
 		} else {
@@ -432,16 +985,36 @@ func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, a
 		}
 	}
 
-	// Convert answer data to JSON
-	if req.AnswerData != nil {
+	now := time.Now()
+	previous := answer.Answer
+	changed := false
+
+	// Convert answer data to JSON; omitting clears the answer
+	if req.Omit {
+		changed = !answer.Omitted
+		answer.Answer = nil
+		answer.Omitted = true
+	} else if req.AnswerData != nil {
 		answerBytes, err := json.Marshal(req.AnswerData)
 		if err != nil {
 			return fmt.Errorf("failed to marshal answer data: %w", err)
 		}
+		changed = !sameJSON(previous, answerBytes) || answer.Omitted
 		answer.Answer = answerBytes
+		answer.Omitted = false
 	}
 
-	answer.UpdatedAt = time.Now()
+	if req.Confidence != nil {
+		answer.Confidence = req.Confidence
+	}
+
+	if changed {
+		if answer.FirstAnsweredAt == nil {
+			answer.FirstAnsweredAt = &now
+		}
+		answer.LastModifiedAt = &now
+	}
+	answer.UpdatedAt = now
 
 	if req.TimeSpent != nil {
 		answer.TimeSpent = *req.TimeSpent
@@ -458,9 +1031,68 @@ func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, a
 		}
 	}
 
+	if !changed {
+		return nil
+	}
+
+	entry := repositories.AnswerHistoryEntry{
+		Timestamp: now,
+		Action:    answerHistoryAction(previous, final),
+		Answer:    json.RawMessage(answer.Answer),
+	}
+	if answer.Omitted {
+		entry.Action = repositories.AnswerActionOmitted
+	}
+	if !isEmptyJSON(previous) {
+		entry.PreviousAnswer = json.RawMessage(previous)
+	}
+	if attempt.StartedAt != nil {
+		entry.OffsetSeconds = int(now.Sub(*attempt.StartedAt).Seconds())
+	}
+	if info, ok := utils.RequestInfoFromContext(ctx); ok {
+		entry.IPAddress = info.IPAddress
+		entry.UserAgent = info.UserAgent
+	}
+
+	if err := s.repo.Answer().UpdateAnswerHistory(ctx, tx, answer.ID, entry, s.history.MaxEntries); err != nil {
+		return fmt.Errorf("failed to record answer history: %w", err)
+	}
+
 	return nil
 }
 
+// answerHistoryAction classifies an answer change for the history
+func answerHistoryAction(previous []byte, final bool) string {
+	switch {
+	case final:
+		return repositories.AnswerActionSubmitted
+	case isEmptyJSON(previous):
+		return repositories.AnswerActionCreated
+	default:
+		return repositories.AnswerActionUpdated
+	}
+}
+
+// isEmptyJSON reports whether a stored answer holds no value
+func isEmptyJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// sameJSON compares two JSON documents by value; jsonb read back from PostgreSQL is
+// reformatted, so the bytes of an unchanged answer differ from the request's
+func sameJSON(a, b []byte) bool {
+	if isEmptyJSON(a) || isEmptyJSON(b) {
+		return isEmptyJSON(a) == isEmptyJSON(b)
+	}
+
+	var left, right interface{}
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(left, right)
+}
+
 // ===== ANSWER SANITIZATION HELPERS =====
 
 // shouldShowCorrectAnswers determines if correct answers should be shown based on attempt status and settings
@@ -523,6 +1155,10 @@ func (s *attemptService) sanitizeQuestionContent(questionType models.QuestionTyp
 		return s.sanitizeOrderingContent(content)
 	case models.ShortAnswer:
 		return s.sanitizeShortAnswerContent(content)
+	case models.Numeric:
+		return s.sanitizeNumericContent(content)
+	case models.Formula:
+		return s.sanitizeFormulaContent(content)
 	default:
 		return content
 	}
@@ -576,6 +1212,7 @@ func (s *attemptService) sanitizeEssayContent(content datatypes.JSON) datatypes.
 	// Remove sample answer and keywords used for auto-grading
 	delete(essay, "sample_answer")
 	delete(essay, "key_words")
+	delete(essay, "synonyms")
 
 	sanitized, err := json.Marshal(essay)
 	if err != nil {
@@ -598,6 +1235,7 @@ func (s *attemptService) sanitizeFillBlankContent(content datatypes.JSON) dataty
 		for key, blank := range blanks {
 			if blankMap, ok := blank.(map[string]interface{}); ok {
 				delete(blankMap, "accepted_answers")
+				delete(blankMap, "answer_rules")
 				blanks[key] = blankMap
 			}
 		}
@@ -659,6 +1297,7 @@ func (s *attemptService) sanitizeShortAnswerContent(content datatypes.JSON) data
 
 	// Remove accepted answers
 	delete(sa, "accepted_answers")
+	delete(sa, "answer_rules")
 
 	sanitized, err := json.Marshal(sa)
 	if err != nil {
@@ -669,22 +1308,54 @@ func (s *attemptService) sanitizeShortAnswerContent(content datatypes.JSON) data
 	return sanitized
 }
 
+func (s *attemptService) sanitizeNumericContent(content datatypes.JSON) datatypes.JSON {
+	var numeric map[string]interface{}
+	if err := json.Unmarshal(content, &numeric); err != nil {
+		s.logger.Error("Failed to unmarshal numeric content", "error", err)
+		return content
+	}
+
+	// Remove the correct value and tolerances; the unit and significant figures stay visible
+	delete(numeric, "correct_value")
+	delete(numeric, "absolute_tolerance")
+	delete(numeric, "relative_tolerance")
+
+	sanitized, err := json.Marshal(numeric)
+	if err != nil {
+		s.logger.Error("Failed to marshal sanitized numeric content", "error", err)
+		return content
+	}
+
+	return sanitized
+}
+
+func (s *attemptService) sanitizeFormulaContent(content datatypes.JSON) datatypes.JSON {
+	var formula map[string]interface{}
+	if err := json.Unmarshal(content, &formula); err != nil {
+		s.logger.Error("Failed to unmarshal formula content", "error", err)
+		return content
+	}
+
+	// Remove the correct formula and sampling settings; variable names stay visible
+	delete(formula, "correct_formula")
+	delete(formula, "sample_points")
+	delete(formula, "tolerance")
+
+	sanitized, err := json.Marshal(formula)
+	if err != nil {
+		s.logger.Error("Failed to marshal sanitized formula content", "error", err)
+		return content
+	}
+
+	return sanitized
+}
+
 // ===== RANDOMIZATION HELPERS (REDIS-BASED SEED STORAGE) =====
 
 // generateAndCacheSeed generates a cryptographically secure random seed and caches it in Redis
 // Returns the seed (falls back to timestamp-based seed if Redis fails)
 func (s *attemptService) generateAndCacheSeed(ctx context.Context, attemptID uint, seedType string, ttlMinutes int) (int64, error) {
-	// Generate a cryptographically secure random seed
-	var seedBytes [8]byte
-	if _, err := cryptoRand.Read(seedBytes[:]); err != nil {
-		s.logger.Warn("Failed to generate crypto random seed, using timestamp fallback",
-			"attempt_id", attemptID,
-			"seed_type", seedType,
-			"error", err)
-		return time.Now().UnixNano(), nil
-	}
-
-	seed := int64(binary.BigEndian.Uint64(seedBytes[:]) & 0x7FFFFFFFFFFFFFFF) // Ensure non-negative
+	seed := s.newRandomSeed(attemptID, seedType)
 
 	// Cache in Redis with TTL
 	cacheKey := fmt.Sprintf("attempt:%d:%s_seed", attemptID, seedType)
@@ -705,6 +1376,21 @@ func (s *attemptService) generateAndCacheSeed(ctx context.Context, attemptID uin
 	return seed, nil
 }
 
+// newRandomSeed generates a cryptographically secure, non-negative seed, falling back to the
+// current time if the system source fails
+func (s *attemptService) newRandomSeed(attemptID uint, seedType string) int64 {
+	var seedBytes [8]byte
+	if _, err := cryptoRand.Read(seedBytes[:]); err != nil {
+		s.logger.Warn("Failed to generate crypto random seed, using timestamp fallback",
+			"attempt_id", attemptID,
+			"seed_type", seedType,
+			"error", err)
+		return time.Now().UnixNano()
+	}
+
+	return int64(binary.BigEndian.Uint64(seedBytes[:]) & 0x7FFFFFFFFFFFFFFF) // Ensure non-negative
+}
+
 // getSeedFromCache retrieves a cached seed from Redis
 // Returns the seed and a boolean indicating if found
 func (s *attemptService) getSeedFromCache(ctx context.Context, attemptID uint, seedType string) (int64, bool) {
@@ -747,9 +1433,23 @@ func (s *attemptService) shuffleQuestionsWithSeed(questions []QuestionForAttempt
 		return questions
 	}
 
+	shuffled := seededShuffle(questions, seed)
+
+	// Update IsFirst and IsLast flags
+	for i := range shuffled {
+		shuffled[i].IsFirst = i == 0
+		shuffled[i].IsLast = i == len(shuffled)-1
+	}
+
+	return shuffled
+}
+
+// seededShuffle returns a shuffled copy of items; the same seed and length always give the same
+// permutation, so question lists of one attempt line up however they were loaded
+func seededShuffle[T any](items []T, seed int64) []T {
 	// Create a copy to avoid modifying the original slice
-	shuffled := make([]QuestionForAttempt, len(questions))
-	copy(shuffled, questions)
+	shuffled := make([]T, len(items))
+	copy(shuffled, items)
 
 	// Use seed for deterministic shuffle
 	rng := mathRand.New(mathRand.NewSource(seed))
@@ -757,12 +1457,6 @@ func (s *attemptService) shuffleQuestionsWithSeed(questions []QuestionForAttempt
 		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 	})
 
-	// Update IsFirst and IsLast flags
-	for i := range shuffled {
-		shuffled[i].IsFirst = i == 0
-		shuffled[i].IsLast = i == len(shuffled)-1
-	}
-
 	return shuffled
 }
 
@@ -15,6 +15,7 @@ func TestNewAttemptService(t *testing.T) {
 		db        *gorm.DB
 		logger    *slog.Logger
 		validator *validator.Validator
+		notifier  NotificationEventService
 	}
 	tests := []struct {
 		name string
@@ -25,7 +26,7 @@ func TestNewAttemptService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			NewAttemptService(tt.args.repo, tt.args.db, tt.args.logger, tt.args.validator, nil)
+			NewAttemptService(tt.args.repo, tt.args.db, tt.args.logger, tt.args.validator, nil, tt.args.notifier, AnswerHistoryConfig{})
 		})
 	}
 }
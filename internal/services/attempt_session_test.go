@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestSessionChanges(t *testing.T) {
+	laptop := models.AttemptClient{IPAddress: "10.0.0.1", UserAgent: "Firefox", DeviceFingerprint: "fp-laptop"}
+
+	tests := []struct {
+		name    string
+		current models.AttemptClient
+		want    []string
+	}{
+		{"same client", laptop, nil},
+		{"new user agent only", models.AttemptClient{IPAddress: "10.0.0.1", UserAgent: "Chrome", DeviceFingerprint: "fp-laptop"}, nil},
+		{"fields not sent", models.AttemptClient{}, nil},
+		{"network changed", models.AttemptClient{IPAddress: "10.0.0.2", DeviceFingerprint: "fp-laptop"}, []string{"ip_address"}},
+		{"device changed", models.AttemptClient{IPAddress: "10.0.0.1", DeviceFingerprint: "fp-phone"}, []string{"device_fingerprint"}},
+		{"device and network changed", models.AttemptClient{IPAddress: "10.0.0.2", DeviceFingerprint: "fp-phone"}, []string{"ip_address", "device_fingerprint"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionChanges(laptop, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sessionChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeClient(t *testing.T) {
+	previous := models.AttemptClient{IPAddress: "10.0.0.1", UserAgent: "Firefox", ScreenResolution: "1920x1080", DeviceFingerprint: "fp-laptop"}
+	current := models.AttemptClient{IPAddress: "10.0.0.2"}
+
+	want := models.AttemptClient{IPAddress: "10.0.0.2", UserAgent: "Firefox", ScreenResolution: "1920x1080", DeviceFingerprint: "fp-laptop"}
+	if got := mergeClient(previous, current); got != want {
+		t.Errorf("mergeClient() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuildSessionChangeEvent(t *testing.T) {
+	startedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	attempt := &models.AssessmentAttempt{ID: 5, StartedAt: &startedAt}
+	change := models.AttemptSessionChange{
+		DetectedAt: startedAt.Add(90 * time.Second),
+		Fields:     []string{"device_fingerprint"},
+		Previous:   models.AttemptClient{DeviceFingerprint: "fp-laptop"},
+		Current:    models.AttemptClient{IPAddress: "10.0.0.2", UserAgent: "Safari", DeviceFingerprint: "fp-phone"},
+	}
+
+	event, err := buildSessionChangeEvent(attempt, change)
+	if err != nil {
+		t.Fatalf("buildSessionChangeEvent() error = %v", err)
+	}
+	if event.AttemptID != 5 || event.Type != models.EventSessionChange || event.TimeOffset != 90 {
+		t.Errorf("event = attempt %d, type %s, offset %d, want 5, %s, 90", event.AttemptID, event.Type, event.TimeOffset, models.EventSessionChange)
+	}
+	if event.IPAddress != "10.0.0.2" || event.UserAgent != "Safari" {
+		t.Errorf("event client = %s %s, want the new client", event.IPAddress, event.UserAgent)
+	}
+
+	var recorded models.AttemptSessionChange
+	if err := json.Unmarshal(event.Data, &recorded); err != nil {
+		t.Fatalf("event data is not a session change: %v", err)
+	}
+	if !reflect.DeepEqual(recorded.Fields, change.Fields) || recorded.Previous != change.Previous {
+		t.Errorf("event data = %+v, want %+v", recorded, change)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const timeoutSweeperLockKey = "attempt-timeout-sweeper"
+
+type TimeoutSweeperConfig struct {
+	Enabled     bool
+	Interval    time.Duration // how often overdue attempts are looked up
+	GracePeriod time.Duration // extra time past EndedAt for in-flight submits to land
+	BatchSize   int           // attempts closed per sweep
+}
+
+// attemptTimeoutSweeper closes attempts whose client never called the timeout endpoint,
+// e.g. because the browser was closed. Only the replica holding the Redis lease sweeps.
+type attemptTimeoutSweeper struct {
+	repo     repositories.Repository
+	db       *gorm.DB
+	logger   *slog.Logger
+	grading  GradingService
+	notifier NotificationEventService
+	config   TimeoutSweeperConfig
+	job      *periodicJob
+}
+
+func newAttemptTimeoutSweeper(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, grading GradingService, cacheManager *cache.CacheManager, notifier NotificationEventService, config TimeoutSweeperConfig) *attemptTimeoutSweeper {
+	w := &attemptTimeoutSweeper{
+		repo:     repo,
+		db:       db,
+		logger:   logger,
+		grading:  grading,
+		notifier: notifier,
+		config:   config,
+	}
+	// The lease outlives a couple of missed ticks; overlapping sweeps are harmless since MarkTimedOut is conditional
+	lock := cacheManager.NewLeaderLock(timeoutSweeperLockKey, 3*config.Interval)
+	w.job = newPeriodicJob(timeoutSweeperLockKey, config.Interval, lock, logger, func(ctx context.Context) {
+		if _, err := w.Sweep(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("Failed to sweep timed out attempts", "error", err)
+		}
+	})
+	return w
+}
+
+// Start runs Sweep periodically until Shutdown is called
+func (w *attemptTimeoutSweeper) Start() {
+	w.job.Start()
+}
+
+// Sweep marks in-progress attempts past EndedAt plus the grace period as timed out and grades them.
+// It returns the number of attempts closed.
+func (w *attemptTimeoutSweeper) Sweep(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-w.config.GracePeriod)
+	attempts, err := w.repo.Attempt().GetOverdueAttempts(ctx, w.db, cutoff, w.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get overdue attempts: %w", err)
+	}
+
+	// Once an attempt is closed it is graded even if shutdown starts meanwhile
+	workCtx := context.WithoutCancel(ctx)
+
+	closed := 0
+	for _, attempt := range attempts {
+		if ctx.Err() != nil {
+			break
+		}
+
+		updated, err := w.repo.Attempt().MarkTimedOut(workCtx, w.db, attempt.ID, time.Now())
+		if err != nil {
+			w.logger.Error("Failed to time out attempt", "attempt_id", attempt.ID, "error", err)
+			continue
+		}
+		if !updated {
+			continue // Submitted or timed out by the client meanwhile
+		}
+		closed++
+
+		attemptID := attempt.ID
+		notifyAsync(w.notifier, w.logger, "attempt_submitted", func(ctx context.Context, notifier NotificationEventService) error {
+			return notifier.NotifyAttemptSubmitted(ctx, attemptID)
+		})
+
+		// Grade inline so a large backlog is worked through one attempt at a time
+		if _, err := w.grading.AutoGradeAttempt(workCtx, attemptID); err != nil {
+			w.logger.Error("Failed to auto-grade timed out attempt", "attempt_id", attemptID, "error", err)
+		}
+	}
+
+	if closed > 0 {
+		w.logger.Info("Timed out overdue attempts", "count", closed)
+	}
+
+	return closed, nil
+}
+
+// Shutdown stops the sweep loop, waits for a running sweep and gives up the lease
+func (w *attemptTimeoutSweeper) Shutdown(ctx context.Context) error {
+	return w.job.Shutdown(ctx)
+}
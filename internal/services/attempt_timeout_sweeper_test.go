@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// sweepRepository serves overdue attempts from memory; MarkTimedOut only applies while an attempt
+// is in progress, like the conditional update in Postgres
+type sweepRepository struct {
+	repositories.Repository
+	attempts *sweepAttemptRepository
+}
+
+func (r *sweepRepository) Attempt() repositories.AttemptRepository { return r.attempts }
+
+type sweepAttemptRepository struct {
+	repositories.AttemptRepository
+
+	mu     sync.Mutex
+	status map[uint]models.AttemptStatus
+}
+
+func newSweepRepository(status map[uint]models.AttemptStatus) *sweepRepository {
+	return &sweepRepository{attempts: &sweepAttemptRepository{status: status}}
+}
+
+// GetOverdueAttempts returns every attempt, as if read before any replica closed one
+func (r *sweepAttemptRepository) GetOverdueAttempts(ctx context.Context, tx *gorm.DB, cutoffTime time.Time, limit int) ([]*models.AssessmentAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := make([]*models.AssessmentAttempt, 0, len(r.status))
+	for id := range r.status {
+		attempts = append(attempts, &models.AssessmentAttempt{ID: id, Status: models.AttemptInProgress})
+	}
+	return attempts, nil
+}
+
+func (r *sweepAttemptRepository) MarkTimedOut(ctx context.Context, tx *gorm.DB, id uint, completedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status[id] != models.AttemptInProgress {
+		return false, nil
+	}
+	r.status[id] = models.AttemptTimeOut
+	return true, nil
+}
+
+// countingGrader records the attempts the sweeper grades
+type countingGrader struct {
+	GradingService
+
+	mu     sync.Mutex
+	graded map[uint]int
+}
+
+func (g *countingGrader) AutoGradeAttempt(ctx context.Context, attemptID uint) (*AttemptGradingResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.graded[attemptID]++
+	return nil, nil
+}
+
+func newTestSweeper(repo repositories.Repository, grader *countingGrader) *attemptTimeoutSweeper {
+	return &attemptTimeoutSweeper{
+		repo:    repo,
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		grading: grader,
+		config:  TimeoutSweeperConfig{Enabled: true, Interval: time.Minute, BatchSize: 100},
+	}
+}
+
+func TestAttemptTimeoutSweeper_Sweep(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     map[uint]models.AttemptStatus
+		wantClosed int
+	}{
+		{"closes overdue attempts", map[uint]models.AttemptStatus{1: models.AttemptInProgress, 2: models.AttemptInProgress}, 2},
+		{"skips attempts submitted meanwhile", map[uint]models.AttemptStatus{1: models.AttemptInProgress, 2: models.AttemptCompleted}, 1},
+		{"nothing overdue", map[uint]models.AttemptStatus{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grader := &countingGrader{graded: map[uint]int{}}
+			sweeper := newTestSweeper(newSweepRepository(tt.status), grader)
+
+			closed, err := sweeper.Sweep(context.Background())
+			if err != nil {
+				t.Fatalf("Sweep() error = %v", err)
+			}
+			if closed != tt.wantClosed {
+				t.Errorf("Sweep() = %d, want %d", closed, tt.wantClosed)
+			}
+			if len(grader.graded) != tt.wantClosed {
+				t.Errorf("attempts graded = %d, want %d", len(grader.graded), tt.wantClosed)
+			}
+		})
+	}
+}
+
+func TestAttemptTimeoutSweeper_OverlappingSweeps(t *testing.T) {
+	status := map[uint]models.AttemptStatus{}
+	for id := uint(1); id <= 5; id++ {
+		status[id] = models.AttemptInProgress
+	}
+	repo := newSweepRepository(status)
+
+	// Two replicas sweeping the same attempts at once, e.g. right after the lease changed hands
+	grader := &countingGrader{graded: map[uint]int{}}
+	replicas := []*attemptTimeoutSweeper{newTestSweeper(repo, grader), newTestSweeper(repo, grader)}
+	closed := make([]int, len(replicas))
+
+	var wg sync.WaitGroup
+	for i, sweeper := range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, err := sweeper.Sweep(context.Background())
+			if err != nil {
+				t.Errorf("Sweep() error = %v", err)
+			}
+			closed[i] = count
+		}()
+	}
+	wg.Wait()
+
+	if total := closed[0] + closed[1]; total != len(status) {
+		t.Errorf("attempts closed = %d, want %d (each closed once)", total, len(status))
+	}
+	for id, s := range repo.attempts.status {
+		if s != models.AttemptTimeOut {
+			t.Errorf("attempt %d status = %s, want %s", id, s, models.AttemptTimeOut)
+		}
+		if graded := grader.graded[id]; graded != 1 {
+			t.Errorf("attempt %d graded %d times, want 1", id, graded)
+		}
+	}
+}
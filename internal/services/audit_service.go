@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type auditService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewAuditService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AuditService {
+	return &auditService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// defaultComplianceLevel is used when the caller does not set a compliance level on the entry
+var defaultComplianceLevel = map[models.AuditEventType]string{
+	models.AuditAssessmentDeleted:   "high",
+	models.AuditAssessmentPublished: "high",
+	models.AuditQuestionDeleted:     "high",
+	models.AuditGradeUpdated:        "high",
+	models.AuditPermissionChanged:   "critical",
+	models.AuditDataExported:        "high",
+	models.AuditDataImported:        "medium",
+}
+
+// ===== RECORDING =====
+
+// Log persists an audit entry. Callers treat failures as non-fatal and only log them,
+// so auditing never blocks the audited operation.
+func (s *auditService) Log(ctx context.Context, entry *AuditEntry) error {
+	log := &models.AuditLog{
+		EventType:       entry.EventType,
+		UserID:          entry.UserID,
+		TargetType:      entry.TargetType,
+		TargetID:        entry.TargetID,
+		Description:     entry.Description,
+		ComplianceLevel: entry.ComplianceLevel,
+		CreatedAt:       time.Now(),
+	}
+
+	if log.ComplianceLevel == "" {
+		log.ComplianceLevel = defaultComplianceLevel[entry.EventType]
+		if log.ComplianceLevel == "" {
+			log.ComplianceLevel = "medium"
+		}
+	}
+
+	// Actor details are best effort: the user directory is external
+	if user, err := s.repo.User().GetByID(ctx, entry.UserID); err == nil {
+		log.UserEmail = user.Email
+		log.UserRole = user.Role
+	} else {
+		s.logger.Warn("Failed to resolve audit actor", "user_id", entry.UserID, "error", err)
+	}
+
+	if entry.Before != nil || entry.After != nil {
+		changes, err := json.Marshal(map[string]interface{}{
+			"before": entry.Before,
+			"after":  entry.After,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit changes: %w", err)
+		}
+		log.Changes = changes
+	}
+
+	if len(entry.Metadata) > 0 {
+		metadata, err := json.Marshal(entry.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit metadata: %w", err)
+		}
+		log.Metadata = metadata
+	}
+
+	if info, ok := utils.RequestInfoFromContext(ctx); ok {
+		log.IPAddress = info.IPAddress
+		log.UserAgent = info.UserAgent
+		if info.RequestID != "" {
+			log.RequestID = &info.RequestID
+		}
+	}
+
+	if err := s.repo.Audit().Create(ctx, nil, log); err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+// ===== QUERY =====
+
+func (s *auditService) List(ctx context.Context, filters repositories.AuditLogFilters, userID string) (*AuditLogListResponse, error) {
+	if err := s.checkAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	logs, total, err := s.repo.Audit().List(ctx, nil, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return &AuditLogListResponse{
+		Logs:  logs,
+		Total: total,
+		Page:  (filters.Offset / max(filters.Limit, 1)) + 1,
+		Size:  filters.Limit,
+	}, nil
+}
+
+func (s *auditService) GetByID(ctx context.Context, id uint, userID string) (*models.AuditLog, error) {
+	if err := s.checkAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	log, err := s.repo.Audit().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAuditLogNotFound
+		}
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	return log, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *auditService) checkAdmin(ctx context.Context, userID string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "audit_log", "view", "admin role required")
+	}
+	return nil
+}
+
+// recordAudit writes an audit entry and only logs failures, so auditing never fails the caller
+func recordAudit(ctx context.Context, audit AuditService, logger *slog.Logger, entry *AuditEntry) {
+	if err := audit.Log(ctx, entry); err != nil {
+		logger.Error("Failed to record audit log",
+			"event_type", entry.EventType,
+			"user_id", entry.UserID,
+			"error", err)
+	}
+}
+
+// assessmentAuditSnapshot captures the audited fields of an assessment, leaving out relations
+func assessmentAuditSnapshot(assessment *models.Assessment) map[string]interface{} {
+	return map[string]interface{}{
+		"title":          assessment.Title,
+		"description":    assessment.Description,
+		"duration":       assessment.Duration,
+		"status":         assessment.Status,
+		"passing_score":  assessment.PassingScore,
+		"max_attempts":   assessment.MaxAttempts,
+		"time_warning":   assessment.TimeWarning,
+		"due_date":       assessment.DueDate,
+		"available_from": assessment.AvailableFrom,
+		"version":        assessment.Version,
+	}
+}
+
+// questionAuditSnapshot captures the audited fields of a question, leaving out relations
+func questionAuditSnapshot(question *models.Question) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        question.Type,
+		"text":        question.Text,
+		"points":      question.Points,
+		"content":     question.Content,
+		"answer":      question.Answer,
+		"category_id": question.CategoryID,
+		"difficulty":  question.Difficulty,
+		"tags":        question.Tags,
+		"explanation": question.Explanation,
+	}
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type categoryService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewCategoryService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) CategoryService {
+	return &categoryService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (s *categoryService) Create(ctx context.Context, req *CreateCategoryRequest, userID string) (*models.QuestionCategory, error) {
+	s.logger.Info("Creating category", "user_id", userID, "name", req.Name)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleTeacher && role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "category", "create", "insufficient role permissions")
+	}
+
+	if req.ParentID != nil {
+		if _, err := s.getOwnedCategory(ctx, *req.ParentID, userID, "create"); err != nil {
+			return nil, err
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := s.checkNameAvailable(ctx, name, userID, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	category := &models.QuestionCategory{
+		Name:        name,
+		Description: req.Description,
+		Color:       req.Color,
+		Icon:        req.Icon,
+		ParentID:    req.ParentID,
+		CreatedBy:   userID,
+	}
+	if category.Color == "" {
+		category.Color = "#3B82F6"
+	}
+
+	if err := s.repo.QuestionCategory().Create(ctx, s.db, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	s.logger.Info("Category created", "category_id", category.ID, "path", category.Path)
+	return category, nil
+}
+
+func (s *categoryService) GetByID(ctx context.Context, id uint, userID string) (*models.QuestionCategory, error) {
+	if _, err := s.getOwnedCategory(ctx, id, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	category, err := s.repo.QuestionCategory().GetByIDWithChildren(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) Update(ctx context.Context, id uint, req *UpdateCategoryRequest, userID string) (*models.QuestionCategory, error) {
+	s.logger.Info("Updating category", "category_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	category, err := s.getOwnedCategory(ctx, id, userID, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if !strings.EqualFold(name, category.Name) {
+			if err := s.checkNameAvailable(ctx, name, category.CreatedBy, category.ParentID); err != nil {
+				return nil, err
+			}
+		}
+		category.Name = name
+	}
+	if req.Description != nil {
+		category.Description = req.Description
+	}
+	if req.Color != nil {
+		category.Color = *req.Color
+	}
+	if req.Icon != nil {
+		category.Icon = req.Icon
+	}
+
+	if err := s.repo.QuestionCategory().Update(ctx, s.db, category); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return category, nil
+}
+
+// Delete removes an empty leaf category. Questions of the category block the deletion
+// unless reassignTo names another category of the same owner to move them to.
+func (s *categoryService) Delete(ctx context.Context, id uint, reassignTo *uint, userID string) (*DeleteCategoryResponse, error) {
+	s.logger.Info("Deleting category", "category_id", id, "user_id", userID, "reassign_to", reassignTo)
+
+	category, err := s.getOwnedCategory(ctx, id, userID, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	hasChildren, err := s.repo.QuestionCategory().HasChildren(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check subcategories: %w", err)
+	}
+	if hasChildren {
+		return nil, ErrCategoryHasChildren
+	}
+
+	hasQuestions, err := s.repo.QuestionCategory().HasQuestions(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check category questions: %w", err)
+	}
+	if hasQuestions && reassignTo == nil {
+		return nil, ErrCategoryHasQuestions
+	}
+
+	if reassignTo != nil {
+		if *reassignTo == id {
+			return nil, NewValidationError("reassign_to", "cannot reassign questions to the deleted category", *reassignTo)
+		}
+		target, err := s.getOwnedCategory(ctx, *reassignTo, userID, "update")
+		if err != nil {
+			return nil, err
+		}
+		if target.CreatedBy != category.CreatedBy {
+			return nil, NewValidationError("reassign_to", "target category belongs to another user", *reassignTo)
+		}
+	}
+
+	response := &DeleteCategoryResponse{}
+	err = s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		if reassignTo != nil {
+			reassigned, err := txRepo.QuestionCategory().ReassignQuestions(ctx, nil, id, *reassignTo)
+			if err != nil {
+				return fmt.Errorf("failed to reassign questions: %w", err)
+			}
+			response.ReassignedQuestions = reassigned
+		}
+
+		if err := txRepo.QuestionCategory().Delete(ctx, nil, id); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Category deleted", "category_id", id, "reassigned_questions", response.ReassignedQuestions)
+	return response, nil
+}
+
+// ===== TREE OPERATIONS =====
+
+// GetTree returns the user's categories as a forest with direct and subtree question counts
+func (s *categoryService) GetTree(ctx context.Context, userID string) ([]*CategoryTreeNode, error) {
+	categories, err := s.repo.QuestionCategory().GetCategoriesWithCounts(ctx, s.db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	// Categories are ordered by level, so every parent is seen before its children
+	nodes := make(map[uint]*CategoryTreeNode, len(categories))
+	roots := make([]*CategoryTreeNode, 0)
+	for _, category := range categories {
+		node := &CategoryTreeNode{
+			ID:          category.ID,
+			Name:        category.Name,
+			Description: category.Description,
+			Color:       category.Color,
+			Icon:        category.Icon,
+			ParentID:    category.ParentID,
+			Level:       category.Level,
+			Path:        category.Path,
+			DirectCount: category.DirectCount,
+			TotalCount:  category.TotalCount,
+			Children:    []*CategoryTreeNode{},
+		}
+		nodes[category.ID] = node
+
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots, nil
+}
+
+func (s *categoryService) GetPath(ctx context.Context, id uint, userID string) ([]*models.QuestionCategory, error) {
+	if _, err := s.getOwnedCategory(ctx, id, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	path, err := s.repo.QuestionCategory().GetPath(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category path: %w", err)
+	}
+
+	return path, nil
+}
+
+func (s *categoryService) Move(ctx context.Context, id uint, req *MoveCategoryRequest, userID string) (*models.QuestionCategory, error) {
+	s.logger.Info("Moving category", "category_id", id, "user_id", userID, "parent_id", req.ParentID)
+
+	category, err := s.getOwnedCategory(ctx, id, userID, "move")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ParentID != nil {
+		parent, err := s.getOwnedCategory(ctx, *req.ParentID, userID, "move")
+		if err != nil {
+			return nil, err
+		}
+		if parent.CreatedBy != category.CreatedBy {
+			return nil, NewValidationError("parent_id", "parent category belongs to another user", *req.ParentID)
+		}
+	}
+
+	if !sameParent(category.ParentID, req.ParentID) {
+		if err := s.checkNameAvailable(ctx, category.Name, category.CreatedBy, req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		return txRepo.QuestionCategory().MoveCategory(ctx, nil, id, req.ParentID)
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrCategoryHierarchyCycle) {
+			return nil, ErrCategoryInvalidParent
+		}
+		return nil, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	moved, err := s.repo.QuestionCategory().GetByID(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	s.logger.Info("Category moved", "category_id", id, "path", moved.Path)
+	return moved, nil
+}
+
+// ===== STATISTICS =====
+
+func (s *categoryService) GetStats(ctx context.Context, id uint, userID string) (*repositories.CategoryStats, error) {
+	if _, err := s.getOwnedCategory(ctx, id, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.QuestionCategory().GetCategoryStats(ctx, s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// getOwnedCategory loads a category that the user owns; admins may access any category
+func (s *categoryService) getOwnedCategory(ctx context.Context, id uint, userID, action string) (*models.QuestionCategory, error) {
+	category, err := s.repo.QuestionCategory().GetByID(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	if category.CreatedBy == userID {
+		return category, nil
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, id, "category", action, "not owner or insufficient permissions")
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) checkNameAvailable(ctx context.Context, name, creatorID string, parentID *uint) error {
+	exists, err := s.repo.QuestionCategory().ExistsByName(ctx, s.db, name, creatorID, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to check category name: %w", err)
+	}
+	if exists {
+		return ErrCategoryDuplicateName
+	}
+	return nil
+}
+
+func (s *categoryService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// categoryRepository keeps one teacher's category tree in memory
+type categoryRepository struct {
+	repositories.Repository
+	categories *categoryTreeRepository
+}
+
+func (r *categoryRepository) QuestionCategory() repositories.QuestionCategoryRepository {
+	return r.categories
+}
+
+func (r *categoryRepository) WithTransaction(ctx context.Context, fn func(repositories.Repository) error) error {
+	return fn(r)
+}
+
+type categoryTreeRepository struct {
+	repositories.QuestionCategoryRepository
+
+	categories map[uint]*models.QuestionCategory
+	questions  map[uint]int64 // question count per category
+	deleted    []uint
+}
+
+// newCategoryTree builds Math (1) > Algebra (2) > Equations (3) and Physics (4), owned by "teacher"
+func newCategoryTree() *categoryTreeRepository {
+	parent := func(id uint) *uint { return &id }
+	return &categoryTreeRepository{
+		categories: map[uint]*models.QuestionCategory{
+			1: {ID: 1, Name: "Math", Path: "/1", CreatedBy: "teacher"},
+			2: {ID: 2, Name: "Algebra", ParentID: parent(1), Path: "/1/2", Level: 1, CreatedBy: "teacher"},
+			3: {ID: 3, Name: "Equations", ParentID: parent(2), Path: "/1/2/3", Level: 2, CreatedBy: "teacher"},
+			4: {ID: 4, Name: "Physics", Path: "/4", CreatedBy: "teacher"},
+		},
+		questions: map[uint]int64{},
+	}
+}
+
+func (r *categoryTreeRepository) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionCategory, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, fmt.Errorf("category %d: %w", id, gorm.ErrRecordNotFound)
+	}
+	copied := *category
+	return &copied, nil
+}
+
+func (r *categoryTreeRepository) ExistsByName(ctx context.Context, tx *gorm.DB, name string, creatorID string, parentID *uint) (bool, error) {
+	for _, category := range r.categories {
+		if category.Name == name && category.CreatedBy == creatorID && sameParent(category.ParentID, parentID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *categoryTreeRepository) HasChildren(ctx context.Context, tx *gorm.DB, id uint) (bool, error) {
+	for _, category := range r.categories {
+		if category.ParentID != nil && *category.ParentID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *categoryTreeRepository) HasQuestions(ctx context.Context, tx *gorm.DB, id uint) (bool, error) {
+	return r.questions[id] > 0, nil
+}
+
+func (r *categoryTreeRepository) ReassignQuestions(ctx context.Context, tx *gorm.DB, fromCategoryID, toCategoryID uint) (int64, error) {
+	moved := r.questions[fromCategoryID]
+	r.questions[toCategoryID] += moved
+	delete(r.questions, fromCategoryID)
+	return moved, nil
+}
+
+func (r *categoryTreeRepository) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	delete(r.categories, id)
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// MoveCategory rejects cycles by path like the Postgres repository and only moves the category itself
+func (r *categoryTreeRepository) MoveCategory(ctx context.Context, tx *gorm.DB, categoryID uint, newParentID *uint) error {
+	category := r.categories[categoryID]
+	if newParentID != nil {
+		parent := r.categories[*newParentID]
+		if parent.ID == categoryID || strings.HasPrefix(parent.Path, category.Path+"/") {
+			return repositories.ErrCategoryHierarchyCycle
+		}
+	}
+	category.ParentID = newParentID
+	return nil
+}
+
+func newTestCategoryService(categories *categoryTreeRepository) CategoryService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewCategoryService(&categoryRepository{categories: categories}, nil, logger, nil)
+}
+
+func TestCategoryService_Move(t *testing.T) {
+	parent := func(id uint) *uint { return &id }
+
+	tests := []struct {
+		name       string
+		categoryID uint
+		parentID   *uint
+		wantErr    error
+	}{
+		{"under another root", 2, parent(4), nil},
+		{"to the top level", 3, nil, nil},
+		{"under itself", 2, parent(2), ErrCategoryInvalidParent},
+		{"under its child", 1, parent(2), ErrCategoryInvalidParent},
+		{"under a deeper descendant", 1, parent(3), ErrCategoryInvalidParent},
+		{"missing parent", 2, parent(99), ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newCategoryTree()
+			service := newTestCategoryService(categories)
+
+			moved, err := service.Move(context.Background(), tt.categoryID, &MoveCategoryRequest{ParentID: tt.parentID}, "teacher")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Move() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !sameParent(moved.ParentID, tt.parentID) {
+				t.Errorf("ParentID = %v, want %v", moved.ParentID, tt.parentID)
+			}
+		})
+	}
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	target := func(id uint) *uint { return &id }
+
+	tests := []struct {
+		name           string
+		categoryID     uint
+		questions      int64
+		reassignTo     *uint
+		wantErr        bool
+		wantErrIs      error // nil for validation errors
+		wantReassigned int64
+	}{
+		{"empty category", 4, 0, nil, false, nil, 0},
+		{"questions block the delete", 4, 3, nil, true, ErrCategoryHasQuestions, 0},
+		{"questions are reassigned", 4, 3, target(1), false, nil, 3},
+		{"subcategories block the delete", 1, 0, target(4), true, ErrCategoryHasChildren, 0},
+		{"reassign to the deleted category", 4, 3, target(4), true, nil, 0},
+		{"reassign to a missing category", 4, 3, target(99), true, ErrCategoryNotFound, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := newCategoryTree()
+			categories.questions[tt.categoryID] = tt.questions
+			service := newTestCategoryService(categories)
+
+			response, err := service.Delete(context.Background(), tt.categoryID, tt.reassignTo, "teacher")
+			if tt.wantErr {
+				var validationErr *ValidationError
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) || tt.wantErrIs == nil && !errors.As(err, &validationErr) {
+					t.Fatalf("Delete() error = %v, want %v", err, tt.wantErrIs)
+				}
+				if len(categories.deleted) > 0 {
+					t.Errorf("category deleted despite error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			if response.ReassignedQuestions != tt.wantReassigned {
+				t.Errorf("ReassignedQuestions = %d, want %d", response.ReassignedQuestions, tt.wantReassigned)
+			}
+			if _, ok := categories.categories[tt.categoryID]; ok {
+				t.Error("category not deleted")
+			}
+			if tt.reassignTo != nil && categories.questions[*tt.reassignTo] != tt.questions {
+				t.Errorf("questions in target = %d, want %d", categories.questions[*tt.reassignTo], tt.questions)
+			}
+		})
+	}
+}
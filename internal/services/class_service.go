@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type classService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewClassService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) ClassService {
+	return &classService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== CLASS MANAGEMENT =====
+
+func (s *classService) Create(ctx context.Context, req *CreateClassRequest, userID string) (*models.Class, error) {
+	s.logger.Info("Creating class", "user_id", userID, "name", req.Name)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleTeacher && role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "class", "create", "insufficient role permissions")
+	}
+
+	studentIDs, rejected, err := s.filterStudents(ctx, req.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(rejected) > 0 {
+		return nil, NewValidationError("student_ids", "users are not students", rejected)
+	}
+
+	class := &models.Class{
+		Name:        strings.TrimSpace(req.Name),
+		Code:        req.Code,
+		Description: req.Description,
+		IsActive:    true,
+		CreatedBy:   userID,
+	}
+
+	err = s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		if err := txRepo.Class().Create(ctx, nil, class); err != nil {
+			return err
+		}
+		added, err := txRepo.Class().AddMembers(ctx, nil, class.ID, studentIDs, userID)
+		if err != nil {
+			return err
+		}
+		class.MemberCount = int(added)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create class: %w", err)
+	}
+
+	s.logger.Info("Class created", "class_id", class.ID, "members", class.MemberCount)
+	return class, nil
+}
+
+func (s *classService) GetByID(ctx context.Context, id uint, userID string) (*models.Class, error) {
+	if _, err := s.getOwnedClass(ctx, id, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	class, err := s.repo.Class().GetByIDWithMembers(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrClassNotFound
+		}
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+
+	return class, nil
+}
+
+func (s *classService) Update(ctx context.Context, id uint, req *UpdateClassRequest, userID string) (*models.Class, error) {
+	s.logger.Info("Updating class", "class_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	class, err := s.getOwnedClass(ctx, id, userID, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		class.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Code != nil {
+		class.Code = req.Code
+	}
+	if req.Description != nil {
+		class.Description = req.Description
+	}
+	if req.IsActive != nil {
+		class.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Class().Update(ctx, s.db, class); err != nil {
+		return nil, fmt.Errorf("failed to update class: %w", err)
+	}
+
+	return class, nil
+}
+
+// Delete removes the class, its roster and the assessment assignments made through it
+func (s *classService) Delete(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting class", "class_id", id, "user_id", userID)
+
+	if _, err := s.getOwnedClass(ctx, id, userID, "delete"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Class().Delete(ctx, s.db, id); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrClassNotFound
+		}
+		return fmt.Errorf("failed to delete class: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the teacher's own classes, every class for admins, and the active classes a student belongs to
+func (s *classService) List(ctx context.Context, filters repositories.ClassFilters, userID string) (*ClassListResponse, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch role {
+	case models.RoleAdmin:
+	case models.RoleTeacher:
+		filters.CreatedBy = &userID
+	case models.RoleStudent:
+		active := true
+		filters.StudentID = &userID
+		filters.IsActive = &active
+	default:
+		return &ClassListResponse{
+			Classes: []*models.Class{},
+			Page:    1,
+			Size:    filters.Limit,
+		}, nil
+	}
+
+	classes, total, err := s.repo.Class().List(ctx, s.db, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	return &ClassListResponse{
+		Classes: classes,
+		Total:   total,
+		Page:    (filters.Offset / max(filters.Limit, 1)) + 1,
+		Size:    filters.Limit,
+	}, nil
+}
+
+// ===== ROSTER =====
+
+// AddMembers enrolls the listed students; IDs that do not belong to students are skipped and reported
+func (s *classService) AddMembers(ctx context.Context, id uint, req *ClassMembersRequest, userID string) (*AddClassMembersResponse, error) {
+	s.logger.Info("Adding class members", "class_id", id, "user_id", userID, "count", len(req.StudentIDs))
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := s.getOwnedClass(ctx, id, userID, "update"); err != nil {
+		return nil, err
+	}
+
+	studentIDs, rejected, err := s.filterStudents(ctx, req.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := s.repo.Class().AddMembers(ctx, s.db, id, studentIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add class members: %w", err)
+	}
+
+	return &AddClassMembersResponse{
+		Added:    added,
+		Rejected: rejected,
+	}, nil
+}
+
+func (s *classService) RemoveMember(ctx context.Context, id uint, studentID string, userID string) error {
+	s.logger.Info("Removing class member", "class_id", id, "student_id", studentID, "user_id", userID)
+
+	if _, err := s.getOwnedClass(ctx, id, userID, "update"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Class().RemoveMember(ctx, s.db, id, studentID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrClassMemberNotFound
+		}
+		return fmt.Errorf("failed to remove class member: %w", err)
+	}
+
+	return nil
+}
+
+// ===== ASSESSMENT ASSIGNMENT =====
+
+// AssignAssessment makes the assessment available to the given classes and students. Classes must
+// belong to the caller (any class for admins); assigning a class or student twice is a no-op.
+func (s *classService) AssignAssessment(ctx context.Context, assessmentID uint, req *AssignAssessmentRequest, userID string) ([]*models.AssessmentAssignment, error) {
+	s.logger.Info("Assigning assessment", "assessment_id", assessmentID, "user_id", userID,
+		"classes", len(req.ClassIDs), "students", len(req.StudentIDs))
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if len(req.ClassIDs) == 0 && len(req.StudentIDs) == 0 {
+		return nil, NewValidationError("class_ids", "at least one class or student is required", nil)
+	}
+
+	if err := s.checkAssessmentOwner(ctx, assessmentID, userID, "assign"); err != nil {
+		return nil, err
+	}
+
+	for _, classID := range req.ClassIDs {
+		if _, err := s.getOwnedClass(ctx, classID, userID, "assign"); err != nil {
+			return nil, err
+		}
+	}
+
+	studentIDs, rejected, err := s.filterStudents(ctx, req.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(rejected) > 0 {
+		return nil, NewValidationError("student_ids", "users are not students", rejected)
+	}
+
+	assignments := make([]*models.AssessmentAssignment, 0, len(req.ClassIDs)+len(studentIDs))
+	err = s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		for _, classID := range req.ClassIDs {
+			assignment := &models.AssessmentAssignment{
+				AssessmentID: assessmentID,
+				ClassID:      &classID,
+				AssignedBy:   userID,
+			}
+			if err := txRepo.Class().Assign(ctx, nil, assignment); err != nil {
+				return err
+			}
+			assignments = append(assignments, assignment)
+		}
+		for _, studentID := range studentIDs {
+			assignment := &models.AssessmentAssignment{
+				AssessmentID: assessmentID,
+				StudentID:    &studentID,
+				AssignedBy:   userID,
+			}
+			if err := txRepo.Class().Assign(ctx, nil, assignment); err != nil {
+				return err
+			}
+			assignments = append(assignments, assignment)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign assessment: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (s *classService) GetAssessmentAssignments(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentAssignment, error) {
+	if err := s.checkAssessmentOwner(ctx, assessmentID, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.repo.Class().GetAssignments(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (s *classService) UnassignAssessment(ctx context.Context, assessmentID, assignmentID uint, userID string) error {
+	s.logger.Info("Removing assessment assignment", "assessment_id", assessmentID, "assignment_id", assignmentID, "user_id", userID)
+
+	if err := s.checkAssessmentOwner(ctx, assessmentID, userID, "assign"); err != nil {
+		return err
+	}
+
+	assignment, err := s.repo.Class().GetAssignment(ctx, s.db, assignmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssignmentNotFound
+		}
+		return fmt.Errorf("failed to get assessment assignment: %w", err)
+	}
+	if assignment.AssessmentID != assessmentID {
+		return ErrAssignmentNotFound
+	}
+
+	if err := s.repo.Class().DeleteAssignment(ctx, s.db, assignmentID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssignmentNotFound
+		}
+		return fmt.Errorf("failed to delete assessment assignment: %w", err)
+	}
+
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *classService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+func (s *classService) getOwnedClass(ctx context.Context, id uint, userID, action string) (*models.Class, error) {
+	class, err := s.repo.Class().GetByID(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrClassNotFound
+		}
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+
+	if class.CreatedBy == userID {
+		return class, nil
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, id, "class", action, "not owner or insufficient permissions")
+	}
+
+	return class, nil
+}
+
+func (s *classService) checkAssessmentOwner(ctx context.Context, assessmentID uint, userID, action string) error {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssessmentNotFound
+		}
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	if assessment.CreatedBy == userID {
+		return nil
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.RoleAdmin {
+		return NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+
+	return nil
+}
+
+// filterStudents de-duplicates the IDs and splits them into known students and rejected IDs
+func (s *classService) filterStudents(ctx context.Context, ids []string) ([]string, []string, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil, nil
+	}
+
+	users, err := s.repo.User().GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	isStudent := make(map[string]bool, len(users))
+	for _, user := range users {
+		if user.Role == models.RoleStudent {
+			isStudent[user.ID] = true
+		}
+	}
+
+	var students, rejected []string
+	for _, id := range unique {
+		if isStudent[id] {
+			students = append(students, id)
+		} else {
+			rejected = append(rejected, id)
+		}
+	}
+	return students, rejected, nil
+}
@@ -347,6 +347,8 @@ func getQuestionTypeName(questionType string) string {
 		"matching":        "Ghép đôi",
 		"ordering":        "Sắp xếp",
 		"short_answer":    "Trả lời ngắn",
+		"numeric":         "Tính toán",
+		"formula":         "Công thức",
 	}
 
 	if name, ok := typeNames[questionType]; ok {
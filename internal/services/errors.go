@@ -20,14 +20,15 @@ var (
 	ErrConflict         = errors.New("resource conflict")
 
 	// Assessment specific errors
-	ErrAssessmentNotFound       = errors.New("assessment not found")
-	ErrAssessmentAccessDenied   = errors.New("access denied to assessment")
-	ErrAssessmentNotEditable    = errors.New("assessment cannot be edited in current status")
-	ErrAssessmentNotDeletable   = errors.New("assessment cannot be deleted - has existing attempts")
-	ErrAssessmentInvalidStatus  = errors.New("invalid assessment status transition")
-	ErrAssessmentDuplicateTitle = errors.New("assessment title already exists for this user")
-	ErrAssessmentExpired        = errors.New("assessment has expired")
-	ErrAssessmentNotPublished   = errors.New("assessment is not published")
+	ErrAssessmentNotFound        = errors.New("assessment not found")
+	ErrAssessmentAccessDenied    = errors.New("access denied to assessment")
+	ErrAssessmentNotEditable     = errors.New("assessment cannot be edited in current status")
+	ErrAssessmentNotDeletable    = errors.New("assessment cannot be deleted - has existing attempts")
+	ErrAssessmentInvalidStatus   = errors.New("invalid assessment status transition")
+	ErrAssessmentDuplicateTitle  = errors.New("assessment title already exists for this user")
+	ErrAssessmentExpired         = errors.New("assessment has expired")
+	ErrAssessmentNotPublished    = errors.New("assessment is not published")
+	ErrAssessmentVersionNotFound = errors.New("assessment version not found")
 
 	// Question specific errors
 	ErrQuestionNotFound       = errors.New("question not found")
@@ -36,6 +37,7 @@ var (
 	ErrQuestionInvalidContent = errors.New("invalid question content for type")
 	ErrQuestionNotDeletable   = errors.New("question cannot be deleted - in use by assessments")
 	ErrQuestionDuplicateOrder = errors.New("question order already exists in assessment")
+	ErrQuestionNotTemplated   = errors.New("question is not templated")
 
 	// Question Bank specific errors
 	ErrQuestionBankNotFound      = errors.New("question bank not found")
@@ -45,6 +47,19 @@ var (
 	ErrQuestionBankShareExists   = errors.New("question bank already shared with this user")
 	ErrQuestionBankNotShared     = errors.New("question bank is not shared with this user")
 
+	// Category specific errors
+	ErrCategoryNotFound      = errors.New("category not found")
+	ErrCategoryDuplicateName = errors.New("category name already exists at this level")
+	ErrCategoryHasQuestions  = errors.New("category has questions - reassign them to another category first")
+	ErrCategoryHasChildren   = errors.New("category has subcategories - move or delete them first")
+	ErrCategoryInvalidParent = errors.New("category cannot be moved under itself or its descendants")
+
+	// Attachment specific errors
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentTooLarge       = errors.New("attachment exceeds the maximum file size")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment file type not allowed")
+	ErrAttachmentLimitReached   = errors.New("question has reached the maximum number of attachments")
+
 	// Attempt specific errors
 	ErrAttemptNotFound         = errors.New("attempt not found")
 	ErrAttemptAccessDenied     = errors.New("access denied to attempt")
@@ -54,12 +69,33 @@ var (
 	ErrAttemptTimeExpired      = errors.New("attempt time has expired")
 	ErrAttemptNotStarted       = errors.New("attempt not started")
 	ErrAttemptCannotStart      = errors.New("cannot start new attempt")
+	ErrAnswerNotFound          = errors.New("answer not found")
 
 	// Grading specific errors
 	ErrGradingNotAllowed       = errors.New("grading not allowed for this question type")
 	ErrGradingAlreadyCompleted = errors.New("answer already graded")
 	ErrGradingInvalidScore     = errors.New("invalid score value")
 	ErrGradingPermissionDenied = errors.New("permission denied for grading")
+	ErrGradingSchemeNotFound   = errors.New("grading scheme not found")
+
+	// Proctoring specific errors
+	ErrProctoringEventNotFound = errors.New("proctoring event not found")
+
+	// Audit specific errors
+	ErrAuditLogNotFound = errors.New("audit log not found")
+
+	// Import specific errors
+	ErrImportJobNotFound = errors.New("import job not found")
+	ErrImportJobFinished = errors.New("import job already finished")
+
+	// Notification specific errors
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	// Class and assignment specific errors
+	ErrClassNotFound         = errors.New("class not found")
+	ErrClassMemberNotFound   = errors.New("student is not a member of this class")
+	ErrAssignmentNotFound    = errors.New("assessment assignment not found")
+	ErrAssessmentNotAssigned = errors.New("assessment is not assigned to this student")
 
 	// User/Permission errors
 	ErrUserNotFound            = errors.New("user not found")
@@ -125,8 +161,20 @@ func NewPermissionError(userID string, resourceID uint, resource, action, reason
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound) ||
 		errors.Is(err, ErrAssessmentNotFound) ||
+		errors.Is(err, ErrAssessmentVersionNotFound) ||
 		errors.Is(err, ErrQuestionNotFound) ||
 		errors.Is(err, ErrAttemptNotFound) ||
+		errors.Is(err, ErrAnswerNotFound) ||
+		errors.Is(err, ErrProctoringEventNotFound) ||
+		errors.Is(err, ErrGradingSchemeNotFound) ||
+		errors.Is(err, ErrAuditLogNotFound) ||
+		errors.Is(err, ErrImportJobNotFound) ||
+		errors.Is(err, ErrCategoryNotFound) ||
+		errors.Is(err, ErrAttachmentNotFound) ||
+		errors.Is(err, ErrNotificationNotFound) ||
+		errors.Is(err, ErrClassNotFound) ||
+		errors.Is(err, ErrClassMemberNotFound) ||
+		errors.Is(err, ErrAssignmentNotFound) ||
 		errors.Is(err, ErrUserNotFound)
 }
 
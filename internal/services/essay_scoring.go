@@ -0,0 +1,191 @@
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// defaultEssayLengthPenalty is the share of the score lost by an essay far outside MinWords/MaxWords
+const defaultEssayLengthPenalty = 0.25
+
+// essayScore is the result of keyword scoring an essay; scores are always provisional
+type essayScore struct {
+	Score      float64 // 0-1 after the length penalty
+	Coverage   float64 // Share of key words found
+	Penalty    float64 // Deducted for being too short or too long
+	Confidence float64 // 0-1, how much the keyword score can be trusted
+	WordCount  int
+	Matched    []string
+	Missing    []string
+}
+
+// essayAutoGradeEnabled reports whether essay content opts into keyword scoring
+func essayAutoGradeEnabled(questionContent json.RawMessage) bool {
+	var content models.EssayContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return false
+	}
+	return content.AutoGrade && len(content.KeyWords) > 0
+}
+
+// essayText extracts the text of an essay answer stored either as a plain string or as models.EssayAnswer
+func essayText(studentAnswer json.RawMessage) (string, error) {
+	var text string
+	if err := json.Unmarshal(studentAnswer, &text); err == nil {
+		return text, nil
+	}
+
+	var answer models.EssayAnswer
+	if err := json.Unmarshal(studentAnswer, &answer); err != nil {
+		return "", err
+	}
+	return answer.Text, nil
+}
+
+// scoreEssay scores an essay by key word and phrase coverage. Words are compared by stem, so
+// "evaporates" covers "evaporation", and a key word also counts when one of its synonyms is used.
+func scoreEssay(content models.EssayContent, text string) essayScore {
+	words := essayWords(text)
+	stems := make([]string, len(words))
+	for i, word := range words {
+		stems[i] = stemWord(word)
+	}
+
+	result := essayScore{
+		WordCount: len(words),
+		Matched:   []string{},
+		Missing:   []string{},
+	}
+
+	for _, keyword := range content.KeyWords {
+		candidates := append([]string{keyword}, content.Synonyms[keyword]...)
+		found := false
+		for _, candidate := range candidates {
+			if containsPhrase(stems, stemPhrase(candidate)) {
+				found = true
+				break
+			}
+		}
+		if found {
+			result.Matched = append(result.Matched, keyword)
+		} else {
+			result.Missing = append(result.Missing, keyword)
+		}
+	}
+
+	if len(content.KeyWords) > 0 {
+		result.Coverage = float64(len(result.Matched)) / float64(len(content.KeyWords))
+	}
+
+	result.Penalty = essayLengthPenalty(content, len(words))
+	result.Score = math.Max(0, result.Coverage-result.Penalty)
+	result.Confidence = essayConfidence(result, len(content.KeyWords))
+
+	return result
+}
+
+// essayLengthPenalty scales the configured penalty by how far the word count is outside the limits
+func essayLengthPenalty(content models.EssayContent, wordCount int) float64 {
+	maxPenalty := defaultEssayLengthPenalty
+	if content.LengthPenalty != nil {
+		maxPenalty = math.Min(math.Max(*content.LengthPenalty, 0), 1)
+	}
+
+	var shortfall float64
+	switch {
+	case content.MinWords != nil && *content.MinWords > 0 && wordCount < *content.MinWords:
+		shortfall = float64(*content.MinWords-wordCount) / float64(*content.MinWords)
+	case content.MaxWords != nil && *content.MaxWords > 0 && wordCount > *content.MaxWords:
+		shortfall = math.Min(float64(wordCount-*content.MaxWords)/float64(*content.MaxWords), 1)
+	}
+
+	return maxPenalty * shortfall
+}
+
+// essayConfidence is high for clear-cut coverage on questions with enough key words, and drops for
+// borderline coverage, few key words and very short answers
+func essayConfidence(score essayScore, keywordCount int) float64 {
+	decisiveness := math.Abs(score.Coverage-0.5) * 2
+	keywordWeight := math.Min(float64(keywordCount)/5, 1)
+
+	confidence := 0.3 + 0.4*decisiveness + 0.3*keywordWeight
+	if score.WordCount < 2*keywordCount {
+		confidence *= 0.5
+	}
+
+	return math.Round(confidence*100) / 100
+}
+
+func essayWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func stemPhrase(phrase string) []string {
+	words := essayWords(phrase)
+	for i, word := range words {
+		words[i] = stemWord(word)
+	}
+	return words
+}
+
+func containsPhrase(stems, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(stems); i++ {
+		match := true
+		for j := range phrase {
+			if !stemsMatch(stems[i+j], phrase[j]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// stemsMatch also accepts stems that differ only by a short tail the stemmer left behind,
+// e.g. "evaporat" (evaporates) and "evapor" (evaporation)
+func stemsMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= 4 && len(b)-len(a) <= 2 && strings.HasPrefix(b, a)
+}
+
+// essaySuffixes are stripped longest first; a stem keeps at least three letters
+var essaySuffixes = []string{
+	"ational", "ization", "fulness", "ousness", "iveness",
+	"ation", "ition", "ement", "ments", "ness", "ment", "able", "ible", "ally", "ings",
+	"ing", "ies", "ied", "ion", "ers", "est", "ful", "ous", "ive", "ize", "ise",
+	"ed", "er", "ly", "es", "al", "s", "e", "y",
+}
+
+// stemWord is a light suffix-stripping stemmer, enough to match inflections of English key words
+func stemWord(word string) string {
+	for _, suffix := range essaySuffixes {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 3 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+
+	// Collapse doubled final consonants left behind, e.g. "stopp" from "stopped"
+	if n := len(word); n > 3 && word[n-1] == word[n-2] && !strings.ContainsRune("aeiou", rune(word[n-1])) {
+		word = word[:n-1]
+	}
+
+	return word
+}
@@ -0,0 +1,496 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type gradingSchemeService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewGradingSchemeService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) GradingSchemeService {
+	return &gradingSchemeService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// defaultGradeRanges is the letter scale used for assessments without an assigned grading scheme
+var defaultGradeRanges = []models.GradeRange{
+	{MinScore: 97, MaxScore: 100, Grade: "A+"},
+	{MinScore: 93, MaxScore: 97, Grade: "A"},
+	{MinScore: 90, MaxScore: 93, Grade: "A-"},
+	{MinScore: 87, MaxScore: 90, Grade: "B+"},
+	{MinScore: 83, MaxScore: 87, Grade: "B"},
+	{MinScore: 80, MaxScore: 83, Grade: "B-"},
+	{MinScore: 77, MaxScore: 80, Grade: "C+"},
+	{MinScore: 73, MaxScore: 77, Grade: "C"},
+	{MinScore: 70, MaxScore: 73, Grade: "C-"},
+	{MinScore: 67, MaxScore: 70, Grade: "D+"},
+	{MinScore: 63, MaxScore: 67, Grade: "D"},
+	{MinScore: 60, MaxScore: 63, Grade: "D-"},
+	{MinScore: 0, MaxScore: 60, Grade: "F"},
+}
+
+const defaultGradingRoundTo = 2
+
+// ===== TEMPLATES =====
+
+func (s *gradingSchemeService) CreateTemplate(ctx context.Context, req *GradingSchemeRequest, userID string) (*models.GradingScheme, error) {
+	s.logger.Info("Creating grading scheme template", "user_id", userID, "name", req.Name)
+
+	if err := s.validateSchemeRequest(req); err != nil {
+		return nil, err
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleTeacher && role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "grading_scheme", "create", "insufficient role permissions")
+	}
+
+	scheme, err := buildGradingScheme(req)
+	if err != nil {
+		return nil, err
+	}
+	scheme.IsTemplate = true
+	scheme.CreatedBy = userID
+
+	if err := s.repo.GradingScheme().Create(ctx, s.db, scheme); err != nil {
+		return nil, fmt.Errorf("failed to create grading scheme: %w", err)
+	}
+
+	s.logger.Info("Grading scheme template created", "scheme_id", scheme.ID)
+	return scheme, nil
+}
+
+func (s *gradingSchemeService) GetTemplate(ctx context.Context, id uint, userID string) (*models.GradingScheme, error) {
+	return s.getTemplate(ctx, id)
+}
+
+func (s *gradingSchemeService) ListTemplates(ctx context.Context, userID string) ([]*models.GradingScheme, error) {
+	schemes, err := s.repo.GradingScheme().ListTemplates(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grading scheme templates: %w", err)
+	}
+	return schemes, nil
+}
+
+func (s *gradingSchemeService) UpdateTemplate(ctx context.Context, id uint, req *GradingSchemeRequest, userID string) (*models.GradingScheme, error) {
+	s.logger.Info("Updating grading scheme template", "scheme_id", id, "user_id", userID)
+
+	if err := s.validateSchemeRequest(req); err != nil {
+		return nil, err
+	}
+
+	scheme, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTemplateOwnership(ctx, scheme, userID, "update"); err != nil {
+		return nil, err
+	}
+
+	if err := applyGradingSchemeRequest(scheme, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.GradingScheme().Update(ctx, s.db, scheme); err != nil {
+		return nil, fmt.Errorf("failed to update grading scheme: %w", err)
+	}
+
+	return scheme, nil
+}
+
+func (s *gradingSchemeService) DeleteTemplate(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting grading scheme template", "scheme_id", id, "user_id", userID)
+
+	scheme, err := s.getTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkTemplateOwnership(ctx, scheme, userID, "delete"); err != nil {
+		return err
+	}
+
+	// Assessments hold their own copy, so deleting a template never changes existing grades
+	if err := s.repo.GradingScheme().Delete(ctx, s.db, id); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrGradingSchemeNotFound
+		}
+		return fmt.Errorf("failed to delete grading scheme: %w", err)
+	}
+
+	return nil
+}
+
+// ===== ASSESSMENT SCHEMES =====
+
+func (s *gradingSchemeService) GetAssessmentScheme(ctx context.Context, assessmentID uint, userID string) (*models.GradingScheme, error) {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_grading_scheme", "not owner or insufficient permissions")
+	}
+
+	scheme, err := s.repo.GradingScheme().GetByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrGradingSchemeNotFound
+		}
+		return nil, fmt.Errorf("failed to get grading scheme: %w", err)
+	}
+
+	return scheme, nil
+}
+
+func (s *gradingSchemeService) AssignToAssessment(ctx context.Context, assessmentID uint, req *AssignGradingSchemeRequest, userID string) (*models.GradingScheme, error) {
+	s.logger.Info("Assigning grading scheme to assessment", "assessment_id", assessmentID, "user_id", userID)
+
+	if (req.TemplateID == nil) == (req.Scheme == nil) {
+		return nil, NewValidationError("template_id", "exactly one of template_id or scheme must be provided", req.TemplateID)
+	}
+
+	if err := s.checkAssessmentEdit(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	schemeReq := req.Scheme
+	if req.TemplateID != nil {
+		template, err := s.getTemplate(ctx, *req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		schemeReq, err = gradingSchemeToRequest(template)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := s.validateSchemeRequest(schemeReq); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GradingScheme().GetByAssessment(ctx, s.db, assessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get grading scheme: %w", err)
+	}
+
+	// Each assessment has at most one scheme: replace it in place if present
+	if existing != nil {
+		if err := applyGradingSchemeRequest(existing, schemeReq); err != nil {
+			return nil, err
+		}
+		if err := s.repo.GradingScheme().Update(ctx, s.db, existing); err != nil {
+			return nil, fmt.Errorf("failed to update grading scheme: %w", err)
+		}
+		return existing, nil
+	}
+
+	scheme, err := buildGradingScheme(schemeReq)
+	if err != nil {
+		return nil, err
+	}
+	scheme.AssessmentID = &assessmentID
+	scheme.CreatedBy = userID
+
+	if err := s.repo.GradingScheme().Create(ctx, s.db, scheme); err != nil {
+		return nil, fmt.Errorf("failed to create grading scheme: %w", err)
+	}
+
+	s.logger.Info("Grading scheme assigned", "assessment_id", assessmentID, "scheme_id", scheme.ID)
+	return scheme, nil
+}
+
+func (s *gradingSchemeService) RemoveFromAssessment(ctx context.Context, assessmentID uint, userID string) error {
+	s.logger.Info("Removing grading scheme from assessment", "assessment_id", assessmentID, "user_id", userID)
+
+	if err := s.checkAssessmentEdit(ctx, assessmentID, userID); err != nil {
+		return err
+	}
+
+	scheme, err := s.repo.GradingScheme().GetByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrGradingSchemeNotFound
+		}
+		return fmt.Errorf("failed to get grading scheme: %w", err)
+	}
+
+	if err := s.repo.GradingScheme().Delete(ctx, s.db, scheme.ID); err != nil {
+		return fmt.Errorf("failed to delete grading scheme: %w", err)
+	}
+
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *gradingSchemeService) getTemplate(ctx context.Context, id uint) (*models.GradingScheme, error) {
+	scheme, err := s.repo.GradingScheme().GetByID(ctx, s.db, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrGradingSchemeNotFound
+		}
+		return nil, fmt.Errorf("failed to get grading scheme: %w", err)
+	}
+	if !scheme.IsTemplate {
+		return nil, ErrGradingSchemeNotFound
+	}
+	return scheme, nil
+}
+
+func (s *gradingSchemeService) checkTemplateOwnership(ctx context.Context, scheme *models.GradingScheme, userID, action string) error {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.RoleAdmin && scheme.CreatedBy != userID {
+		return NewPermissionError(userID, scheme.ID, "grading_scheme", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *gradingSchemeService) checkAssessmentEdit(ctx context.Context, assessmentID uint, userID string) error {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
+	canEdit, err := assessmentService.CanEdit(ctx, assessmentID, userID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrAssessmentNotFound
+		}
+		return err
+	}
+	if !canEdit {
+		return NewPermissionError(userID, assessmentID, "assessment", "update_grading_scheme", "not owner or assessment not editable")
+	}
+	return nil
+}
+
+func (s *gradingSchemeService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+func (s *gradingSchemeService) validateSchemeRequest(req *GradingSchemeRequest) error {
+	if err := s.validator.Validate(req); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	switch req.Type {
+	case models.GradingPoints:
+		if req.MaxScore <= 0 {
+			return NewValidationError("max_score", "points schemes require a positive max_score", req.MaxScore)
+		}
+	case models.GradingLetter, models.GradingCustom:
+		if err := validateGradeRanges(req.GradeRanges); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateGradeRanges requires non-empty, non-overlapping percentage ranges within 0-100
+func validateGradeRanges(ranges []models.GradeRange) error {
+	if len(ranges) == 0 {
+		return NewValidationError("grade_ranges", "letter and custom schemes require grade ranges", nil)
+	}
+
+	sorted := make([]models.GradeRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinScore < sorted[j].MinScore })
+
+	for i, r := range sorted {
+		if r.Grade == "" {
+			return NewValidationError("grade_ranges", "grade is required for every range", r)
+		}
+		if r.MinScore < 0 || r.MaxScore > 100 || r.MinScore > r.MaxScore {
+			return NewValidationError("grade_ranges", "range must satisfy 0 <= min_score <= max_score <= 100", r)
+		}
+		if i > 0 && r.MinScore < sorted[i-1].MaxScore {
+			return NewValidationError("grade_ranges", "grade ranges must not overlap", r)
+		}
+	}
+
+	return nil
+}
+
+func buildGradingScheme(req *GradingSchemeRequest) (*models.GradingScheme, error) {
+	scheme := &models.GradingScheme{}
+	if err := applyGradingSchemeRequest(scheme, req); err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
+
+func applyGradingSchemeRequest(scheme *models.GradingScheme, req *GradingSchemeRequest) error {
+	ranges, err := json.Marshal(req.GradeRanges)
+	if err != nil {
+		return fmt.Errorf("failed to marshal grade ranges: %w", err)
+	}
+
+	scheme.Name = req.Name
+	scheme.Type = req.Type
+	scheme.PassingScore = req.PassingScore
+	scheme.MaxScore = req.MaxScore
+	scheme.GradeRanges = ranges
+	scheme.RoundTo = defaultGradingRoundTo
+	if req.RoundTo != nil {
+		scheme.RoundTo = *req.RoundTo
+	}
+	scheme.BonusPoints = req.BonusPoints
+	scheme.CurvePoints = req.CurvePoints
+	return nil
+}
+
+func gradingSchemeToRequest(scheme *models.GradingScheme) (*GradingSchemeRequest, error) {
+	ranges, err := decodeGradeRanges(scheme)
+	if err != nil {
+		return nil, err
+	}
+	roundTo := scheme.RoundTo
+	return &GradingSchemeRequest{
+		Name:         scheme.Name,
+		Type:         scheme.Type,
+		PassingScore: scheme.PassingScore,
+		MaxScore:     scheme.MaxScore,
+		GradeRanges:  ranges,
+		RoundTo:      &roundTo,
+		BonusPoints:  scheme.BonusPoints,
+		CurvePoints:  scheme.CurvePoints,
+	}, nil
+}
+
+func decodeGradeRanges(scheme *models.GradingScheme) ([]models.GradeRange, error) {
+	var ranges []models.GradeRange
+	if len(scheme.GradeRanges) == 0 {
+		return ranges, nil
+	}
+	if err := json.Unmarshal(scheme.GradeRanges, &ranges); err != nil {
+		return nil, fmt.Errorf("failed to decode grade ranges: %w", err)
+	}
+	return ranges, nil
+}
+
+// ===== GRADE CALCULATION =====
+
+// gradeOutcome is the final attempt result after applying a grading scheme to the raw score
+type gradeOutcome struct {
+	Score      float64
+	Percentage float64
+	Passed     bool
+	Grade      *string
+}
+
+// defaultGradingScheme is applied to assessments that have no scheme assigned
+func defaultGradingScheme() *models.GradingScheme {
+	ranges, _ := json.Marshal(defaultGradeRanges)
+	return &models.GradingScheme{
+		Name:        "Default letter scale",
+		Type:        models.GradingLetter,
+		GradeRanges: ranges,
+		RoundTo:     defaultGradingRoundTo,
+	}
+}
+
+// calculateGrade applies bonus points, curve, rounding and the grade mapping of a scheme.
+// Bonus points are added to the raw score and the curve to the percentage, both capped at the maximum.
+func calculateGrade(scheme *models.GradingScheme, rawScore, maxScore float64, assessmentPassingScore int) (*gradeOutcome, error) {
+	score := rawScore + scheme.BonusPoints
+	if maxScore > 0 && score > maxScore {
+		score = maxScore
+	}
+
+	percentage := 0.0
+	if maxScore > 0 {
+		percentage = score / maxScore * 100
+	}
+
+	if scheme.CurvePoints > 0 && maxScore > 0 {
+		percentage = math.Min(percentage+scheme.CurvePoints, 100)
+		score = percentage * maxScore / 100
+	}
+
+	score = roundTo(score, scheme.RoundTo)
+	percentage = roundTo(percentage, scheme.RoundTo)
+
+	passingScore := scheme.PassingScore
+	if passingScore <= 0 {
+		passingScore = float64(assessmentPassingScore)
+	}
+
+	outcome := &gradeOutcome{
+		Score:      score,
+		Percentage: percentage,
+		Passed:     percentage >= passingScore,
+	}
+
+	var grade string
+	switch scheme.Type {
+	case models.GradingPoints:
+		scale := float64(scheme.MaxScore)
+		if scale <= 0 {
+			scale = maxScore
+		}
+		grade = strconv.FormatFloat(roundTo(percentage/100*scale, scheme.RoundTo), 'f', -1, 64)
+	case models.GradingPercentage:
+		grade = strconv.FormatFloat(percentage, 'f', -1, 64) + "%"
+	default:
+		ranges, err := decodeGradeRanges(scheme)
+		if err != nil {
+			return nil, err
+		}
+		grade = matchGradeRange(ranges, percentage)
+	}
+
+	if grade != "" {
+		outcome.Grade = &grade
+	}
+	return outcome, nil
+}
+
+// matchGradeRange returns the grade of the highest range whose minimum the percentage reaches
+func matchGradeRange(ranges []models.GradeRange, percentage float64) string {
+	sorted := make([]models.GradeRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinScore > sorted[j].MinScore })
+
+	for _, r := range sorted {
+		if percentage >= r.MinScore {
+			return r.Grade
+		}
+	}
+	return ""
+}
+
+func roundTo(value float64, places int) float64 {
+	if places < 0 {
+		return value
+	}
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -19,15 +19,21 @@ type gradingService struct {
 	logger         *slog.Logger
 	validator      *validator.Validator
 	attemptService AttemptService
+	audit          AuditService
+	analytics      AnalyticsService
+	notifier       NotificationEventService
 }
 
-func NewGradingService(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) GradingService {
+func NewGradingService(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, notifier NotificationEventService) GradingService {
 	return &gradingService{
 		db:             db,
 		repo:           repo,
 		logger:         logger,
 		validator:      validator,
-		attemptService: NewAttemptService(repo, db, logger, validator, nil),
+		attemptService: NewAttemptService(repo, db, logger, validator, nil, notifier, AnswerHistoryConfig{}),
+		audit:          NewAuditService(repo, db, logger, validator),
+		analytics:      NewAnalyticsService(repo, db, logger, validator),
+		notifier:       notifier,
 	}
 }
 
@@ -55,17 +61,30 @@ func (s *gradingService) GradeAnswer(ctx context.Context, answerID uint, score f
 	}
 
 	// Validate score
-	maxScore := float64(answer.Question.Points)
+	points, err := s.answerMaxScore(ctx, nil, answer)
+	if err != nil {
+		return nil, err
+	}
+	maxScore := float64(points)
 	if score < 0 || score > maxScore {
 		return nil, NewValidationError("score", "score must be between 0 and max points", score)
 	}
 
+	before := map[string]interface{}{
+		"score":          answer.Score,
+		"feedback":       answer.Feedback,
+		"is_graded":      answer.IsGraded,
+		"is_provisional": answer.IsProvisional,
+		"graded_by":      answer.GradedBy,
+	}
+
 	// Update answer with grade
 	answer.Score = score
 	answer.Feedback = feedback
 	answer.GradedBy = &graderID
 	answer.GradedAt = timePtr(time.Now())
 	answer.IsGraded = true
+	answer.IsProvisional = false // Confirms or overrides a provisional auto-grade
 
 	if err := s.repo.Answer().Update(ctx, nil, answer); err != nil {
 		return nil, fmt.Errorf("failed to update answer grade: %w", err)
@@ -88,6 +107,25 @@ func (s *gradingService) GradeAnswer(ctx context.Context, answerID uint, score f
 		"score", score,
 		"max_score", maxScore)
 
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditGradeUpdated,
+		UserID:      graderID,
+		TargetType:  "answer",
+		TargetID:    &answerID,
+		Description: fmt.Sprintf("Answer %d graded %.2f/%.2f", answerID, score, maxScore),
+		Before:      before,
+		After: map[string]interface{}{
+			"score":     score,
+			"feedback":  feedback,
+			"is_graded": true,
+			"graded_by": graderID,
+		},
+		Metadata: map[string]interface{}{
+			"attempt_id":  answer.AttemptID,
+			"question_id": answer.QuestionID,
+		},
+	})
+
 	// Update attempt grade if all questions are graded
 	go s.updateAttemptGradeIfComplete(answer.AttemptID)
 
@@ -126,7 +164,7 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 	}
 
 	// Check grading permissions
-	assessmentService := NewAssessmentService(s.repo, tx, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, tx, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, attempt.AssessmentID, graderID)
 	if err != nil {
 		tx.Rollback()
@@ -145,7 +183,14 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 	}
 
 	// Use batch auto-grading for all ungraded answers
-	questionResults, err := s.autoGradeAnswers(ctx, tx, answers, attempt.AssessmentID)
+	// Attempts are graded against the assessment version they were started on
+	snapshot, err := getPinnedSnapshot(ctx, s.repo, tx, attempt)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	questionResults, err := s.autoGradeAnswers(ctx, tx, answers, attempt.AssessmentID, snapshot)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to auto-grade answers: %w", err)
@@ -159,25 +204,27 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 		maxTotalScore += result.MaxScore
 	}
 
-	percentage := 0.0
-	if maxTotalScore > 0 {
-		percentage = (totalScore / maxTotalScore) * 100
-	}
-
 	// Get assessment to check passing score
 	assessment, err := s.repo.Assessment().GetByID(ctx, tx, attempt.AssessmentID)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to get assessment: %w", err)
 	}
+	if snapshot != nil {
+		assessment.PassingScore = snapshot.PassingScore
+	}
 
-	isPassing := percentage >= float64(assessment.PassingScore)
-	grade := s.calculateLetterGrade(percentage)
+	// Apply the assessment grading scheme (bonus, curve, rounding, grade mapping)
+	outcome, err := s.gradeWithScheme(ctx, tx, assessment, totalScore, maxTotalScore)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
 	// Update attempt with final grade
-	attempt.Score = totalScore
-	attempt.Percentage = percentage
-	attempt.Passed = isPassing
+	attempt.Score = outcome.Score
+	attempt.Percentage = outcome.Percentage
+	attempt.Passed = outcome.Passed
 
 	if err := s.repo.Attempt().Update(ctx, tx, attempt); err != nil {
 		tx.Rollback()
@@ -191,11 +238,11 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 
 	result := &AttemptGradingResult{
 		AttemptID:  attemptID,
-		TotalScore: totalScore,
+		TotalScore: outcome.Score,
 		MaxScore:   maxTotalScore,
-		Percentage: percentage,
-		IsPassing:  isPassing,
-		Grade:      &grade,
+		Percentage: outcome.Percentage,
+		IsPassing:  outcome.Passed,
+		Grade:      outcome.Grade,
 		Questions:  questionResults,
 		GradedAt:   time.Now(),
 		GradedBy:   graderID,
@@ -203,9 +250,13 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 
 	s.logger.Info("Attempt graded successfully",
 		"attempt_id", attemptID,
-		"total_score", totalScore,
-		"percentage", percentage,
-		"is_passing", isPassing)
+		"total_score", outcome.Score,
+		"percentage", outcome.Percentage,
+		"is_passing", outcome.Passed)
+
+	notifyAsync(s.notifier, s.logger, "attempt_graded", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyAttemptGraded(ctx, attemptID)
+	})
 
 	return result, nil
 }
@@ -216,6 +267,7 @@ func (s *gradingService) GradeMultipleAnswers(ctx context.Context, grades []repo
 		"grader_id", graderID)
 
 	results := make([]GradingResult, len(grades))
+	var completedAttemptID uint
 
 	// Process each grade in transaction
 	err := s.db.Transaction(func(tx *gorm.DB) error {
@@ -280,6 +332,7 @@ func (s *gradingService) GradeMultipleAnswers(ctx context.Context, grades []repo
 				if err != nil {
 					return fmt.Errorf("failed to update attempt grade: %w", err)
 				}
+				completedAttemptID = attemptId
 			}
 		}
 		return nil
@@ -291,6 +344,12 @@ func (s *gradingService) GradeMultipleAnswers(ctx context.Context, grades []repo
 
 	s.logger.Info("Multiple answers graded successfully", "count", len(grades))
 
+	if completedAttemptID != 0 {
+		notifyAsync(s.notifier, s.logger, "attempt_graded", func(ctx context.Context, notifier NotificationEventService) error {
+			return notifier.NotifyAttemptGraded(ctx, completedAttemptID)
+		})
+	}
+
 	return results, nil
 }
 
@@ -326,14 +385,20 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 		}, nil
 	}
 
+	// Templated questions are graded against the student's variant
+	content, err := variantContent(json.RawMessage(answer.Question.Content), answer)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate score based on question type
-	score, isCorrect, err := s.CalculateScore(ctx, answer.Question.Type, json.RawMessage(answer.Question.Content), json.RawMessage(answer.Answer))
+	score, isCorrect, err := s.CalculateScore(ctx, answer.Question.Type, content, json.RawMessage(answer.Answer))
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate score: %w", err)
 	}
 
 	// Generate feedback
-	feedback, err := s.GenerateFeedback(ctx, answer.Question.Type, json.RawMessage(answer.Question.Content), json.RawMessage(answer.Answer), isCorrect)
+	feedback, err := s.GenerateFeedback(ctx, answer.Question.Type, content, json.RawMessage(answer.Answer), isCorrect)
 	if err != nil {
 		s.logger.Warn("Failed to generate feedback", "answer_id", answerID, "error", err)
 	}
@@ -374,7 +439,9 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 
 // autoGradeAnswers performs batch auto-grading for multiple answers
 // This method handles transaction management internally for consistency
-func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answers []*models.StudentAnswer, assessmentId uint) ([]GradingResult, error) {
+// When snapshot is set, points, question type and answer key come from the pinned version instead of
+// the live assessment.
+func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answers []*models.StudentAnswer, assessmentId uint, snapshot *models.AssessmentSnapshot) ([]GradingResult, error) {
 	if len(answers) == 0 {
 		return []GradingResult{}, nil
 	}
@@ -382,42 +449,40 @@ func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answ
 	var result []GradingResult
 	var answersToUpdate []*models.StudentAnswer
 
-	// Get assessment questions for points mapping
-	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessment(ctx, tx, assessmentId)
+	gradingKeys, err := s.gradingKeys(ctx, tx, assessmentId, snapshot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
-	}
-
-	mapAssessmentQuestions := make(map[uint]models.AssessmentQuestion)
-	for _, aq := range assessmentQuestions {
-		mapAssessmentQuestions[aq.QuestionID] = *aq
+		return nil, err
 	}
 
 	// Process each answer
 	for _, answer := range answers {
-		assessmentQuestion, exists := mapAssessmentQuestions[answer.QuestionID]
+		key, exists := gradingKeys[answer.QuestionID]
 		if !exists {
 			s.logger.Warn("Question not found in assessment",
 				"question_id", answer.QuestionID,
 				"assessment_id", assessmentId)
 			continue
 		}
+		if key.Type == "" {
+			key.Type = answer.Question.Type
+			key.Content = json.RawMessage(answer.Question.Content)
+		}
 
-		// If already graded, include in results but don't update
-		//if answer.IsGraded {
-		//	result = append(result, GradingResult{
-		//		AnswerID:      answer.ID,
-		//		QuestionID:    answer.QuestionID,
-		//		Score:         answer.Score,
-		//		MaxScore:      float64(*assessmentQuestion.Points),
-		//		IsCorrect:     answer.Score == float64(*assessmentQuestion.Points),
-		//		PartialCredit: answer.Score > 0 && answer.Score < float64(*assessmentQuestion.Points),
-		//		Feedback:      answer.Feedback,
-		//		GradedAt:      *answer.GradedAt,
-		//		GradedBy:      answer.GradedBy,
-		//	})
-		//	continue
-		//}
+		// Grades set by a teacher stand; include them in results but don't update
+		if answer.IsGraded && answer.GradedBy != nil && answer.GradedAt != nil {
+			result = append(result, GradingResult{
+				AnswerID:      answer.ID,
+				QuestionID:    answer.QuestionID,
+				Score:         answer.Score,
+				MaxScore:      float64(key.Points),
+				IsCorrect:     answer.Score == float64(key.Points),
+				PartialCredit: answer.Score > 0 && answer.Score < float64(key.Points),
+				Feedback:      answer.Feedback,
+				GradedAt:      *answer.GradedAt,
+				GradedBy:      answer.GradedBy,
+			})
+			continue
+		}
 
 		// Skip if no answer provided
 		if answer.Answer == nil || len(answer.Answer) == 0 {
@@ -433,7 +498,7 @@ func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answ
 				AnswerID:   answer.ID,
 				QuestionID: answer.QuestionID,
 				Score:      0.0,
-				MaxScore:   float64(*assessmentQuestion.Points),
+				MaxScore:   float64(key.Points),
 				IsCorrect:  false,
 				GradedAt:   time.Now(),
 				GradedBy:   nil,
@@ -441,52 +506,71 @@ func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answ
 			continue
 		}
 
-		// Calculate score based on question type
-		score, isCorrect, err := s.CalculateScore(ctx, answer.Question.Type,
-			json.RawMessage(answer.Question.Content),
-			json.RawMessage(answer.Answer))
+		// Calculate score based on question type; templated questions are graded against the
+		// student's variant
+		content, err := variantContent(key.Content, answer)
+		var score float64
+		var isCorrect bool
+		if err == nil {
+			score, isCorrect, err = s.CalculateScore(ctx, key.Type, content, json.RawMessage(answer.Answer))
+		}
+		scored := err == nil
 		if err != nil {
 			// If grading fails (e.g., essay type), mark with 0 score
 			s.logger.Warn("Failed to calculate score, marking as 0",
 				"answer_id", answer.ID,
-				"question_type", answer.Question.Type,
+				"question_type", key.Type,
 				"error", err)
 			score = 0.0
 			isCorrect = false
 		}
 
 		// Generate feedback
-		feedback, err := s.GenerateFeedback(ctx, answer.Question.Type,
-			json.RawMessage(answer.Question.Content),
+		feedback, err := s.GenerateFeedback(ctx, key.Type,
+			content,
 			json.RawMessage(answer.Answer),
 			isCorrect)
 		if err != nil {
 			s.logger.Warn("Failed to generate feedback", "answer_id", answer.ID, "error", err)
 		}
 
-		// Update answer with auto-grade
-		finalScore := score * float64(*assessmentQuestion.Points)
+		// Update answer with auto-grade; negative and confidence-based marking only apply to
+		// objectively scored answers
+		finalScore := score * float64(key.Points)
+		if scored && key.Type != models.Essay {
+			finalScore = markScore(key.Marking, score, key.Points, answer.Confidence)
+		}
+		if finalScore < 0 && feedback != nil {
+			penalized := fmt.Sprintf("%s %.2f points were deducted for an incorrect answer.", *feedback, -finalScore)
+			feedback = &penalized
+		}
 		answer.Score = finalScore
 		answer.Feedback = feedback
 		answer.GradedAt = timePtr(time.Now())
 		answer.IsGraded = true
 		answer.IsCorrect = &isCorrect
 		answer.UpdatedAt = time.Now()
-		answer.MaxScore = *assessmentQuestion.Points
+		answer.MaxScore = key.Points
 		// Note: GradedBy is nil for auto-graded answers
-		if !s.isAutoGradeable(answer.Question.Type) {
+		if !s.isAutoGradeableQuestion(key.Type, key.Content) {
 			answer.IsGraded = false
 		}
 
+		// Keyword-scored essays count toward the attempt but wait for a teacher to confirm
+		answer.GradingConfidence = s.provisionalConfidence(key.Type, key.Content, json.RawMessage(answer.Answer))
+		answer.IsProvisional = answer.GradingConfidence != nil
+
 		answersToUpdate = append(answersToUpdate, answer)
 
 		result = append(result, GradingResult{
 			AnswerID:      answer.ID,
 			QuestionID:    answer.QuestionID,
 			Score:         finalScore,
-			MaxScore:      float64(*assessmentQuestion.Points),
+			MaxScore:      float64(key.Points),
 			IsCorrect:     isCorrect,
 			PartialCredit: score > 0 && score < 1.0,
+			Provisional:   answer.IsProvisional,
+			Confidence:    answer.GradingConfidence,
 			Feedback:      feedback,
 			GradedAt:      time.Now(),
 			GradedBy:      nil, // Auto-graded
@@ -508,6 +592,12 @@ func (s *gradingService) autoGradeAnswers(ctx context.Context, tx *gorm.DB, answ
 }
 
 func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (*AttemptGradingResult, error) {
+	return s.autoGradeAttempt(ctx, attemptID, true)
+}
+
+// autoGradeAttempt grades an attempt; with notify set it tells the student the result, or the
+// grader that essay answers are waiting. Bulk and follow-up grading send their own notifications.
+func (s *gradingService) autoGradeAttempt(ctx context.Context, attemptID uint, notify bool) (*AttemptGradingResult, error) {
 	s.logger.Info("Auto-grading attempt", "attempt_id", attemptID)
 
 	// Begin transaction to ensure atomicity
@@ -545,17 +635,24 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 	var questionResults []GradingResult
 	totalScore := 0.0
 	maxTotalScore := 0.0
-	hasManualGrading := false
+	pendingManualGrading := 0
+
+	// Attempts are graded against the assessment version they were started on
+	snapshot, err := getPinnedSnapshot(ctx, s.repo, tx, attempt)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
-	questionResults, err = s.autoGradeAnswers(ctx, tx, answers, attempt.AssessmentID)
+	questionResults, err = s.autoGradeAnswers(ctx, tx, answers, attempt.AssessmentID, snapshot)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to auto-grade answers: %w", err)
 	}
 
 	for _, answer := range answers {
-		if !s.isAutoGradeable(answer.Question.Type) {
-			hasManualGrading = true
+		if !s.isAutoGradeable(answer.Question.Type) && !answer.IsGraded {
+			pendingManualGrading++
 		}
 	}
 
@@ -564,29 +661,30 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 		maxTotalScore += result.MaxScore
 	}
 
-	// Calculate final grade (only if no manual grading required)
-	percentage := 0.0
-	if maxTotalScore > 0 {
-		percentage = (totalScore / maxTotalScore) * 100
-	}
-
 	// Get assessment to check passing score
 	assessment, err := s.repo.Assessment().GetByID(ctx, tx, attempt.AssessmentID)
 	if err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to get assessment: %w", err)
 	}
+	if snapshot != nil {
+		assessment.PassingScore = snapshot.PassingScore
+	}
 
-	isPassing := percentage >= float64(assessment.PassingScore)
-	grade := s.calculateLetterGrade(percentage)
+	// Calculate final grade from the assessment grading scheme
+	outcome, err := s.gradeWithScheme(ctx, tx, assessment, totalScore, maxTotalScore)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
-	attempt.Score = totalScore
-	attempt.Percentage = percentage
-	attempt.Passed = isPassing
+	attempt.Score = outcome.Score
+	attempt.Percentage = outcome.Percentage
+	attempt.Passed = outcome.Passed
 	attempt.IsGraded = true
 	attempt.MaxScore = int(maxTotalScore)
 
-	if hasManualGrading {
+	if pendingManualGrading > 0 {
 		attempt.IsGraded = false
 	}
 
@@ -602,11 +700,11 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 
 	result := &AttemptGradingResult{
 		AttemptID:  attemptID,
-		TotalScore: totalScore,
+		TotalScore: outcome.Score,
 		MaxScore:   maxTotalScore,
-		Percentage: percentage,
-		IsPassing:  isPassing,
-		Grade:      &grade,
+		Percentage: outcome.Percentage,
+		IsPassing:  outcome.Passed,
+		Grade:      outcome.Grade,
 		Questions:  questionResults,
 		GradedAt:   time.Now(),
 		GradedBy:   "", // Auto-graded
@@ -614,8 +712,21 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 
 	s.logger.Info("Attempt auto-graded successfully",
 		"attempt_id", attemptID,
-		"total_score", totalScore,
-		"has_manual_grading", hasManualGrading)
+		"total_score", outcome.Score,
+		"pending_manual_grading", pendingManualGrading)
+
+	if notify {
+		assessmentID := attempt.AssessmentID
+		if pendingManualGrading > 0 {
+			notifyAsync(s.notifier, s.logger, "manual_grading_required", func(ctx context.Context, notifier NotificationEventService) error {
+				return notifier.NotifyManualGradingRequired(ctx, assessmentID, pendingManualGrading)
+			})
+		} else {
+			notifyAsync(s.notifier, s.logger, "attempt_graded", func(ctx context.Context, notifier NotificationEventService) error {
+				return notifier.NotifyAttemptGraded(ctx, attemptID)
+			})
+		}
+	}
 
 	return result, nil
 }
@@ -638,7 +749,7 @@ func (s *gradingService) AutoGradeAssessment(ctx context.Context, assessmentID u
 
 	// Auto-grade each attempt
 	for _, attempt := range attempts {
-		result, err := s.AutoGradeAttempt(ctx, attempt.ID)
+		result, err := s.autoGradeAttempt(ctx, attempt.ID, false)
 		if err != nil {
 			s.logger.Error("Failed to auto-grade attempt", "attempt_id", attempt.ID, "error", err)
 			continue
@@ -650,5 +761,11 @@ func (s *gradingService) AutoGradeAssessment(ctx context.Context, assessmentID u
 		"assessment_id", assessmentID,
 		"attempts_processed", len(results))
 
+	s.refreshAnalytics(ctx, assessmentID)
+
+	notifyAsync(s.notifier, s.logger, "grading_completed", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyGradingCompleted(ctx, assessmentID)
+	})
+
 	return results, nil
 }
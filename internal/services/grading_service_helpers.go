@@ -7,11 +7,12 @@ import (
 	"math"
 	"reflect"
 	"sort"
-	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"gorm.io/gorm"
 )
 
@@ -36,8 +37,11 @@ func (s *gradingService) CalculateScore(ctx context.Context, questionType models
 	case models.Ordering:
 		return s.gradeOrdering(questionContent, studentAnswer)
 	case models.Essay:
-		// Essays require manual grading
-		return 0.0, false, ErrGradingNotAllowed
+		return s.gradeEssay(questionContent, studentAnswer)
+	case models.Numeric:
+		return s.gradeNumeric(questionContent, studentAnswer)
+	case models.Formula:
+		return s.gradeFormula(questionContent, studentAnswer)
 	default:
 		return 0.0, false, fmt.Errorf("unsupported question type: %s", questionType)
 	}
@@ -60,7 +64,11 @@ func (s *gradingService) GenerateFeedback(ctx context.Context, questionType mode
 	case models.Ordering:
 		feedback = s.generateOrderingFeedback(questionContent, studentAnswer, isCorrect)
 	case models.Essay:
-		feedback = "Essay questions require manual grading."
+		feedback = s.generateEssayFeedback(questionContent, studentAnswer)
+	case models.Numeric:
+		feedback = s.generateNumericFeedback(questionContent, studentAnswer, isCorrect)
+	case models.Formula:
+		feedback = s.generateFormulaFeedback(questionContent, studentAnswer, isCorrect)
 	default:
 		if isCorrect {
 			feedback = "Correct answer!"
@@ -116,7 +124,7 @@ func (s *gradingService) ReGradeAssessment(ctx context.Context, assessmentID uin
 	s.logger.Info("Re-grading all attempts for assessment", "assessment_id", assessmentID, "user_id", userID)
 
 	// Check permission
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
 	if err != nil {
 		return nil, err
@@ -149,6 +157,8 @@ func (s *gradingService) ReGradeAssessment(ctx context.Context, assessmentID uin
 		"assessment_id", assessmentID,
 		"attempts_processed", len(results))
 
+	s.refreshAnalytics(ctx, assessmentID)
+
 	return results, nil
 }
 
@@ -156,7 +166,7 @@ func (s *gradingService) ReGradeAssessment(ctx context.Context, assessmentID uin
 
 func (s *gradingService) GetGradingOverview(ctx context.Context, assessmentID uint, userID string) (*repositories.GradingStats, error) {
 	// Check permission
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
 	if err != nil {
 		return nil, err
@@ -199,44 +209,14 @@ func (s *gradingService) gradeMultipleChoice(questionContent json.RawMessage, st
 		return 1.0, true, nil
 	}
 
-	// Partial credit scoring for multiple correct answers
-	if len(correctAnswers) > 1 {
-		correct := 0
-		incorrect := 0
-
-		answerSet := make(map[string]bool)
-		for _, a := range answer {
-			answerSet[a] = true
-		}
-
-		correctSet := make(map[string]bool)
-		for _, c := range correctAnswers {
-			correctSet[c] = true
-		}
-
-		// Count correct selections
-		for _, a := range answer {
-			if correctSet[a] {
-				correct++
-			} else {
-				incorrect++
-			}
-		}
-
-		// Count missed correct answers
-		for _, c := range correctAnswers {
-			if !answerSet[c] {
-				incorrect++
-			}
-		}
-
-		// Calculate partial credit (at least 0)
-		totalCorrect := len(correctAnswers)
-		score := float64(correct-incorrect) / float64(totalCorrect)
-		return math.Max(0.0, score), false, nil
+	// Partial credit only when the question allows it and has several correct answers
+	rule := effectiveScoringRule(models.MultipleChoice, content.PartialCredit && len(correctAnswers) > 1, content.ScoringRule)
+	optionIDs := make([]string, len(content.Options))
+	for i, option := range content.Options {
+		optionIDs[i] = option.ID
 	}
 
-	return 0.0, false, nil
+	return scoreChoices(rule, optionIDs, correctAnswers, answer), false, nil
 }
 
 func (s *gradingService) gradeTrueFalse(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
@@ -269,7 +249,7 @@ func (s *gradingService) gradeFillBlank(questionContent json.RawMessage, student
 	}
 
 	totalPoints := 0
-	earnedPoints := 0
+	earnedPoints := 0.0
 	allCorrect := true
 
 	for blankID, blankDef := range content.Blanks {
@@ -281,18 +261,12 @@ func (s *gradingService) gradeFillBlank(questionContent json.RawMessage, student
 			continue
 		}
 
-		// Check against accepted answers
-		correct := false
-		for _, accepted := range blankDef.AcceptedAnswers {
-			if s.compareStrings(studentAns, accepted, content.CaseSensitive) {
-				correct = true
-				break
-			}
-		}
+		// Check against accepted answers and rules
+		matcher := newAnswerMatcher(blankDef.AcceptedAnswers, blankDef.AnswerRules, content.CaseSensitive, content.FoldDiacritics, blankDef.FuzzyMatching, blankDef.FuzzyThreshold)
+		credit, correct := matcher.match(studentAns)
 
-		if correct {
-			earnedPoints += blankDef.Points
-		} else {
+		earnedPoints += credit * float64(blankDef.Points)
+		if !correct {
 			allCorrect = false
 		}
 	}
@@ -301,7 +275,7 @@ func (s *gradingService) gradeFillBlank(questionContent json.RawMessage, student
 		return 0.0, false, nil
 	}
 
-	score := float64(earnedPoints) / float64(totalPoints)
+	score := earnedPoints / float64(totalPoints)
 	return score, allCorrect, nil
 }
 
@@ -316,30 +290,43 @@ func (s *gradingService) gradeShortAnswer(questionContent json.RawMessage, stude
 		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
 	}
 
-	// Check against accepted answers
-	for _, accepted := range content.AcceptedAnswers {
-		if s.compareStrings(answer, accepted, content.CaseSensitive) {
-			return 1.0, true, nil
-		}
+	// Check against accepted answers and rules, with fuzzy matching for partial credit
+	matcher := newAnswerMatcher(content.AcceptedAnswers, content.AnswerRules, content.CaseSensitive, content.FoldDiacritics, content.FuzzyMatching, content.FuzzyThreshold)
+	score, correct := matcher.match(answer)
+	return score, correct, nil
+}
+
+func (s *gradingService) gradeNumeric(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
+	var content models.NumericContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal question content: %w", err)
 	}
 
-	// Fuzzy matching for partial credit
-	if content.FuzzyMatching {
-		bestMatch := 0.0
-		for _, accepted := range content.AcceptedAnswers {
-			similarity := s.calculateStringSimilarity(answer, accepted)
-			if similarity > bestMatch {
-				bestMatch = similarity
-			}
-		}
+	answer, err := typedAnswer(studentAnswer)
+	if err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
+	}
 
-		// Award partial credit if similarity is above threshold
-		if bestMatch >= 0.8 {
-			return bestMatch, false, nil
-		}
+	score, correct := scoreNumeric(content, answer)
+	return score, correct, nil
+}
+
+func (s *gradingService) gradeFormula(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
+	var content models.FormulaContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal question content: %w", err)
 	}
 
-	return 0.0, false, nil
+	answer, err := typedAnswer(studentAnswer)
+	if err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
+	}
+
+	correct, err := scoreFormula(content, answer)
+	if err != nil {
+		return 0.0, false, err
+	}
+	return boolScore(correct), correct, nil
 }
 
 func (s *gradingService) gradeMatching(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
@@ -359,21 +346,24 @@ func (s *gradingService) gradeMatching(questionContent json.RawMessage, studentA
 		correctMappings[pair.LeftID] = pair.RightID
 	}
 
-	correct := 0
-	total := len(content.CorrectPairs)
+	if len(correctMappings) == 0 {
+		return 0.0, false, nil
+	}
 
+	// Perfect match
+	allCorrect := true
 	for left, expectedRight := range correctMappings {
-		if studentRight, exists := answers[left]; exists && studentRight == expectedRight {
-			correct++
+		if answers[left] != expectedRight {
+			allCorrect = false
+			break
 		}
 	}
-
-	if total == 0 {
-		return 0.0, false, nil
+	if allCorrect {
+		return 1.0, true, nil
 	}
 
-	score := float64(correct) / float64(total)
-	return score, correct == total, nil
+	rule := effectiveScoringRule(models.Matching, content.PartialCredit, content.ScoringRule)
+	return scorePairs(rule, correctMappings, answers), false, nil
 }
 
 func (s *gradingService) gradeOrdering(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
@@ -394,16 +384,28 @@ func (s *gradingService) gradeOrdering(questionContent json.RawMessage, studentA
 		return 1.0, true, nil
 	}
 
-	// Partial credit based on position accuracy
-	correct := 0
-	for i, itemID := range answer {
-		if i < len(expectedOrder) && itemID == expectedOrder[i] {
-			correct++
-		}
+	rule := effectiveScoringRule(models.Ordering, content.PartialCredit, content.ScoringRule)
+	return scoreSequence(rule, expectedOrder, answer), false, nil
+}
+
+// gradeEssay keyword-scores essays that opt into auto-grading; all other essays need a grader
+func (s *gradingService) gradeEssay(questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
+	if !essayAutoGradeEnabled(questionContent) {
+		return 0.0, false, ErrGradingNotAllowed
 	}
 
-	score := float64(correct) / float64(len(content.Items))
-	return score, false, nil
+	var content models.EssayContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal question content: %w", err)
+	}
+
+	text, err := essayText(studentAnswer)
+	if err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
+	}
+
+	result := scoreEssay(content, text)
+	return result.Score, result.Score >= 1.0, nil
 }
 
 // ===== FEEDBACK GENERATION =====
@@ -447,6 +449,40 @@ func (s *gradingService) generateShortAnswerFeedback(questionContent json.RawMes
 	return "Your answer doesn't match the expected response. Please review the question."
 }
 
+func (s *gradingService) generateNumericFeedback(questionContent json.RawMessage, studentAnswer json.RawMessage, isCorrect bool) string {
+	if isCorrect {
+		return "Correct answer!"
+	}
+
+	var content models.NumericContent
+	answer, err := typedAnswer(studentAnswer)
+	if err != nil || json.Unmarshal(questionContent, &content) != nil {
+		return "Incorrect answer."
+	}
+	if score, _ := scoreNumeric(content, answer); score > 0 {
+		return fmt.Sprintf("The value is correct, but it should be given to %d significant figures.", *content.SignificantFigures)
+	}
+	if quantity, ok := parseQuantity(answer); ok && content.UnitRequired && quantity.Unit == "" {
+		return fmt.Sprintf("Your answer is missing the unit (%s).", content.Unit)
+	}
+	return "Your answer is outside the accepted range. Please check your calculation."
+}
+
+func (s *gradingService) generateFormulaFeedback(questionContent json.RawMessage, studentAnswer json.RawMessage, isCorrect bool) string {
+	if isCorrect {
+		return "Correct answer!"
+	}
+
+	answer, err := typedAnswer(studentAnswer)
+	if err != nil {
+		return "Incorrect answer."
+	}
+	if _, err := utils.ParseExpression(answer); err != nil {
+		return fmt.Sprintf("Your expression could not be read: %s.", err.Error())
+	}
+	return "Your expression is not equivalent to the expected formula."
+}
+
 func (s *gradingService) generateMatchingFeedback(questionContent json.RawMessage, studentAnswer json.RawMessage, isCorrect bool) string {
 	if isCorrect {
 		return "All items matched correctly!"
@@ -461,6 +497,28 @@ func (s *gradingService) generateOrderingFeedback(questionContent json.RawMessag
 	return "The order is not completely correct. Please review the sequence."
 }
 
+func (s *gradingService) generateEssayFeedback(questionContent json.RawMessage, studentAnswer json.RawMessage) string {
+	if !essayAutoGradeEnabled(questionContent) {
+		return "Essay questions require manual grading."
+	}
+
+	var content models.EssayContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return "Essay questions require manual grading."
+	}
+	text, err := essayText(studentAnswer)
+	if err != nil {
+		return "Essay questions require manual grading."
+	}
+
+	result := scoreEssay(content, text)
+	feedback := fmt.Sprintf("Provisional score: your essay covers %d of %d key points.", len(result.Matched), len(content.KeyWords))
+	if result.Penalty > 0 {
+		feedback += fmt.Sprintf(" The length of %d words is outside the expected range.", result.WordCount)
+	}
+	return feedback + " Your teacher may adjust this score."
+}
+
 // ===== HELPER FUNCTIONS =====
 
 func (s *gradingService) checkGradingPermission(ctx context.Context, answer *models.StudentAnswer, graderID string) error {
@@ -476,7 +534,7 @@ func (s *gradingService) checkGradingPermission(ctx context.Context, answer *mod
 	}
 
 	// Check if grader has access to the assessment
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, answer.Attempt.AssessmentID, graderID)
 	if err != nil {
 		return err
@@ -497,6 +555,38 @@ func (s *gradingService) getUserRole(ctx context.Context, userID string) (models
 }
 
 func (s *gradingService) isAutoGradeable(questionType models.QuestionType) bool {
+	return isAutoGradeableType(questionType)
+}
+
+// isAutoGradeableQuestion also accepts essays that opt into keyword scoring
+func (s *gradingService) isAutoGradeableQuestion(questionType models.QuestionType, questionContent json.RawMessage) bool {
+	if questionType == models.Essay {
+		return essayAutoGradeEnabled(questionContent)
+	}
+	return isAutoGradeableType(questionType)
+}
+
+// provisionalConfidence returns the confidence of a provisional auto-grade, or nil when the
+// auto-grade is final
+func (s *gradingService) provisionalConfidence(questionType models.QuestionType, questionContent json.RawMessage, studentAnswer json.RawMessage) *float64 {
+	if questionType != models.Essay || !essayAutoGradeEnabled(questionContent) {
+		return nil
+	}
+
+	var content models.EssayContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return nil
+	}
+	text, err := essayText(studentAnswer)
+	if err != nil {
+		return nil
+	}
+
+	confidence := scoreEssay(content, text).Confidence
+	return &confidence
+}
+
+func isAutoGradeableType(questionType models.QuestionType) bool {
 	autoGradeableTypes := map[models.QuestionType]bool{
 		models.MultipleChoice: true,
 		models.TrueFalse:      true,
@@ -504,40 +594,100 @@ func (s *gradingService) isAutoGradeable(questionType models.QuestionType) bool
 		models.ShortAnswer:    true,
 		models.Matching:       true,
 		models.Ordering:       true,
-		models.Essay:          false, // Requires manual grading
+		models.Numeric:        true,
+		models.Formula:        true,
+		models.Essay:          false, // Requires manual grading unless keyword scoring is enabled
 	}
 
 	return autoGradeableTypes[questionType]
 }
 
-func (s *gradingService) calculateLetterGrade(percentage float64) string {
-	if percentage >= 97 {
-		return "A+"
-	} else if percentage >= 93 {
-		return "A"
-	} else if percentage >= 90 {
-		return "A-"
-	} else if percentage >= 87 {
-		return "B+"
-	} else if percentage >= 83 {
-		return "B"
-	} else if percentage >= 80 {
-		return "B-"
-	} else if percentage >= 77 {
-		return "C+"
-	} else if percentage >= 73 {
-		return "C"
-	} else if percentage >= 70 {
-		return "C-"
-	} else if percentage >= 67 {
-		return "D+"
-	} else if percentage >= 63 {
-		return "D"
-	} else if percentage >= 60 {
-		return "D-"
-	} else {
-		return "F"
+// gradingKey is what an answer is graded against
+type gradingKey struct {
+	Type    models.QuestionType // Empty when the live question of the answer applies
+	Content json.RawMessage
+	Points  int
+	Marking answerMarking
+}
+
+// gradingKeys maps question IDs to their grading keys, from the pinned snapshot when there is one
+// and otherwise from the live assessment questions
+func (s *gradingService) gradingKeys(ctx context.Context, tx *gorm.DB, assessmentID uint, snapshot *models.AssessmentSnapshot) (map[uint]gradingKey, error) {
+	if snapshot != nil {
+		keys := make(map[uint]gradingKey, len(snapshot.Questions))
+		for _, question := range snapshot.Questions {
+			keys[question.QuestionID] = gradingKey{
+				Type:    question.Type,
+				Content: json.RawMessage(question.Content),
+				Points:  question.Points,
+				Marking: answerMarking{
+					NegativeMarking:      question.NegativeMarking,
+					NegativeMarkingValue: question.NegativeMarkingValue,
+					ConfidenceMarking:    snapshot.ConfidenceMarking,
+				},
+			}
+		}
+		return keys, nil
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, tx, assessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+		}
+		settings = &models.AssessmentSettings{AssessmentID: assessmentID}
 	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessment(ctx, tx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	keys := make(map[uint]gradingKey, len(assessmentQuestions))
+	for _, aq := range assessmentQuestions {
+		key := gradingKey{Marking: resolveMarking(settings, aq)}
+		if aq.Points != nil {
+			key.Points = *aq.Points
+		}
+		keys[aq.QuestionID] = key
+	}
+	return keys, nil
+}
+
+// answerMaxScore returns the points an answer is worth in the version its attempt was started on
+func (s *gradingService) answerMaxScore(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) (int, error) {
+	snapshot, err := getPinnedSnapshot(ctx, s.repo, tx, &answer.Attempt)
+	if err != nil {
+		return 0, err
+	}
+	if snapshot != nil {
+		if question, ok := snapshot.Question(answer.QuestionID); ok {
+			return question.Points, nil
+		}
+	}
+
+	assessmentQuestion, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, tx, answer.Attempt.AssessmentID, answer.QuestionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get assessment question: %w", err)
+	}
+	if assessmentQuestion.Points == nil {
+		return answer.Question.Points, nil
+	}
+	return *assessmentQuestion.Points, nil
+}
+
+// gradeWithScheme applies the assessment's grading scheme, or the default letter scale when none is assigned
+func (s *gradingService) gradeWithScheme(ctx context.Context, tx *gorm.DB, assessment *models.Assessment, totalScore, maxTotalScore float64) (*gradeOutcome, error) {
+	scheme, err := s.repo.GradingScheme().GetByAssessment(ctx, tx, assessment.ID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get grading scheme: %w", err)
+		}
+		scheme = defaultGradingScheme()
+	}
+
+	// Negative marking can take the raw total below zero; an attempt never scores less than nothing
+	return calculateGrade(scheme, math.Max(0, totalScore), maxTotalScore, assessment.PassingScore)
 }
 
 func (s *gradingService) gradeAnswerInTransaction(ctx context.Context, tx *gorm.DB, answerID uint, score float64, feedback *string, graderID string) (*GradingResult, error) {
@@ -547,18 +697,18 @@ func (s *gradingService) gradeAnswerInTransaction(ctx context.Context, tx *gorm.
 		return nil, fmt.Errorf("failed to get answer: %w", err)
 	}
 
-	assessmentQuestion, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, tx, answer.Attempt.AssessmentID, answer.QuestionID)
+	maxScore, err := s.answerMaxScore(ctx, tx, answer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get assessment question: %w", err)
+		return nil, err
 	}
 
 	// Update with grade
-	maxScore := *assessmentQuestion.Points
 	answer.Score = score
 	answer.Feedback = feedback
 	answer.GradedBy = &graderID
 	answer.GradedAt = timePtr(time.Now())
 	answer.IsGraded = true
+	answer.IsProvisional = false
 
 	if err := s.repo.Answer().Update(ctx, tx, answer); err != nil {
 		return nil, fmt.Errorf("failed to update answer: %w", err)
@@ -587,34 +737,41 @@ func (s *gradingService) updateAttemptGradeIfComplete(attemptID uint) {
 		return
 	}
 
-	if allGraded {
-		if _, err := s.AutoGradeAttempt(ctx, attemptID); err != nil {
-			s.logger.Error("Failed to update attempt grade", "attempt_id", attemptID, "error", err)
-		}
+	if !allGraded {
+		return
 	}
-}
 
-func (s *gradingService) compareStrings(s1, s2 string, caseSensitive bool) bool {
-	if !caseSensitive {
-		s1 = strings.ToLower(strings.TrimSpace(s1))
-		s2 = strings.ToLower(strings.TrimSpace(s2))
-	} else {
-		s1 = strings.TrimSpace(s1)
-		s2 = strings.TrimSpace(s2)
+	if _, err := s.autoGradeAttempt(ctx, attemptID, false); err != nil {
+		s.logger.Error("Failed to update attempt grade", "attempt_id", attemptID, "error", err)
+		return
 	}
-	return s1 == s2
+
+	notifyAsync(s.notifier, s.logger, "attempt_graded", func(ctx context.Context, notifier NotificationEventService) error {
+		return notifier.NotifyAttemptGraded(ctx, attemptID)
+	})
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		s.logger.Error("Failed to get attempt for analytics", "attempt_id", attemptID, "error", err)
+		return
+	}
+	s.refreshAnalytics(ctx, attempt.AssessmentID)
 }
 
-func (s *gradingService) calculateStringSimilarity(s1, s2 string) float64 {
-	// Simple similarity calculation using Levenshtein distance
-	s1 = strings.ToLower(strings.TrimSpace(s1))
-	s2 = strings.ToLower(strings.TrimSpace(s2))
+// refreshAnalytics recalculates assessment analytics after grading; failures are only logged
+func (s *gradingService) refreshAnalytics(ctx context.Context, assessmentID uint) {
+	if _, err := s.analytics.RecalculateAssessment(ctx, assessmentID); err != nil {
+		s.logger.Error("Failed to recalculate assessment analytics", "assessment_id", assessmentID, "error", err)
+	}
+}
 
+// stringSimilarity is one minus the edit distance relative to the longer string
+func stringSimilarity(s1, s2 string) float64 {
 	if s1 == s2 {
 		return 1.0
 	}
 
-	maxLen := math.Max(float64(len(s1)), float64(len(s2)))
+	maxLen := math.Max(float64(utf8.RuneCountInString(s1)), float64(utf8.RuneCountInString(s2)))
 	if maxLen == 0 {
 		return 1.0
 	}
@@ -623,7 +780,9 @@ func (s *gradingService) calculateStringSimilarity(s1, s2 string) float64 {
 	return 1.0 - (distance / maxLen)
 }
 
-func levenshteinDistance(s1, s2 string) int {
+// levenshteinDistance counts edits in runes, so accented letters count once
+func levenshteinDistance(a, b string) int {
+	s1, s2 := []rune(a), []rune(b)
 	if len(s1) == 0 {
 		return len(s2)
 	}
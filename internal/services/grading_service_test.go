@@ -1,11 +1,16 @@
 package services
 
 import (
+	"encoding/json"
 	"log/slog"
+	"math"
+	"strings"
 	"testing"
 
+	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +20,7 @@ func TestNewGradingService(t *testing.T) {
 		repo      repositories.Repository
 		logger    *slog.Logger
 		validator *validator.Validator
+		notifier  NotificationEventService
 	}
 	tests := []struct {
 		name string
@@ -25,7 +31,362 @@ func TestNewGradingService(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			NewGradingService(tt.args.db, tt.args.repo, tt.args.logger, tt.args.validator)
+			NewGradingService(tt.args.db, tt.args.repo, tt.args.logger, tt.args.validator, tt.args.notifier)
 		})
 	}
 }
+
+func TestCalculateGrade(t *testing.T) {
+	meritRanges, _ := json.Marshal([]models.GradeRange{
+		{MinScore: 85, MaxScore: 100, Grade: "Distinction"},
+		{MinScore: 70, MaxScore: 85, Grade: "Merit"},
+		{MinScore: 50, MaxScore: 70, Grade: "Pass"},
+		{MinScore: 0, MaxScore: 50, Grade: "Fail"},
+	})
+
+	tests := []struct {
+		name           string
+		scheme         *models.GradingScheme
+		rawScore       float64
+		maxScore       float64
+		passingScore   int
+		wantScore      float64
+		wantPercentage float64
+		wantPassed     bool
+		wantGrade      string
+	}{
+		{
+			name:           "default letter scale",
+			scheme:         defaultGradingScheme(),
+			rawScore:       45,
+			maxScore:       50,
+			passingScore:   60,
+			wantScore:      45,
+			wantPercentage: 90,
+			wantPassed:     true,
+			wantGrade:      "A-",
+		},
+		{
+			name:           "ten point scale",
+			scheme:         &models.GradingScheme{Type: models.GradingPoints, MaxScore: 10, RoundTo: 1},
+			rawScore:       17,
+			maxScore:       20,
+			passingScore:   50,
+			wantScore:      17,
+			wantPercentage: 85,
+			wantPassed:     true,
+			wantGrade:      "8.5",
+		},
+		{
+			name:           "custom ranges with bonus and curve",
+			scheme:         &models.GradingScheme{Type: models.GradingCustom, GradeRanges: meritRanges, RoundTo: 2, BonusPoints: 2, CurvePoints: 5},
+			rawScore:       60,
+			maxScore:       100,
+			passingScore:   70,
+			wantScore:      67,
+			wantPercentage: 67,
+			wantPassed:     false,
+			wantGrade:      "Pass",
+		},
+		{
+			name:           "curve capped at maximum",
+			scheme:         &models.GradingScheme{Type: models.GradingPercentage, RoundTo: 0, CurvePoints: 10, PassingScore: 50},
+			rawScore:       95,
+			maxScore:       100,
+			passingScore:   99,
+			wantScore:      100,
+			wantPercentage: 100,
+			wantPassed:     true,
+			wantGrade:      "100%",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculateGrade(tt.scheme, tt.rawScore, tt.maxScore, tt.passingScore)
+			if err != nil {
+				t.Fatalf("calculateGrade() error = %v", err)
+			}
+			if got.Score != tt.wantScore || got.Percentage != tt.wantPercentage || got.Passed != tt.wantPassed {
+				t.Errorf("calculateGrade() = %+v, want score %v percentage %v passed %v", got, tt.wantScore, tt.wantPercentage, tt.wantPassed)
+			}
+			if got.Grade == nil || *got.Grade != tt.wantGrade {
+				t.Errorf("calculateGrade() grade = %v, want %v", got.Grade, tt.wantGrade)
+			}
+		})
+	}
+}
+
+func TestScoreEssay(t *testing.T) {
+	minWords := 10
+	content := models.EssayContent{
+		AutoGrade: true,
+		KeyWords:  []string{"evaporation", "condensation", "precipitation", "water cycle"},
+		Synonyms:  map[string][]string{"precipitation": {"rain", "snow"}},
+		MinWords:  &minWords,
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		wantMatched int
+		wantScore   float64
+		wantPenalty bool
+	}{
+		{
+			name:        "stems and synonyms",
+			text:        "In the water cycle, water evaporates from the sea, condenses into clouds and falls back as rain.",
+			wantMatched: 4,
+			wantScore:   1,
+		},
+		{
+			name:        "partial coverage",
+			text:        "Evaporation moves water from oceans into the air where it stays for a while.",
+			wantMatched: 1,
+			wantScore:   0.25,
+		},
+		{
+			name:        "too short",
+			text:        "Evaporation, condensation, rain.",
+			wantMatched: 3,
+			wantScore:   0.75 - 0.25*0.7,
+			wantPenalty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreEssay(content, tt.text)
+			if len(got.Matched) != tt.wantMatched {
+				t.Errorf("scoreEssay() matched = %v, missing = %v, want %d matched", got.Matched, got.Missing, tt.wantMatched)
+			}
+			if math.Abs(got.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("scoreEssay() score = %v, want %v", got.Score, tt.wantScore)
+			}
+			if (got.Penalty > 0) != tt.wantPenalty {
+				t.Errorf("scoreEssay() penalty = %v, want penalty %v", got.Penalty, tt.wantPenalty)
+			}
+			if got.Confidence <= 0 || got.Confidence > 1 {
+				t.Errorf("scoreEssay() confidence = %v, want within (0, 1]", got.Confidence)
+			}
+		})
+	}
+}
+
+func TestScoringRules(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"choices all or nothing", scoreChoices(effectiveScoringRule(models.MultipleChoice, false, models.ScoringProportional), []string{"a", "b", "c", "d"}, []string{"a", "b"}, []string{"a"}), 0},
+		{"choices proportional", scoreChoices(models.ScoringProportional, []string{"a", "b", "c", "d"}, []string{"a", "b"}, []string{"a"}), 0.75},
+		{"choices right minus wrong", scoreChoices(models.ScoringRightMinusWrong, []string{"a", "b", "c", "d"}, []string{"a", "b", "c"}, []string{"a", "b"}), 1.0 / 3},
+		{"pairs per pair", scorePairs(models.ScoringPerPair, map[string]string{"1": "a", "2": "b", "3": "c", "4": "d"}, map[string]string{"1": "a", "2": "c", "3": "b"}), 0.25},
+		{"pairs right minus wrong", scorePairs(models.ScoringRightMinusWrong, map[string]string{"1": "a", "2": "b", "3": "c", "4": "d"}, map[string]string{"1": "a", "2": "b", "3": "d"}), 0.25},
+		{"sequence default positional", scoreSequence(effectiveScoringRule(models.Ordering, true, ""), []string{"a", "b", "c", "d"}, []string{"b", "a", "c", "d"}), 0.5},
+		{"sequence kendall tau", scoreSequence(models.ScoringKendallTau, []string{"a", "b", "c", "d"}, []string{"b", "a", "c", "d"}), 4.0 / 6},
+		{"sequence longest subsequence", scoreSequence(models.ScoringLongestSequence, []string{"a", "b", "c", "d"}, []string{"d", "a", "b", "c"}), 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-9 {
+				t.Errorf("score = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkScore(t *testing.T) {
+	high := models.ConfidenceHigh
+	medium := models.ConfidenceMedium
+	fixed := answerMarking{NegativeMarking: models.NegativeMarkingFixed, NegativeMarkingValue: 1}
+	fraction := answerMarking{NegativeMarking: models.NegativeMarkingFraction, NegativeMarkingValue: 0.25}
+	confidence := answerMarking{NegativeMarking: models.NegativeMarkingFixed, NegativeMarkingValue: 1, ConfidenceMarking: true}
+
+	tests := []struct {
+		name       string
+		marking    answerMarking
+		fraction   float64
+		confidence *int
+		want       float64
+	}{
+		{"no negative marking", answerMarking{NegativeMarking: models.NegativeMarkingNone}, 0, nil, 0},
+		{"fixed deduction", fixed, 0, nil, -1},
+		{"fraction deduction", fraction, 0, nil, -1},
+		{"partial credit is not penalized", fraction, 0.5, nil, 2},
+		{"high confidence correct", confidence, 1, &high, 4},
+		{"high confidence wrong", confidence, 0, &high, -8},
+		{"medium confidence wrong", confidence, 0, &medium, -8.0 / 3},
+		{"missing confidence counts as low", confidence, 0, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markScore(tt.marking, tt.fraction, 4, tt.confidence); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("markScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMarking(t *testing.T) {
+	fraction, fixed := models.NegativeMarkingFraction, models.NegativeMarkingFixed
+	half, five, negative := 0.5, 5.0, -0.5
+	settings := &models.AssessmentSettings{NegativeMarking: models.NegativeMarkingFraction, NegativeMarkingValue: 0.25, ConfidenceMarking: true}
+
+	tests := []struct {
+		name     string
+		settings *models.AssessmentSettings
+		question models.AssessmentQuestion
+		want     answerMarking
+	}{
+		{"inherited marking", settings, models.AssessmentQuestion{}, answerMarking{models.NegativeMarkingFraction, 0.25, true}},
+		{"value override", settings, models.AssessmentQuestion{NegativeMarkingValue: &half}, answerMarking{models.NegativeMarkingFraction, 0.5, true}},
+		{"mode and value override", settings, models.AssessmentQuestion{NegativeMarking: &fixed, NegativeMarkingValue: &five}, answerMarking{models.NegativeMarkingFixed, 5, true}},
+		{"no mode set", &models.AssessmentSettings{}, models.AssessmentQuestion{}, answerMarking{models.NegativeMarkingNone, 0, false}},
+		{"fraction over 1 is clamped", settings, models.AssessmentQuestion{NegativeMarking: &fraction, NegativeMarkingValue: &five}, answerMarking{models.NegativeMarkingFraction, 1, true}},
+		{"negative fraction is clamped", settings, models.AssessmentQuestion{NegativeMarkingValue: &negative}, answerMarking{models.NegativeMarkingFraction, 0, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveMarking(tt.settings, &tt.question); got != tt.want {
+				t.Errorf("resolveMarking() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswerMatcher(t *testing.T) {
+	tolerance := 0.05
+	threshold := 0.7
+	rules := []models.AnswerRule{
+		{Type: models.AnswerMatchText, Value: "Hà Nội", Synonyms: []string{"Thăng Long"}},
+		{Type: models.AnswerMatchRegex, Value: `h2o|water`},
+		{Type: models.AnswerMatchNumeric, Value: "9.81", Unit: "m/s2", AbsoluteTolerance: &tolerance, UnitFactors: map[string]float64{"cm/s2": 0.01}},
+	}
+	folding := newAnswerMatcher(nil, rules, false, true, true, &threshold)
+	strict := newAnswerMatcher([]string{"Paris"}, nil, true, false, false, nil)
+	accented := newAnswerMatcher([]string{"Hà Nội"}, nil, false, false, false, nil)
+
+	tests := []struct {
+		name      string
+		matcher   answerMatcher
+		answer    string
+		wantScore float64
+		wantExact bool
+	}{
+		{"diacritics folded", folding, "  ha  noi ", 1, true},
+		{"synonym", folding, "THĂNG LONG", 1, true},
+		{"decomposed input is normalized", accented, "Ha\u0300 No\u0302\u0323i", 1, true},
+		{"accents kept without folding", accented, "Ha Noi", 0, false},
+		{"anchored regex", folding, "H2O", 1, true},
+		{"regex must match the whole answer", folding, "h2o2", 0, false},
+		{"numeric within tolerance", folding, "9,8 m/s2", 1, true},
+		{"numeric converted unit", folding, "981 cm/s2", 1, true},
+		{"numeric unknown unit", folding, "9.81 km", 0, false},
+		{"fuzzy partial credit", folding, "thang lon", 0.9, false},
+		{"case sensitive", strict, "paris", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, exact := tt.matcher.match(tt.answer)
+			if math.Abs(score-tt.wantScore) > 1e-9 || exact != tt.wantExact {
+				t.Errorf("match(%q) = %v, %v, want %v, %v", tt.answer, score, exact, tt.wantScore, tt.wantExact)
+			}
+		})
+	}
+}
+
+func TestScoreNumeric(t *testing.T) {
+	tolerance := 0.01
+	figures := 3
+	content := models.NumericContent{
+		CorrectValue:       9.80665,
+		AbsoluteTolerance:  &tolerance,
+		SignificantFigures: &figures,
+		Unit:               "m/s2",
+		UnitFactors:        map[string]float64{"cm/s2": 0.01},
+	}
+
+	tests := []struct {
+		name      string
+		answer    string
+		wantScore float64
+		wantExact bool
+	}{
+		{"right figures", "9.81 m/s2", 1, true},
+		{"converted unit", "981 cm/s2", 1, true},
+		{"too many figures", "9.807", 0.5, false},
+		{"wrong value", "9.9", 0, false},
+		{"unknown unit", "9.81 ft/s2", 0, false},
+		{"not a number", "nine", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, exact := scoreNumeric(content, tt.answer)
+			if math.Abs(score-tt.wantScore) > 1e-9 || exact != tt.wantExact {
+				t.Errorf("scoreNumeric(%q) = %v, %v, want %v, %v", tt.answer, score, exact, tt.wantScore, tt.wantExact)
+			}
+		})
+	}
+}
+
+func TestScoreFormula(t *testing.T) {
+	content := models.FormulaContent{
+		CorrectFormula: "(x+1)^2",
+		Variables:      []models.FormulaVariable{{Name: "x", Min: -5, Max: 5}},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"x^2 + 2x + 1", true},
+		{"(1 + x)(x + 1)", true},
+		{"x^2 + 1", false},
+		{"y^2", false},
+		{"(x+1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := scoreFormula(content, tt.expression)
+			if err != nil {
+				t.Fatalf("scoreFormula() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("scoreFormula(%q) = %v, want %v", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionVariants(t *testing.T) {
+	content := datatypes.JSON(`{"correct_value":0,"unit":"N","template":{"variables":[{"name":"m","min":2,"max":9},{"name":"g","values":[9.8,10]}],"answer_formula":"m*g"}}`)
+	text := "A {{m}} kg mass weighs how much at g = {{g}}? Half of it is {{m*g/2:1}} N."
+
+	first, err := drawVariant(content, 42)
+	if err != nil {
+		t.Fatalf("drawVariant() error = %v", err)
+	}
+	second, _ := drawVariant(content, 42)
+	if string(first) != string(second) {
+		t.Errorf("drawVariant() is not deterministic: %s != %s", first, second)
+	}
+
+	renderedText, renderedContent, err := renderVariant(text, content, datatypes.JSON(`{"m":3,"g":9.8}`))
+	if err != nil {
+		t.Fatalf("renderVariant() error = %v", err)
+	}
+	if want := "A 3 kg mass weighs how much at g = 9.8? Half of it is 14.7 N."; renderedText != want {
+		t.Errorf("renderVariant() text = %q, want %q", renderedText, want)
+	}
+
+	var numeric models.NumericContent
+	if err := json.Unmarshal(renderedContent, &numeric); err != nil {
+		t.Fatalf("rendered content is not numeric content: %v", err)
+	}
+	if score, correct := scoreNumeric(numeric, "29.4 N"); !correct || score != 1 {
+		t.Errorf("scoreNumeric() on the variant = %v, %v, want 1, true", score, correct)
+	}
+	if strings.Contains(string(renderedContent), "template") {
+		t.Errorf("rendered content still contains the template: %s", renderedContent)
+	}
+}
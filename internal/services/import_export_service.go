@@ -8,13 +8,19 @@ import (
 	"io"
 	"log/slog"
 	"mime/multipart"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/google/uuid"
 	"github.com/xuri/excelize/v2"
 )
 
@@ -24,6 +30,7 @@ type ImportExportService interface {
 	ImportQuestionsFromFile(ctx context.Context, file multipart.File, filename string, creatorID string) (*ImportResult, error)
 	ImportQuestionsFromCSV(ctx context.Context, reader io.Reader, creatorID string) (*ImportResult, error)
 	ImportQuestionsFromExcel(ctx context.Context, reader io.Reader, creatorID string) (*ImportResult, error)
+	ValidateImportFile(ctx context.Context, reader io.Reader, filename string, creatorID string) (*ImportResult, error)
 
 	// Export operations
 	ExportQuestionsToCSV(ctx context.Context, questionIDs []uint, userID string) ([]byte, error)
@@ -31,22 +38,74 @@ type ImportExportService interface {
 	ExportAssessmentResults(ctx context.Context, assessmentID uint, userID string) ([]byte, error)
 
 	// Job management
-	GetImportJob(ctx context.Context, jobID string) (*models.ImportJob, error)
+	StartImportJob(ctx context.Context, file io.Reader, filename string, size int64, bankID *uint, userID string) (*models.ImportJob, error)
+	GetImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error)
+	ListImportJobs(ctx context.Context, filters repositories.ImportJobFilters, userID string) (*ImportJobListResponse, error)
 	ProcessImportJobAsync(ctx context.Context, jobID string) error
+	ResumeImportJobs(ctx context.Context) error
+	StartRecovery()
+	Shutdown(ctx context.Context) error
+}
+
+// ImportJobConfig controls how the background worker processes import jobs
+type ImportJobConfig struct {
+	MaxConcurrentJobs int
+	BatchSize         int           // rows committed per transaction; progress is persisted after each batch
+	ClaimTTL          time.Duration // a job whose replica stops saving progress this long is taken over by another
 }
 
 type importExportService struct {
 	repo      repositories.Repository
+	storage   storage.Storage
+	audit     AuditService
 	logger    *slog.Logger
 	validator *validator.Validator
+	config    ImportJobConfig
+
+	// Background job processing
+	jobSlots    chan struct{}
+	jobsCtx     context.Context
+	cancelJobs  context.CancelFunc
+	jobsWG      sync.WaitGroup
+	jobsMu      sync.Mutex
+	runningJobs map[string]bool
+	owner       string // claims this replica's jobs
+	recovery    *periodicJob
 }
 
-func NewImportExportService(repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) ImportExportService {
-	return &importExportService{
-		repo:      repo,
-		logger:    logger,
-		validator: validator,
+func NewImportExportService(repo repositories.Repository, fileStorage storage.Storage, logger *slog.Logger, validator *validator.Validator, config ImportJobConfig) ImportExportService {
+	if config.MaxConcurrentJobs <= 0 {
+		config.MaxConcurrentJobs = 2
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.ClaimTTL <= 0 {
+		config.ClaimTTL = 5 * time.Minute
+	}
+
+	hostname, _ := os.Hostname()
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+
+	s := &importExportService{
+		repo:        repo,
+		storage:     fileStorage,
+		audit:       NewAuditService(repo, nil, logger, validator),
+		logger:      logger,
+		validator:   validator,
+		config:      config,
+		jobSlots:    make(chan struct{}, config.MaxConcurrentJobs),
+		jobsCtx:     jobsCtx,
+		cancelJobs:  cancelJobs,
+		runningJobs: make(map[string]bool),
+		owner:       hostname + "-" + uuid.NewString()[:8],
 	}
+	s.recovery = newPeriodicJob("import-job-recovery", config.ClaimTTL, nil, logger, func(ctx context.Context) {
+		if err := s.ResumeImportJobs(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Failed to resume import jobs", "error", err)
+		}
+	})
+	return s
 }
 
 // ===== IMPORT OPERATIONS =====
@@ -62,6 +121,20 @@ type ImportResult struct {
 	Status        models.ImportJobStatus         `json:"status"`
 }
 
+type ImportJobListResponse struct {
+	Jobs  []*models.ImportJob `json:"jobs"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Size  int                 `json:"size"`
+}
+
+// ===== EXPORT REQUESTS =====
+
+type ExportQuestionsRequest struct {
+	QuestionIDs []uint `json:"question_ids" validate:"required,min=1,max=5000"`
+	Format      string `json:"format" validate:"required,oneof=csv xlsx"`
+}
+
 func (s *importExportService) ImportQuestionsFromFile(ctx context.Context, file multipart.File, filename string, creatorID string) (*ImportResult, error) {
 	s.logger.Info("Starting file import", "filename", filename, "creator_id", creatorID)
 
@@ -143,6 +216,8 @@ func (s *importExportService) ImportQuestionsFromCSV(ctx context.Context, reader
 		"success_count", result.SuccessCount,
 		"error_count", result.ErrorCount)
 
+	s.auditImport(ctx, creatorID, "csv", result)
+
 	return result, nil
 }
 
@@ -220,6 +295,59 @@ func (s *importExportService) ImportQuestionsFromExcel(ctx context.Context, read
 		"success_count", result.SuccessCount,
 		"error_count", result.ErrorCount)
 
+	s.auditImport(ctx, creatorID, "excel", result)
+
+	return result, nil
+}
+
+// ValidateImportFile parses an upload like an import would and reports row errors without saving anything
+func (s *importExportService) ValidateImportFile(ctx context.Context, reader io.Reader, filename string, creatorID string) (*ImportResult, error) {
+	fileType := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	if fileType != "csv" && fileType != "xlsx" {
+		return nil, NewValidationError("file", "unsupported file format", fileType)
+	}
+
+	rows, err := readImportRows(fileType, reader)
+	if err != nil {
+		return nil, NewValidationError("file", err.Error(), filename)
+	}
+	if len(rows) == 0 {
+		return nil, NewValidationError("file", "file must have a header row", filename)
+	}
+
+	headerMap := buildHeaderMap(rows[0])
+	result := &ImportResult{
+		TotalRows: len(rows) - 1,
+		Status:    models.ImportCompleted,
+	}
+
+	if headerErrors := missingImportColumns(headerMap); len(headerErrors) > 0 {
+		result.Errors = headerErrors
+		result.Status = models.ImportValidationFailed
+		return result, nil
+	}
+
+	for rowIndex, row := range rows[1:] {
+		_, rowErrors := s.parseCSVRow(row, headerMap, rowIndex+2, creatorID)
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			result.ErrorCount++
+		} else {
+			result.SuccessCount++
+		}
+		result.ProcessedRows++
+	}
+
+	if result.ErrorCount > 0 {
+		result.Status = models.ImportValidationFailed
+	}
+
+	s.logger.Info("Import file validated",
+		"filename", filename,
+		"total_rows", result.TotalRows,
+		"valid_rows", result.SuccessCount,
+		"error_count", result.ErrorCount)
+
 	return result, nil
 }
 
@@ -256,6 +384,8 @@ func (s *importExportService) ExportQuestionsToCSV(ctx context.Context, question
 		return nil, fmt.Errorf("CSV writer error: %w", err)
 	}
 
+	s.auditQuestionExport(ctx, userID, "csv", questions)
+
 	return []byte(buf.String()), nil
 }
 
@@ -301,12 +431,14 @@ func (s *importExportService) ExportQuestionsToExcel(ctx context.Context, questi
 		return nil, fmt.Errorf("failed to write Excel file: %w", err)
 	}
 
+	s.auditQuestionExport(ctx, userID, "excel", questions)
+
 	return buf.Bytes(), nil
 }
 
 func (s *importExportService) ExportAssessmentResults(ctx context.Context, assessmentID uint, userID string) ([]byte, error) {
 	// Check permission
-	assessmentService := NewAssessmentService(s.repo, nil, s.logger, s.validator)
+	assessmentService := NewAssessmentService(s.repo, nil, s.logger, s.validator, nil)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
 	if err != nil {
 		return nil, err
@@ -382,30 +514,221 @@ func (s *importExportService) ExportAssessmentResults(ctx context.Context, asses
 		return nil, fmt.Errorf("failed to write Excel file: %w", err)
 	}
 
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditDataExported,
+		UserID:      userID,
+		TargetType:  "assessment",
+		TargetID:    &assessmentID,
+		Description: fmt.Sprintf("Results of assessment %d exported", assessmentID),
+		Metadata: map[string]interface{}{
+			"format":        "excel",
+			"attempt_count": len(attempts),
+		},
+	})
+
 	return buf.Bytes(), nil
 }
 
 // ===== JOB MANAGEMENT =====
 
-func (s *importExportService) GetImportJob(ctx context.Context, jobID string) (*models.ImportJob, error) {
-	// TODO: Implement job storage and retrieval
-	// For now, return a placeholder
-	return &models.ImportJob{
-		ID:     jobID,
-		Status: "completed",
+// StartImportJob stores the uploaded file, records a pending job and hands it to the background worker
+func (s *importExportService) StartImportJob(ctx context.Context, file io.Reader, filename string, size int64, bankID *uint, userID string) (*models.ImportJob, error) {
+	s.logger.Info("Creating import job", "filename", filename, "bank_id", bankID, "user_id", userID)
+
+	fileType := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	if fileType != "csv" && fileType != "xlsx" {
+		return nil, NewValidationError("file", "unsupported file format", fileType)
+	}
+
+	if bankID != nil {
+		canEdit, err := s.repo.QuestionBank().CanEdit(ctx, nil, *bankID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check question bank permission: %w", err)
+		}
+		if !canEdit {
+			return nil, NewPermissionError(userID, *bankID, "question_bank", "import", "not owner or insufficient permissions")
+		}
+	}
+
+	job := &models.ImportJob{
+		ID:        uuid.NewString(),
+		BankID:    bankID,
+		UserID:    userID,
+		FileName:  filepath.Base(filename),
+		FileType:  fileType,
+		FileSize:  size,
+		Status:    models.ImportPending,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.storeImportFile(ctx, job, file); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ImportJob().Create(ctx, nil, job); err != nil {
+		s.removeImportFile(ctx, job)
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	if err := s.ProcessImportJobAsync(ctx, job.ID); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *importExportService) GetImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error) {
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if job.UserID != userID {
+		user, err := s.repo.User().GetByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Role != models.RoleAdmin {
+			return nil, NewPermissionError(userID, 0, "import_job", "view", "not owner of import job")
+		}
+	}
+
+	return job, nil
+}
+
+func (s *importExportService) ListImportJobs(ctx context.Context, filters repositories.ImportJobFilters, userID string) (*ImportJobListResponse, error) {
+	jobs, total, err := s.repo.ImportJob().ListByUser(ctx, nil, userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+
+	return &ImportJobListResponse{
+		Jobs:  jobs,
+		Total: total,
+		Page:  (filters.Offset / max(filters.Limit, 1)) + 1,
+		Size:  filters.Limit,
 	}, nil
 }
 
+// ProcessImportJobAsync schedules a pending or interrupted job on the background worker and returns immediately
 func (s *importExportService) ProcessImportJobAsync(ctx context.Context, jobID string) error {
-	// TODO: Implement async job processing
-	// This would typically involve:
-	// 1. Get job from storage
-	// 2. Process file in background
-	// 3. Update job status and progress
-	// 4. Store results
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrImportJobNotFound
+		}
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if job.Status != models.ImportPending && job.Status != models.ImportProcessing {
+		return ErrImportJobFinished
+	}
+
+	s.jobsMu.Lock()
+	if s.runningJobs[jobID] {
+		s.jobsMu.Unlock()
+		return nil
+	}
+	s.runningJobs[jobID] = true
+	s.jobsMu.Unlock()
+
+	s.jobsWG.Add(1)
+	go func() {
+		defer s.jobsWG.Done()
+		defer func() {
+			s.jobsMu.Lock()
+			delete(s.runningJobs, jobID)
+			s.jobsMu.Unlock()
+		}()
+
+		// Wait for a free worker slot unless shutting down
+		select {
+		case s.jobSlots <- struct{}{}:
+		case <-s.jobsCtx.Done():
+			return
+		}
+		defer func() { <-s.jobSlots }()
+
+		// Every replica may schedule the same job, only the one claiming it runs it
+		claimed, err := s.repo.ImportJob().Claim(s.jobsCtx, nil, jobID, s.owner, s.config.ClaimTTL)
+		if err != nil {
+			s.logger.Error("Failed to claim import job", "job_id", jobID, "error", err)
+			return
+		}
+		if !claimed {
+			return
+		}
+		defer s.releaseImportJob(jobID)
+
+		s.runImportJob(s.jobsCtx, jobID)
+	}()
+
 	return nil
 }
 
+// releaseImportJob gives up this replica's claim so an interrupted job is resumed right away
+// instead of once the claim expires
+func (s *importExportService) releaseImportJob(jobID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.ImportJob().ReleaseClaim(ctx, nil, jobID, s.owner); err != nil {
+		s.logger.Warn("Failed to release import job claim", "job_id", jobID, "error", err)
+	}
+}
+
+// ResumeImportJobs re-schedules jobs left pending or interrupted by a restart, and jobs whose
+// replica stopped renewing its claim. Processing resumes after the last committed batch.
+func (s *importExportService) ResumeImportJobs(ctx context.Context) error {
+	jobs, err := s.repo.ImportJob().ListByStatus(ctx, nil, []models.ImportJobStatus{models.ImportPending, models.ImportProcessing})
+	if err != nil {
+		return fmt.Errorf("failed to list unfinished import jobs: %w", err)
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		if job.ClaimedBy != nil && job.ClaimExpiresAt != nil && job.ClaimExpiresAt.After(now) {
+			continue
+		}
+
+		s.logger.Info("Resuming import job", "job_id", job.ID, "processed_rows", job.ProcessedRows)
+		if err := s.ProcessImportJobAsync(ctx, job.ID); err != nil {
+			s.logger.Error("Failed to resume import job", "job_id", job.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// StartRecovery resumes unfinished jobs periodically, picking up those of replicas that went away
+func (s *importExportService) StartRecovery() {
+	s.recovery.Start()
+}
+
+// Shutdown stops the worker between batches and waits for running jobs to save their progress
+func (s *importExportService) Shutdown(ctx context.Context) error {
+	if err := s.recovery.Shutdown(ctx); err != nil {
+		return fmt.Errorf("timed out waiting for import job recovery: %w", err)
+	}
+	s.cancelJobs()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobsWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for import jobs: %w", ctx.Err())
+	}
+}
+
 // ===== HELPER FUNCTIONS =====
 
 func (s *importExportService) parseCSVRow(record []string, headerMap map[string]int, rowNum int, creatorID string) (*models.Question, []models.ImportValidationError) {
@@ -540,6 +863,10 @@ func (s *importExportService) parseQuestionContent(questionType models.QuestionT
 		return models.TrueFalseContent{CorrectAnswer: isTrue}, nil
 	case models.Essay:
 		return models.EssayContent{}, nil
+	case models.Numeric:
+		return s.parseNumericContent(getColumn("correct_answer"), rowNum)
+	case models.Formula:
+		return s.parseFormulaContent(getColumn("correct_answer"), rowNum)
 	default:
 		errors = append(errors, models.ImportValidationError{
 			Row: rowNum, Column: "question_type", Message: "unsupported question type", Value: string(questionType),
@@ -612,31 +939,63 @@ func (s *importExportService) parseMultipleChoiceContent(record []string, header
 	}, nil
 }
 
-func (s *importExportService) saveImportedQuestions(ctx context.Context, questions []*models.Question) error {
-	// Begin transaction
-	txRepo, err := s.repo.(repositories.TransactionRepository).Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+// parseNumericContent reads a correct answer such as "9.81", "9.81±0.05 m/s2" or "120 +/- 5% N"
+func (s *importExportService) parseNumericContent(correctAnswer string, rowNum int) (interface{}, []models.ImportValidationError) {
+	invalid := []models.ImportValidationError{{
+		Row: rowNum, Column: "correct_answer", Message: "must be a number, optionally with ±tolerance and a unit", Value: correctAnswer,
+	}}
+
+	quantity, ok := parseQuantity(strings.Replace(correctAnswer, "+/-", "±", 1))
+	if !ok {
+		return nil, invalid
 	}
-	defer func() {
-		if err != nil {
-			txRepo.(repositories.TransactionRepository).Rollback(ctx)
-		}
-	}()
+	content := models.NumericContent{CorrectValue: quantity.Value, Unit: quantity.Unit}
 
-	// Save questions
-	for _, question := range questions {
-		if err := txRepo.Question().Create(ctx, nil, question); err != nil {
-			return fmt.Errorf("failed to create question: %w", err)
+	if rest, hasTolerance := strings.CutPrefix(quantity.Unit, "±"); hasTolerance {
+		tolerance, ok := parseQuantity(rest)
+		if !ok || tolerance.Value < 0 {
+			return nil, invalid
+		}
+		if unit, isPercent := strings.CutPrefix(tolerance.Unit, "%"); isPercent {
+			relative := tolerance.Value / 100
+			content.RelativeTolerance = &relative
+			content.Unit = unit
+		} else {
+			absolute := tolerance.Value
+			content.AbsoluteTolerance = &absolute
+			content.Unit = tolerance.Unit
 		}
 	}
 
-	// Commit transaction
-	if err := txRepo.(repositories.TransactionRepository).Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	return content, nil
+}
+
+// parseFormulaContent reads a correct formula; its variables are sampled from 1 to 10
+func (s *importExportService) parseFormulaContent(correctAnswer string, rowNum int) (interface{}, []models.ImportValidationError) {
+	expression, err := utils.ParseExpression(correctAnswer)
+	if err != nil {
+		return nil, []models.ImportValidationError{{
+			Row: rowNum, Column: "correct_answer", Message: fmt.Sprintf("invalid formula: %s", err.Error()), Value: correctAnswer,
+		}}
 	}
 
-	return nil
+	var variables []models.FormulaVariable
+	for _, name := range expression.Variables() {
+		variables = append(variables, models.FormulaVariable{Name: name, Min: 1, Max: 10})
+	}
+
+	return models.FormulaContent{CorrectFormula: correctAnswer, Variables: variables}, nil
+}
+
+func (s *importExportService) saveImportedQuestions(ctx context.Context, questions []*models.Question) error {
+	return s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		for _, question := range questions {
+			if err := txRepo.Question().Create(ctx, nil, question); err != nil {
+				return fmt.Errorf("failed to create question: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 func (s *importExportService) getQuestionsForExport(ctx context.Context, questionIDs []uint, userID string) ([]*models.Question, error) {
@@ -667,6 +1026,46 @@ func (s *importExportService) getQuestionsForExport(ctx context.Context, questio
 	return questions, nil
 }
 
+func (s *importExportService) auditImport(ctx context.Context, creatorID, format string, result *ImportResult) {
+	questionIDs := make([]uint, 0, len(result.Questions))
+	for _, question := range result.Questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditDataImported,
+		UserID:      creatorID,
+		TargetType:  "question",
+		Description: fmt.Sprintf("Imported %d questions from %s", result.SuccessCount, format),
+		Metadata: map[string]interface{}{
+			"format":        format,
+			"total_rows":    result.TotalRows,
+			"success_count": result.SuccessCount,
+			"error_count":   result.ErrorCount,
+			"question_ids":  questionIDs,
+			"job_id":        result.JobID,
+		},
+	})
+}
+
+func (s *importExportService) auditQuestionExport(ctx context.Context, userID, format string, questions []*models.Question) {
+	questionIDs := make([]uint, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditDataExported,
+		UserID:      userID,
+		TargetType:  "question",
+		Description: fmt.Sprintf("Exported %d questions to %s", len(questions), format),
+		Metadata: map[string]interface{}{
+			"format":       format,
+			"question_ids": questionIDs,
+		},
+	})
+}
+
 func (s *importExportService) questionToCSVRow(question *models.Question) []string {
 	row := make([]string, 12) // 12 columns as defined in headers
 
@@ -703,6 +1102,16 @@ func (s *importExportService) questionToCSVRow(question *models.Question) []stri
 				row[6] = "False"
 			}
 		}
+	} else if question.Type == models.Numeric {
+		var content models.NumericContent
+		if err := json.Unmarshal(question.Content, &content); err == nil {
+			row[6] = formatNumericAnswer(content)
+		}
+	} else if question.Type == models.Formula {
+		var content models.FormulaContent
+		if err := json.Unmarshal(question.Content, &content); err == nil {
+			row[6] = content.CorrectFormula
+		}
 	}
 
 	row[7] = strconv.Itoa(question.Points)
@@ -727,3 +1136,17 @@ func (s *importExportService) questionToCSVRow(question *models.Question) []stri
 
 	return row
 }
+
+// formatNumericAnswer writes a numeric answer the way parseNumericContent reads it
+func formatNumericAnswer(content models.NumericContent) string {
+	answer := strconv.FormatFloat(content.CorrectValue, 'g', -1, 64)
+	if content.AbsoluteTolerance != nil {
+		answer += "±" + strconv.FormatFloat(*content.AbsoluteTolerance, 'g', -1, 64)
+	} else if content.RelativeTolerance != nil {
+		answer += "±" + strconv.FormatFloat(*content.RelativeTolerance*100, 'g', -1, 64) + "%"
+	}
+	if content.Unit != "" {
+		answer += " " + content.Unit
+	}
+	return answer
+}
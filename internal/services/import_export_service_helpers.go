@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxStoredImportErrors caps the row errors persisted on a job; ErrorCount still counts every failed row
+const maxStoredImportErrors = 1000
+
+// errImportClaimLost stops a job whose claim expired and was taken over by another replica
+var errImportClaimLost = errors.New("import job claimed by another replica")
+
+// ===== BACKGROUND IMPORT PROCESSING =====
+
+// runImportJob processes a job in batches. Each batch's questions and the job's progress are
+// committed in one transaction, so an interrupted job resumes exactly after its last batch.
+func (s *importExportService) runImportJob(ctx context.Context, jobID string) {
+	startedAt := time.Now()
+
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		s.logger.Error("Failed to load import job", "job_id", jobID, "error", err)
+		return
+	}
+
+	s.logger.Info("Processing import job", "job_id", job.ID, "file_type", job.FileType, "processed_rows", job.ProcessedRows)
+
+	job.Status = models.ImportProcessing
+	if job.StartedAt == nil {
+		job.StartedAt = &startedAt
+	}
+	if err := s.repo.ImportJob().Update(ctx, nil, job); err != nil {
+		s.logger.Error("Failed to mark import job as processing", "job_id", job.ID, "error", err)
+		return
+	}
+
+	rows, err := s.readImportFile(ctx, job)
+	if err != nil {
+		s.failImportJob(ctx, job, err)
+		return
+	}
+
+	if len(rows) == 0 {
+		s.failImportJob(ctx, job, fmt.Errorf("file has no header row"))
+		return
+	}
+
+	headerMap := buildHeaderMap(rows[0])
+
+	if headerErrors := missingImportColumns(headerMap); len(headerErrors) > 0 {
+		job.Errors, _ = json.Marshal(headerErrors)
+		s.finishImportJob(ctx, job, models.ImportValidationFailed, startedAt)
+		return
+	}
+
+	// Errors of already committed batches when resuming
+	var rowErrors []models.ImportValidationError
+	if len(job.Errors) > 0 {
+		if err := json.Unmarshal(job.Errors, &rowErrors); err != nil {
+			s.logger.Warn("Failed to restore import job errors", "job_id", job.ID, "error", err)
+		}
+	}
+
+	job.TotalRows = len(rows) - 1
+	dataRows := rows[1:]
+
+	for job.ProcessedRows < job.TotalRows {
+		// Stop between batches on shutdown; the job stays in processing and is resumed on restart
+		if ctx.Err() != nil {
+			s.logger.Info("Import job interrupted", "job_id", job.ID, "processed_rows", job.ProcessedRows)
+			return
+		}
+
+		start := job.ProcessedRows
+		end := start + s.config.BatchSize
+		if end > job.TotalRows {
+			end = job.TotalRows
+		}
+
+		var questions []*models.Question
+		batchErrors := 0
+		for i := start; i < end; i++ {
+			question, errs := s.parseCSVRow(dataRows[i], headerMap, i+2, job.UserID)
+			if len(errs) > 0 {
+				rowErrors = append(rowErrors, errs...)
+				batchErrors++
+			} else if question != nil {
+				questions = append(questions, question)
+			}
+		}
+
+		previous := *job
+		job.ProcessedRows = end
+		job.SuccessCount += len(questions)
+		job.ErrorCount += batchErrors
+		job.Progress = end * 100 / job.TotalRows
+		storedErrors := rowErrors
+		if len(storedErrors) > maxStoredImportErrors {
+			storedErrors = storedErrors[:maxStoredImportErrors]
+		}
+		job.Errors, _ = json.Marshal(storedErrors)
+
+		if err := s.saveImportBatch(ctx, job, questions); err != nil {
+			if errors.Is(err, errImportClaimLost) {
+				s.logger.Warn("Import job taken over by another replica", "job_id", job.ID, "processed_rows", previous.ProcessedRows)
+				return
+			}
+			*job = previous
+			s.failImportJob(ctx, job, err)
+			return
+		}
+	}
+
+	status := models.ImportCompleted
+	if job.SuccessCount == 0 && job.ErrorCount > 0 {
+		status = models.ImportValidationFailed
+	}
+	s.finishImportJob(ctx, job, status, startedAt)
+}
+
+// saveImportBatch creates the batch's questions, adds them to the target bank and
+// persists the job's progress in a single transaction. Renewing the claim first keeps a replica
+// that lost it from committing the batch twice.
+func (s *importExportService) saveImportBatch(ctx context.Context, job *models.ImportJob, questions []*models.Question) error {
+	return s.repo.WithTransaction(ctx, func(txRepo repositories.Repository) error {
+		claimed, err := txRepo.ImportJob().Claim(ctx, nil, job.ID, s.owner, s.config.ClaimTTL)
+		if err != nil {
+			return fmt.Errorf("failed to renew import job claim: %w", err)
+		}
+		if !claimed {
+			return errImportClaimLost
+		}
+
+		questionIDs := make([]uint, 0, len(questions))
+		for _, question := range questions {
+			if err := txRepo.Question().Create(ctx, nil, question); err != nil {
+				return fmt.Errorf("failed to create question: %w", err)
+			}
+			questionIDs = append(questionIDs, question.ID)
+		}
+
+		if job.BankID != nil && len(questionIDs) > 0 {
+			if err := txRepo.QuestionBank().AddQuestions(ctx, nil, *job.BankID, questionIDs); err != nil {
+				return fmt.Errorf("failed to add questions to bank: %w", err)
+			}
+		}
+
+		if err := txRepo.ImportJob().Update(ctx, nil, job); err != nil {
+			return fmt.Errorf("failed to update import job progress: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *importExportService) finishImportJob(ctx context.Context, job *models.ImportJob, status models.ImportJobStatus, startedAt time.Time) {
+	completedAt := time.Now()
+	job.Status = status
+	job.Progress = 100
+	job.CompletedAt = &completedAt
+	job.Summary, _ = json.Marshal(map[string]interface{}{
+		"format":           job.FileType,
+		"bank_id":          job.BankID,
+		"duration_seconds": completedAt.Sub(startedAt).Seconds(),
+	})
+
+	if err := s.repo.ImportJob().Update(ctx, nil, job); err != nil {
+		s.logger.Error("Failed to complete import job", "job_id", job.ID, "error", err)
+		return
+	}
+	s.removeImportFile(ctx, job)
+
+	s.logger.Info("Import job finished",
+		"job_id", job.ID,
+		"status", job.Status,
+		"total_rows", job.TotalRows,
+		"success_count", job.SuccessCount,
+		"error_count", job.ErrorCount)
+
+	s.auditImport(ctx, job.UserID, job.FileType, &ImportResult{
+		JobID:         job.ID,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		SuccessCount:  job.SuccessCount,
+		ErrorCount:    job.ErrorCount,
+		Status:        job.Status,
+	})
+}
+
+func (s *importExportService) failImportJob(ctx context.Context, job *models.ImportJob, cause error) {
+	s.logger.Error("Import job failed", "job_id", job.ID, "error", cause)
+
+	completedAt := time.Now()
+	job.Status = models.ImportFailed
+	job.CompletedAt = &completedAt
+	job.Summary, _ = json.Marshal(map[string]interface{}{
+		"format": job.FileType,
+		"error":  cause.Error(),
+	})
+
+	if err := s.repo.ImportJob().Update(ctx, nil, job); err != nil {
+		s.logger.Error("Failed to mark import job as failed", "job_id", job.ID, "error", err)
+		return
+	}
+	s.removeImportFile(ctx, job)
+}
+
+// ===== FILE HANDLING =====
+
+// storeImportFile uploads the file to the shared storage, so any replica can process the job.
+// FilePath holds the object key.
+func (s *importExportService) storeImportFile(ctx context.Context, job *models.ImportJob, file io.Reader) error {
+	job.FilePath = "imports/" + job.ID + "." + job.FileType
+
+	contentType := "text/csv"
+	if job.FileType == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	if err := s.storage.Put(ctx, job.FilePath, file, job.FileSize, contentType); err != nil {
+		return fmt.Errorf("failed to store import file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *importExportService) removeImportFile(ctx context.Context, job *models.ImportJob) {
+	if err := s.storage.Delete(ctx, job.FilePath); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		s.logger.Warn("Failed to remove import file", "job_id", job.ID, "key", job.FilePath, "error", err)
+	}
+}
+
+// readImportFile returns all rows of the stored file, header first
+func (s *importExportService) readImportFile(ctx context.Context, job *models.ImportJob) ([][]string, error) {
+	body, err := s.storage.Get(ctx, job.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer body.Close()
+
+	return readImportRows(job.FileType, body)
+}
+
+// readImportRows reads a csv or xlsx upload into rows, header first
+func readImportRows(fileType string, reader io.Reader) ([][]string, error) {
+	switch fileType {
+	case "csv":
+		csvReader := csv.NewReader(reader)
+		csvReader.TrimLeadingSpace = true
+		csvReader.FieldsPerRecord = -1
+		records, err := csvReader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		return records, nil
+	case "xlsx":
+		f, err := excelize.OpenReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open Excel file: %w", err)
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("workbook has no sheets")
+		}
+		rows, err := f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Excel rows: %w", err)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", fileType)
+	}
+}
+
+func buildHeaderMap(headers []string) map[string]int {
+	headerMap := make(map[string]int, len(headers))
+	for i, header := range headers {
+		headerMap[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	return headerMap
+}
+
+func missingImportColumns(headerMap map[string]int) []models.ImportValidationError {
+	var errors []models.ImportValidationError
+	for _, col := range []string{"question_type", "question_text", "correct_answer"} {
+		if _, exists := headerMap[col]; !exists {
+			errors = append(errors, models.ImportValidationError{
+				Row: 1, Column: col, Message: "missing required column", Code: "missing_column",
+			})
+		}
+	}
+	return errors
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
+	"gorm.io/gorm"
+)
+
+// importRepository keeps import jobs in memory; Claim follows the conditional update in Postgres
+type importRepository struct {
+	repositories.Repository
+	jobs *importJobRepository
+}
+
+func (r *importRepository) ImportJob() repositories.ImportJobRepository { return r.jobs }
+
+type importJobRepository struct {
+	repositories.ImportJobRepository
+
+	mu   sync.Mutex
+	jobs map[string]*models.ImportJob
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, tx *gorm.DB, id string) (*models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *importJobRepository) ListByStatus(ctx context.Context, tx *gorm.DB, statuses []models.ImportJobStatus) ([]*models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []*models.ImportJob
+	for _, job := range r.jobs {
+		for _, status := range statuses {
+			if job.Status == status {
+				copied := *job
+				jobs = append(jobs, &copied)
+			}
+		}
+	}
+	return jobs, nil
+}
+
+func (r *importJobRepository) Update(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *job
+	saved.ClaimedBy, saved.ClaimExpiresAt = r.jobs[job.ID].ClaimedBy, r.jobs[job.ID].ClaimExpiresAt
+	r.jobs[job.ID] = &saved
+	return nil
+}
+
+func (r *importJobRepository) Claim(ctx context.Context, tx *gorm.DB, id, owner string, lease time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job := r.jobs[id]
+	now := time.Now()
+	if job.Status != models.ImportPending && job.Status != models.ImportProcessing {
+		return false, nil
+	}
+	if job.ClaimedBy != nil && *job.ClaimedBy != owner && job.ClaimExpiresAt.After(now) {
+		return false, nil
+	}
+	expiresAt := now.Add(lease)
+	job.ClaimedBy, job.ClaimExpiresAt = &owner, &expiresAt
+	return true, nil
+}
+
+func (r *importJobRepository) ReleaseClaim(ctx context.Context, tx *gorm.DB, id, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job := r.jobs[id]; job.ClaimedBy != nil && *job.ClaimedBy == owner {
+		job.ClaimedBy, job.ClaimExpiresAt = nil, nil
+	}
+	return nil
+}
+
+// importStorage has no stored files, so a job fails right after reading its file; reads counts
+// the replicas that ran it
+type importStorage struct {
+	storage.Storage
+
+	mu    sync.Mutex
+	reads int
+}
+
+func (s *importStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reads++
+	return nil, storage.ErrObjectNotFound
+}
+
+func (s *importStorage) Delete(ctx context.Context, key string) error { return nil }
+
+func newTestImportService(repo *importRepository, fileStorage *importStorage) *importExportService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewImportExportService(repo, fileStorage, logger, nil, ImportJobConfig{}).(*importExportService)
+}
+
+func TestProcessImportJobAsync_Claims(t *testing.T) {
+	other := "other-replica"
+	expired, held := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		claimedBy *string
+		expiresAt *time.Time
+		wantRuns  int
+	}{
+		{"unclaimed job runs", nil, nil, 1},
+		{"job claimed by another replica is skipped", &other, &held, 0},
+		{"expired claim is taken over", &other, &expired, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &importRepository{jobs: &importJobRepository{jobs: map[string]*models.ImportJob{
+				"job": {ID: "job", FileType: "csv", Status: models.ImportProcessing, ClaimedBy: tt.claimedBy, ClaimExpiresAt: tt.expiresAt},
+			}}}
+			fileStorage := &importStorage{}
+			s := newTestImportService(repo, fileStorage)
+
+			if err := s.ProcessImportJobAsync(context.Background(), "job"); err != nil {
+				t.Fatalf("ProcessImportJobAsync() error = %v", err)
+			}
+			s.jobsWG.Wait()
+
+			if fileStorage.reads != tt.wantRuns {
+				t.Errorf("job ran %d times, want %d", fileStorage.reads, tt.wantRuns)
+			}
+			if tt.wantRuns > 0 && repo.jobs.jobs["job"].ClaimedBy != nil {
+				t.Error("claim not released after the job stopped")
+			}
+		})
+	}
+}
+
+func TestResumeImportJobs_ReplicasRunJobOnce(t *testing.T) {
+	repo := &importRepository{jobs: &importJobRepository{jobs: map[string]*models.ImportJob{}}}
+	for _, id := range []string{"a", "b", "c"} {
+		repo.jobs.jobs[id] = &models.ImportJob{ID: id, FileType: "csv", Status: models.ImportPending}
+	}
+
+	// Every replica resumes unfinished jobs on startup
+	fileStorage := &importStorage{}
+	replicas := []*importExportService{newTestImportService(repo, fileStorage), newTestImportService(repo, fileStorage)}
+	for _, s := range replicas {
+		if err := s.ResumeImportJobs(context.Background()); err != nil {
+			t.Fatalf("ResumeImportJobs() error = %v", err)
+		}
+	}
+	for _, s := range replicas {
+		s.jobsWG.Wait()
+	}
+
+	if fileStorage.reads != len(repo.jobs.jobs) {
+		t.Errorf("jobs ran %d times, want %d (each once)", fileStorage.reads, len(repo.jobs.jobs))
+	}
+}
+
+func TestValidateImportFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		content     string
+		wantErr     bool
+		wantStatus  models.ImportJobStatus
+		wantSuccess int
+		wantErrors  []models.ImportValidationError
+	}{
+		{
+			name:        "valid rows",
+			filename:    "questions.csv",
+			content:     "question_type,question_text,correct_answer\ntrue_false,Sky is blue,true\ntrue_false,Grass is red,false\n",
+			wantStatus:  models.ImportCompleted,
+			wantSuccess: 2,
+		},
+		{
+			name:        "invalid row is reported",
+			filename:    "questions.csv",
+			content:     "question_type,question_text,correct_answer\ntrue_false,Sky is blue,true\ntrue_false,Grass is red,maybe\n",
+			wantStatus:  models.ImportValidationFailed,
+			wantSuccess: 1,
+			wantErrors:  []models.ImportValidationError{{Row: 3, Column: "correct_answer", Message: "must be 'true' or 'false'", Value: "maybe"}},
+		},
+		{
+			name:       "missing column",
+			filename:   "questions.csv",
+			content:    "question_type,question_text\ntrue_false,Sky is blue\n",
+			wantStatus: models.ImportValidationFailed,
+			wantErrors: []models.ImportValidationError{{Row: 1, Column: "correct_answer", Message: "missing required column", Code: "missing_column"}},
+		},
+		{
+			name:     "unsupported format",
+			filename: "questions.json",
+			content:  "[]",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Any write goes to the nil repository and panics: a dry run must not save anything
+			s := newTestImportService(&importRepository{}, &importStorage{})
+
+			result, err := s.ValidateImportFile(context.Background(), strings.NewReader(tt.content), tt.filename, "teacher")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ValidateImportFile() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateImportFile() error = %v", err)
+			}
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", result.Status, tt.wantStatus)
+			}
+			if result.SuccessCount != tt.wantSuccess {
+				t.Errorf("SuccessCount = %d, want %d", result.SuccessCount, tt.wantSuccess)
+			}
+			if !reflect.DeepEqual(result.Errors, tt.wantErrors) {
+				t.Errorf("Errors = %+v, want %+v", result.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
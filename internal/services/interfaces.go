@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
@@ -44,22 +45,70 @@ type UpdateAssessmentQuestionRequest struct {
 	QuestionId uint `json:"question_id"`
 	Points     int  `json:"points" validate:"required,min=1,max=100"`       // Required: Actual points for this question in the assessment
 	TimeLimit  *int `json:"time_limit" validate:"omitempty,min=5,max=3600"` // DEPRECATED: Not used in timing logic
+
+	// Negative marking overrides; "none" switches it off for this question only
+	NegativeMarking      *models.NegativeMarkingMode `json:"negative_marking" validate:"omitempty,oneof=none fixed fraction"`
+	NegativeMarkingValue *float64                    `json:"negative_marking_value" validate:"omitempty,min=0,max=100"`
 }
 
 type ReorderQuestionsRequest struct {
 	QuestionOrders []repositories.QuestionOrder `json:"question_orders"`
 }
 
+// AssessmentVersionDiff lists what changed between two assessment versions
+type AssessmentVersionDiff struct {
+	AssessmentID     uint                                `json:"assessment_id"`
+	FromVersion      int                                 `json:"from_version"`
+	ToVersion        int                                 `json:"to_version"`
+	Fields           []FieldChange                       `json:"fields"`
+	AddedQuestions   []models.AssessmentQuestionSnapshot `json:"added_questions"`
+	RemovedQuestions []models.AssessmentQuestionSnapshot `json:"removed_questions"`
+	ChangedQuestions []QuestionVersionChange             `json:"changed_questions"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type QuestionVersionChange struct {
+	QuestionID uint          `json:"question_id"`
+	Changes    []FieldChange `json:"changes"`
+}
+
 // ===== ATTEMPT RELATED DTOs =====
 
 type StartAttemptRequest struct {
 	AssessmentID uint `json:"assessment_id" validate:"required"`
+	ClientSessionInfo
+}
+
+// ClientSessionInfo is the device information reported by the client at start and on each heartbeat;
+// IP address and user agent are taken from the request
+type ClientSessionInfo struct {
+	ScreenResolution  string `json:"screen_resolution" validate:"omitempty,max=32"`
+	DeviceFingerprint string `json:"device_fingerprint" validate:"omitempty,max=255"`
+}
+
+type AttemptHeartbeatRequest struct {
+	ClientSessionInfo
+}
+
+type AttemptHeartbeatResponse struct {
+	AttemptID     uint      `json:"attempt_id"`
+	TimeRemaining int       `json:"time_remaining"` // seconds
+	ServerTime    time.Time `json:"server_time"`
 }
 
 type SubmitAnswerRequest struct {
 	QuestionID uint        `json:"question_id" validate:"required"`
-	AnswerData interface{} `json:"answer" validate:"required"`
+	AnswerData interface{} `json:"answer" validate:"required_without=Omit"`
 	TimeSpent  *int        `json:"time_spent"`
+	// Confidence (1-3) scales reward and penalty when the assessment uses confidence-based marking
+	Confidence *int `json:"confidence" validate:"omitempty,min=1,max=3"`
+	// Omit withdraws the answer so the question scores zero instead of risking a penalty
+	Omit bool `json:"omit"`
 }
 
 type SubmitAttemptRequest struct {
@@ -67,6 +116,40 @@ type SubmitAttemptRequest struct {
 	Answers   []SubmitAnswerRequest `json:"answers" validate:"required,dive"`
 	TimeSpent *int                  `json:"time_spent"`
 	EndReason string                `json:"end_reason"`
+	// ConfirmUnanswered acknowledges unanswered required questions when the assessment asks for confirmation
+	ConfirmUnanswered bool `json:"confirm_unanswered"`
+}
+
+type FlagQuestionRequest struct {
+	QuestionID uint `json:"question_id" validate:"required"`
+	Flagged    bool `json:"flagged"`
+}
+
+type NavigateAttemptRequest struct {
+	QuestionIndex int `json:"question_index" validate:"min=0"`
+}
+
+// AttemptReviewSummary is shown to a student before submitting, questions are in the order the student sees them
+type AttemptReviewSummary struct {
+	AttemptID               uint                   `json:"attempt_id"`
+	IsReview                bool                   `json:"is_review"`
+	CurrentQuestionIndex    int                    `json:"current_question_index"`
+	TotalQuestions          int                    `json:"total_questions"`
+	AnsweredCount           int                    `json:"answered_count"`
+	UnansweredCount         int                    `json:"unanswered_count"`
+	FlaggedCount            int                    `json:"flagged_count"`
+	UnansweredRequiredCount int                    `json:"unanswered_required_count"`
+	RequiresSubmitConfirm   bool                   `json:"requires_submit_confirmation"` // submit must set confirm_unanswered
+	TimeRemaining           *int                   `json:"time_remaining,omitempty"`     // seconds
+	Questions               []ReviewQuestionStatus `json:"questions"`
+}
+
+type ReviewQuestionStatus struct {
+	Index      int  `json:"index"`
+	QuestionID uint `json:"question_id"`
+	Answered   bool `json:"answered"`
+	Flagged    bool `json:"flagged"`
+	Required   bool `json:"required"`
 }
 
 type AttemptResponse struct {
@@ -113,6 +196,13 @@ type QuestionListResponse struct {
 	Size      int                 `json:"size"`
 }
 
+// QuestionVariant is a templated question rendered for one draw of its variables
+type QuestionVariant struct {
+	Values  map[string]float64 `json:"values"`
+	Text    string             `json:"text"`
+	Content json.RawMessage    `json:"content"`
+}
+
 // ===== GRADING RELATED DTOs =====
 
 type GradingResult struct {
@@ -122,6 +212,8 @@ type GradingResult struct {
 	MaxScore      float64   `json:"max_score"`
 	IsCorrect     bool      `json:"is_correct"`
 	PartialCredit bool      `json:"partial_credit"`
+	Provisional   bool      `json:"provisional,omitempty"` // Auto-graded essay awaiting teacher confirmation
+	Confidence    *float64  `json:"confidence,omitempty"`
 	Feedback      *string   `json:"feedback"`
 	GradedAt      time.Time `json:"graded_at"`
 	GradedBy      *string   `json:"graded_by"`
@@ -139,6 +231,201 @@ type AttemptGradingResult struct {
 	GradedBy   string          `json:"graded_by"`
 }
 
+// ===== GRADING SCHEME RELATED DTOs =====
+
+type GradingSchemeRequest struct {
+	Name         string                   `json:"name" validate:"required,min=1,max=100"`
+	Type         models.GradingSchemeType `json:"type" validate:"required,oneof=points percentage letter custom"`
+	PassingScore float64                  `json:"passing_score" validate:"min=0,max=100"` // 0 falls back to the assessment passing score
+	MaxScore     int                      `json:"max_score" validate:"min=0,max=1000"`    // Scale for points schemes, e.g. 10 for a 10-point scale
+	GradeRanges  []models.GradeRange      `json:"grade_ranges"`                           // Required for letter and custom schemes
+	RoundTo      *int                     `json:"round_to" validate:"omitempty,min=0,max=4"`
+	BonusPoints  float64                  `json:"bonus_points" validate:"min=0"`
+	CurvePoints  float64                  `json:"curve_points" validate:"min=0,max=100"`
+}
+
+// AssignGradingSchemeRequest assigns a scheme to an assessment, either by copying a template or from an inline definition
+type AssignGradingSchemeRequest struct {
+	TemplateID *uint                 `json:"template_id"`
+	Scheme     *GradingSchemeRequest `json:"scheme"`
+}
+
+// ===== CATEGORY RELATED DTOs =====
+
+type CreateCategoryRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Color       string  `json:"color" validate:"omitempty,hexcolor"`
+	Icon        *string `json:"icon" validate:"omitempty,max=50"`
+	ParentID    *uint   `json:"parent_id"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Color       *string `json:"color" validate:"omitempty,hexcolor"`
+	Icon        *string `json:"icon" validate:"omitempty,max=50"`
+}
+
+// MoveCategoryRequest re-parents a category; a nil ParentID moves it to the root
+type MoveCategoryRequest struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+type CategoryTreeNode struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Description *string             `json:"description"`
+	Color       string              `json:"color"`
+	Icon        *string             `json:"icon"`
+	ParentID    *uint               `json:"parent_id"`
+	Level       int                 `json:"level"`
+	Path        string              `json:"path"`
+	DirectCount int                 `json:"direct_count"` // Questions directly in this category
+	TotalCount  int                 `json:"total_count"`  // Including subcategories
+	Children    []*CategoryTreeNode `json:"children"`
+}
+
+type DeleteCategoryResponse struct {
+	ReassignedQuestions int64 `json:"reassigned_questions"`
+}
+
+// ===== ATTACHMENT RELATED DTOs =====
+
+type AttachmentMetadataRequest struct {
+	Alt     *string `json:"alt" form:"alt" validate:"omitempty,max=255"`
+	Caption *string `json:"caption" form:"caption" validate:"omitempty,max=2000"`
+}
+
+type ReorderAttachmentsRequest struct {
+	Orders []repositories.AttachmentOrder `json:"orders" validate:"required,min=1,max=100"`
+}
+
+// ===== PROCTORING RELATED DTOs =====
+
+type ProctoringEventRequest struct {
+	Type          models.ProctoringEventType `json:"type" validate:"required,oneof=tab_switch window_blur fullscreen_exit multiple_faces no_face suspicious_object audio_detection right_click copy_paste screenshot"`
+	Severity      *int                       `json:"severity" validate:"omitempty,min=1,max=5"`
+	Data          map[string]interface{}     `json:"data"`
+	QuestionID    *uint                      `json:"question_id"`
+	OccurredAt    *time.Time                 `json:"occurred_at"`
+	ScreenshotURL *string                    `json:"screenshot_url" validate:"omitempty,url,max=2048"`
+	VideoURL      *string                    `json:"video_url" validate:"omitempty,url,max=2048"`
+	AudioURL      *string                    `json:"audio_url" validate:"omitempty,url,max=2048"`
+}
+
+type RecordProctoringEventsRequest struct {
+	Events []ProctoringEventRequest `json:"events" validate:"required,min=1,max=100,dive"`
+
+	// Populated by the handler from the HTTP request
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type RecordProctoringEventsResponse struct {
+	AttemptID uint `json:"attempt_id"`
+	Accepted  int  `json:"accepted"`
+	Ignored   int  `json:"ignored"` // Events for checks that are disabled in the assessment settings
+}
+
+type ReviewProctoringEventRequest struct {
+	ReviewStatus string  `json:"review_status" validate:"required,oneof=pending reviewed dismissed"`
+	ReviewNotes  *string `json:"review_notes" validate:"omitempty,max=2000"`
+}
+
+type ProctoringEventListResponse struct {
+	Events []*models.ProctoringEvent `json:"events"`
+	Total  int64                     `json:"total"`
+	Page   int                       `json:"page"`
+	Size   int                       `json:"size"`
+}
+
+// ===== AUDIT RELATED DTOs =====
+
+// AuditEntry describes an auditable action; actor details and request context are filled in by the audit service
+type AuditEntry struct {
+	EventType       models.AuditEventType
+	UserID          string
+	TargetType      string
+	TargetID        *uint
+	Description     string
+	Before          interface{}
+	After           interface{}
+	Metadata        map[string]interface{}
+	ComplianceLevel string
+}
+
+type AuditLogListResponse struct {
+	Logs  []*models.AuditLog `json:"logs"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Size  int                `json:"size"`
+}
+
+// ===== NOTIFICATION RELATED DTOs =====
+
+type NotificationListResponse struct {
+	Notifications []*models.Notification `json:"notifications"`
+	Total         int64                  `json:"total"`
+	Unread        int64                  `json:"unread"`
+	Page          int                    `json:"page"`
+	Size          int                    `json:"size"`
+}
+
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// ===== CLASS RELATED DTOs =====
+
+type CreateClassRequest struct {
+	Name        string   `json:"name" validate:"required,min=1,max=200"`
+	Code        *string  `json:"code" validate:"omitempty,max=50"`
+	Description *string  `json:"description" validate:"omitempty,max=2000"`
+	StudentIDs  []string `json:"student_ids" validate:"omitempty,max=500,dive,required,max=255"` // Initial roster
+}
+
+type UpdateClassRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=200"`
+	Code        *string `json:"code" validate:"omitempty,max=50"`
+	Description *string `json:"description" validate:"omitempty,max=2000"`
+	IsActive    *bool   `json:"is_active"` // Inactive classes keep their roster but grant no access
+}
+
+type ClassListResponse struct {
+	Classes []*models.Class `json:"classes"`
+	Total   int64           `json:"total"`
+	Page    int             `json:"page"`
+	Size    int             `json:"size"`
+}
+
+type ClassMembersRequest struct {
+	StudentIDs []string `json:"student_ids" validate:"required,min=1,max=500,dive,required,max=255"`
+}
+
+// AddClassMembersResponse reports how many students were enrolled; Rejected lists IDs that are not students
+type AddClassMembersResponse struct {
+	Added    int64    `json:"added"`
+	Rejected []string `json:"rejected,omitempty"`
+}
+
+// AssignAssessmentRequest assigns an assessment to classes and/or individual students
+type AssignAssessmentRequest struct {
+	ClassIDs   []uint   `json:"class_ids" validate:"omitempty,max=100"`
+	StudentIDs []string `json:"student_ids" validate:"omitempty,max=500,dive,required,max=255"`
+}
+
+// ===== ANALYTICS RELATED DTOs =====
+
+type AssessmentAnalyticsResponse struct {
+	Analytics *models.AssessmentAnalytics `json:"analytics"`
+	Questions []*models.QuestionAnalytics `json:"questions"`
+}
+
 // ===== QUESTION BANK RELATED DTOs =====
 
 type CreateQuestionBankRequest struct {
@@ -206,6 +493,7 @@ type AssessmentService interface {
 	UpdateStatus(ctx context.Context, id uint, req *UpdateStatusRequest, userID string) error
 	Publish(ctx context.Context, id uint, userID string) error
 	Archive(ctx context.Context, id uint, userID string) error
+	ApplyScheduledStatus(ctx context.Context, id uint, status models.AssessmentStatus, reason string) error // Used by the lifecycle scheduler
 
 	// Question management
 	AddQuestion(ctx context.Context, assessmentID, questionID uint, order int, points int, userID string) error
@@ -218,6 +506,11 @@ type AssessmentService interface {
 	UpdateAssessmentQuestionBatch(ctx context.Context, assessmentID uint, reqs []UpdateAssessmentQuestionRequest, userID string) error
 	UpdateAssessmentQuestion(ctx context.Context, assessmentID, questionID uint, req *UpdateAssessmentQuestionRequest, userID string) error
 
+	// Version snapshots
+	ListVersions(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentVersion, error)
+	GetVersion(ctx context.Context, assessmentID uint, version int, userID string) (*models.AssessmentVersion, error)
+	DiffVersions(ctx context.Context, assessmentID uint, fromVersion, toVersion int, userID string) (*AssessmentVersionDiff, error)
+
 	// Statistics and analytics
 	GetStats(ctx context.Context, id uint, userID string) (*repositories.AssessmentStats, error)
 	GetCreatorStats(ctx context.Context, creatorID string) (*repositories.CreatorStats, error)
@@ -256,6 +549,9 @@ type QuestionService interface {
 	GetStats(ctx context.Context, questionID uint, userID string) (*repositories.QuestionStats, error)
 	GetUsageStats(ctx context.Context, creatorID string) (*repositories.QuestionUsageStats, error)
 
+	// Templated questions
+	PreviewVariants(ctx context.Context, questionID uint, count int, userID string) ([]QuestionVariant, error)
+
 	// Permission checks
 	CanAccess(ctx context.Context, questionID uint, userID string) (bool, error)
 	CanEdit(ctx context.Context, questionID uint, userID string) (bool, error)
@@ -321,6 +617,18 @@ type AttemptService interface {
 	ExtendTime(ctx context.Context, attemptID uint, minutes int, userID string) error
 	HandleTimeout(ctx context.Context, attemptID uint) error
 
+	// Session tracking
+	Heartbeat(ctx context.Context, attemptID uint, req *AttemptHeartbeatRequest, studentID string) (*AttemptHeartbeatResponse, error)
+
+	// Flagging and review
+	FlagQuestion(ctx context.Context, attemptID uint, req *FlagQuestionRequest, studentID string) error
+	EnterReview(ctx context.Context, attemptID uint, studentID string) (*AttemptReviewSummary, error)
+	GetReviewSummary(ctx context.Context, attemptID uint, studentID string) (*AttemptReviewSummary, error)
+	NavigateToQuestion(ctx context.Context, attemptID uint, req *NavigateAttemptRequest, studentID string) error
+
+	// Answer history
+	GetAnswerHistory(ctx context.Context, attemptID, answerID uint, userID string) ([]repositories.AnswerHistoryEntry, error)
+
 	// Validation
 	CanStart(ctx context.Context, assessmentID uint, studentID string) (bool, error)
 	GetAttemptCount(ctx context.Context, assessmentID uint, studentID string) (int, error)
@@ -354,6 +662,99 @@ type GradingService interface {
 	GetGradingOverview(ctx context.Context, assessmentID uint, userID string) (*repositories.GradingStats, error)
 }
 
+type GradingSchemeService interface {
+	// Organization-level templates
+	CreateTemplate(ctx context.Context, req *GradingSchemeRequest, userID string) (*models.GradingScheme, error)
+	GetTemplate(ctx context.Context, id uint, userID string) (*models.GradingScheme, error)
+	ListTemplates(ctx context.Context, userID string) ([]*models.GradingScheme, error)
+	UpdateTemplate(ctx context.Context, id uint, req *GradingSchemeRequest, userID string) (*models.GradingScheme, error)
+	DeleteTemplate(ctx context.Context, id uint, userID string) error
+
+	// Per-assessment schemes
+	GetAssessmentScheme(ctx context.Context, assessmentID uint, userID string) (*models.GradingScheme, error)
+	AssignToAssessment(ctx context.Context, assessmentID uint, req *AssignGradingSchemeRequest, userID string) (*models.GradingScheme, error)
+	RemoveFromAssessment(ctx context.Context, assessmentID uint, userID string) error
+}
+
+type CategoryService interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, req *CreateCategoryRequest, userID string) (*models.QuestionCategory, error)
+	GetByID(ctx context.Context, id uint, userID string) (*models.QuestionCategory, error)
+	Update(ctx context.Context, id uint, req *UpdateCategoryRequest, userID string) (*models.QuestionCategory, error)
+	Delete(ctx context.Context, id uint, reassignTo *uint, userID string) (*DeleteCategoryResponse, error)
+
+	// Tree operations
+	GetTree(ctx context.Context, userID string) ([]*CategoryTreeNode, error)
+	GetPath(ctx context.Context, id uint, userID string) ([]*models.QuestionCategory, error)
+	Move(ctx context.Context, id uint, req *MoveCategoryRequest, userID string) (*models.QuestionCategory, error)
+
+	// Statistics
+	GetStats(ctx context.Context, id uint, userID string) (*repositories.CategoryStats, error)
+}
+
+type ProctoringService interface {
+	// Event ingestion (student)
+	RecordEvents(ctx context.Context, attemptID uint, req *RecordProctoringEventsRequest, studentID string) (*RecordProctoringEventsResponse, error)
+
+	// Review (proctor/teacher)
+	GetAttemptEvents(ctx context.Context, attemptID uint, filters repositories.ProctoringEventFilters, userID string) (*ProctoringEventListResponse, error)
+	GetAssessmentEvents(ctx context.Context, assessmentID uint, filters repositories.ProctoringEventFilters, userID string) (*ProctoringEventListResponse, error)
+	GetAttemptSummary(ctx context.Context, attemptID uint, userID string) (*repositories.ProctoringSummary, error)
+	ReviewEvent(ctx context.Context, eventID uint, req *ReviewProctoringEventRequest, reviewerID string) (*models.ProctoringEvent, error)
+}
+
+type AuditService interface {
+	// Recording
+	Log(ctx context.Context, entry *AuditEntry) error
+
+	// Query (admin)
+	List(ctx context.Context, filters repositories.AuditLogFilters, userID string) (*AuditLogListResponse, error)
+	GetByID(ctx context.Context, id uint, userID string) (*models.AuditLog, error)
+}
+
+type AnalyticsService interface {
+	// Recalculation (internal, no permission checks)
+	RecalculateAssessment(ctx context.Context, assessmentID uint) (*AssessmentAnalyticsResponse, error)
+	RecalculateQuestion(ctx context.Context, questionID uint) (*models.QuestionAnalytics, error)
+
+	// Query and on-demand refresh
+	GetAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsResponse, error)
+	GetQuestionAnalytics(ctx context.Context, questionID uint, userID string) (*models.QuestionAnalytics, error)
+	RefreshAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsResponse, error)
+	RefreshQuestionAnalytics(ctx context.Context, questionID uint, userID string) (*models.QuestionAnalytics, error)
+}
+
+type NotificationService interface {
+	// Inbox
+	List(ctx context.Context, filters repositories.NotificationFilters, userID string) (*NotificationListResponse, error)
+	GetUnreadCount(ctx context.Context, userID string) (*UnreadCountResponse, error)
+
+	// Read state
+	MarkRead(ctx context.Context, id uint, userID string) (*models.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (*MarkAllReadResponse, error)
+
+	// Delete removes a direct notification; role broadcasts are only hidden for the user
+	Delete(ctx context.Context, id uint, userID string) error
+}
+
+type ClassService interface {
+	// Class management
+	Create(ctx context.Context, req *CreateClassRequest, userID string) (*models.Class, error)
+	GetByID(ctx context.Context, id uint, userID string) (*models.Class, error)
+	Update(ctx context.Context, id uint, req *UpdateClassRequest, userID string) (*models.Class, error)
+	Delete(ctx context.Context, id uint, userID string) error
+	List(ctx context.Context, filters repositories.ClassFilters, userID string) (*ClassListResponse, error)
+
+	// Roster
+	AddMembers(ctx context.Context, id uint, req *ClassMembersRequest, userID string) (*AddClassMembersResponse, error)
+	RemoveMember(ctx context.Context, id uint, studentID string, userID string) error
+
+	// Assessment assignment
+	AssignAssessment(ctx context.Context, assessmentID uint, req *AssignAssessmentRequest, userID string) ([]*models.AssessmentAssignment, error)
+	GetAssessmentAssignments(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentAssignment, error)
+	UnassignAssessment(ctx context.Context, assessmentID, assignmentID uint, userID string) error
+}
+
 // ===== SERVICE MANAGER =====
 
 type ServiceManager interface {
@@ -365,11 +766,22 @@ type ServiceManager interface {
 	Grading() GradingService
 	Dashboard() DashboardService
 	Student() StudentService
+	Proctoring() ProctoringService
+	Audit() AuditService
+	GradingScheme() GradingSchemeService
+	Analytics() AnalyticsService
+	Category() CategoryService
+	Attachment() AttachmentService
 
 	// Additional service getters
 	ImportExport() ImportExportService
-	// Notification() NotificationService
-	// Analytics() AnalyticsService
+	Notification() NotificationService
+	NotificationEvents() NotificationEventService
+	Class() ClassService
+
+	// Request rate limiting
+	RateLimiter() *cache.RateLimiter
+	RateLimitRule(name string) (RateLimit, bool)
 
 	// Health and lifecycle
 	Initialize(ctx context.Context) error
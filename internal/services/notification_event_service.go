@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/events"
@@ -75,7 +77,7 @@ func (s *notificationEventService) NotifyAssessmentPublished(ctx context.Context
 		return fmt.Errorf("failed to get assessment: %w", err)
 	}
 
-	// Get enrolled students (placeholder - implement based on your enrollment system)
+	// Get students the assessment is assigned to
 	studentIDs := s.getEnrolledStudentIDs(ctx, assessmentID)
 
 	// Create and publish event
@@ -88,6 +90,16 @@ func (s *notificationEventService) NotifyAssessmentPublished(ctx context.Context
 		assessment.CreatedBy,
 	)
 
+	s.saveInApp(ctx, studentIDs, &models.Notification{
+		Type:         models.NotificationAssessmentPublished,
+		Title:        fmt.Sprintf("New assessment: %s", assessment.Title),
+		Message:      fmt.Sprintf("%s is now available.", assessment.Title),
+		AssessmentID: &assessmentID,
+		Priority:     int(models.PriorityNormal),
+		ExpiresAt:    assessment.DueDate,
+		CreatedBy:    assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -102,6 +114,10 @@ func (s *notificationEventService) NotifyAssessmentExpiring(ctx context.Context,
 		return fmt.Errorf("failed to get assessment: %w", err)
 	}
 
+	if assessment.DueDate == nil {
+		return fmt.Errorf("assessment %d has no due date", assessmentID)
+	}
+
 	// Get enrolled students who haven't completed the assessment
 	studentIDs := s.getStudentsWithIncompleteAssessment(ctx, assessmentID)
 
@@ -121,6 +137,16 @@ func (s *notificationEventService) NotifyAssessmentExpiring(ctx context.Context,
 		},
 	}
 
+	s.saveInApp(ctx, studentIDs, &models.Notification{
+		Type:         models.NotificationAssessmentDue,
+		Title:        fmt.Sprintf("Assessment due soon: %s", assessment.Title),
+		Message:      fmt.Sprintf("%s is due in %d hour(s).", assessment.Title, hoursRemaining),
+		AssessmentID: &assessmentID,
+		Priority:     int(models.PriorityHigh),
+		ExpiresAt:    assessment.DueDate,
+		CreatedBy:    assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -152,6 +178,15 @@ func (s *notificationEventService) NotifyAssessmentExpired(ctx context.Context,
 		},
 	}
 
+	s.saveInApp(ctx, append(studentIDs, assessment.CreatedBy), &models.Notification{
+		Type:         models.NotificationAssessmentExpired,
+		Title:        fmt.Sprintf("Assessment closed: %s", assessment.Title),
+		Message:      fmt.Sprintf("%s has passed its due date and no longer accepts attempts.", assessment.Title),
+		AssessmentID: &assessmentID,
+		Priority:     int(models.PriorityNormal),
+		CreatedBy:    assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -176,6 +211,16 @@ func (s *notificationEventService) NotifyAttemptStarted(ctx context.Context, att
 		&attempt.Assessment.Duration,
 	)
 
+	s.saveInApp(ctx, []string{attempt.StudentID}, &models.Notification{
+		Type:         models.NotificationAttemptStarted,
+		Title:        fmt.Sprintf("Attempt started: %s", attempt.Assessment.Title),
+		Message:      fmt.Sprintf("You started %s. Good luck!", attempt.Assessment.Title),
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attemptID,
+		Priority:     int(models.PriorityLow),
+		CreatedBy:    attempt.Assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -207,6 +252,16 @@ func (s *notificationEventService) NotifyAttemptSubmitted(ctx context.Context, a
 		},
 	}
 
+	s.saveInApp(ctx, []string{attempt.StudentID}, &models.Notification{
+		Type:         models.NotificationAttemptSubmitted,
+		Title:        fmt.Sprintf("Attempt submitted: %s", attempt.Assessment.Title),
+		Message:      fmt.Sprintf("Your answers for %s have been submitted.", attempt.Assessment.Title),
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attemptID,
+		Priority:     int(models.PriorityLow),
+		CreatedBy:    attempt.Assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -240,6 +295,17 @@ func (s *notificationEventService) NotifyAttemptGraded(ctx context.Context, atte
 		},
 	}
 
+	s.saveInApp(ctx, []string{attempt.StudentID}, &models.Notification{
+		Type:  models.NotificationResultAvailable,
+		Title: fmt.Sprintf("Results available: %s", attempt.Assessment.Title),
+		Message: fmt.Sprintf("You scored %.1f/%d (%.1f%%) on %s.",
+			attempt.Score, attempt.MaxScore, attempt.Percentage, attempt.Assessment.Title),
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attemptID,
+		Priority:     int(models.PriorityHigh),
+		CreatedBy:    attempt.Assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -271,6 +337,19 @@ func (s *notificationEventService) NotifyAttemptTimeWarning(ctx context.Context,
 		},
 	}
 
+	// The warning is meaningless once the attempt has timed out
+	expiresAt := time.Now().Add(time.Duration(minutesRemaining) * time.Minute)
+	s.saveInApp(ctx, []string{attempt.StudentID}, &models.Notification{
+		Type:         models.NotificationTimeWarning,
+		Title:        fmt.Sprintf("%d minute(s) remaining", minutesRemaining),
+		Message:      fmt.Sprintf("Your attempt at %s ends in %d minute(s).", attempt.Assessment.Title, minutesRemaining),
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attemptID,
+		Priority:     int(models.PriorityCritical),
+		ExpiresAt:    &expiresAt,
+		CreatedBy:    attempt.Assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -306,6 +385,15 @@ func (s *notificationEventService) NotifyGradingCompleted(ctx context.Context, a
 		},
 	}
 
+	s.saveInApp(ctx, []string{assessment.CreatedBy}, &models.Notification{
+		Type:         models.NotificationGradingCompleted,
+		Title:        fmt.Sprintf("Grading completed: %s", assessment.Title),
+		Message:      fmt.Sprintf("All %d attempt(s) of %s have been graded.", stats.TotalAttempts, assessment.Title),
+		AssessmentID: &assessmentID,
+		Priority:     int(models.PriorityNormal),
+		CreatedBy:    assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -344,6 +432,15 @@ func (s *notificationEventService) NotifyManualGradingRequired(ctx context.Conte
 		},
 	}
 
+	s.saveInApp(ctx, append(graderIDs, assessment.CreatedBy), &models.Notification{
+		Type:         models.NotificationGradingRequired,
+		Title:        fmt.Sprintf("Manual grading required: %s", assessment.Title),
+		Message:      fmt.Sprintf("%d question(s) in %s need manual grading.", questionCount, assessment.Title),
+		AssessmentID: &assessmentID,
+		Priority:     int(models.PriorityHigh),
+		CreatedBy:    assessment.CreatedBy,
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
@@ -367,31 +464,103 @@ func (s *notificationEventService) SendBulkNotification(ctx context.Context, use
 		"0", // TODO: Get sender ID from context
 	)
 
+	recipientIDs := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		recipientIDs[i] = strconv.FormatUint(uint64(userID), 10)
+	}
+	s.saveInApp(ctx, recipientIDs, &models.Notification{
+		Type:         notification.Type,
+		Title:        notification.Title,
+		Message:      notification.Message,
+		Priority:     int(notification.Priority),
+		ScheduledFor: notification.ScheduledAt,
+		CreatedBy:    "0",
+	})
+
 	return s.eventPublisher.PublishNotificationEvent(ctx, event)
 }
 
 // ===== HELPER METHODS =====
 
-// These methods should be implemented based on your specific business logic
-// For now, they return placeholder data
+// saveInApp stores an in-app copy of an event for each recipient so it appears in their inbox.
+// Failures are only logged; the event is still published.
+func (s *notificationEventService) saveInApp(ctx context.Context, recipientIDs []string, template *models.Notification) {
+	channels, _ := json.Marshal([]string{"in_app"})
+	now := time.Now()
+	seen := make(map[string]bool, len(recipientIDs))
+
+	notifications := make([]*models.Notification, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		if recipientID == "" || seen[recipientID] {
+			continue
+		}
+		seen[recipientID] = true
+
+		notification := *template
+		notification.RecipientID = &recipientID
+		notification.Channels = channels
+		notification.SentAt = &now
+		notification.DeliveryStatus = "delivered"
+		notifications = append(notifications, &notification)
+	}
+
+	if err := s.repo.Notification().CreateBatch(ctx, nil, notifications); err != nil {
+		s.logger.Error("Failed to store in-app notifications",
+			"notification_type", template.Type,
+			"recipient_count", len(notifications),
+			"error", err)
+	}
+}
 
+// getEnrolledStudentIDs returns the students an assessment is assigned to, directly or through their classes
 func (s *notificationEventService) getEnrolledStudentIDs(ctx context.Context, assessmentID uint) []string {
-	// TODO: Implement based on your enrollment/class management system
-	// This might involve querying a separate enrollment service or database table
-	s.logger.Debug("Getting enrolled student IDs", "assessment_id", assessmentID)
-	return []string{} // Placeholder
+	studentIDs, err := s.repo.Class().GetAssignedStudentIDs(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Error("Failed to get assigned students", "assessment_id", assessmentID, "error", err)
+		return []string{}
+	}
+	return studentIDs
 }
 
+// getStudentsWithIncompleteAssessment returns assigned students who have not finished an attempt,
+// including those who never started one
 func (s *notificationEventService) getStudentsWithIncompleteAssessment(ctx context.Context, assessmentID uint) []string {
-	// TODO: Query students who are enrolled but haven't completed the assessment
-	s.logger.Debug("Getting students with incomplete assessment", "assessment_id", assessmentID)
-	return []string{} // Placeholder
+	attempts, _, err := s.repo.Attempt().GetByAssessment(ctx, nil, assessmentID, repositories.AttemptFilters{})
+	if err != nil {
+		s.logger.Error("Failed to get assessment attempts", "assessment_id", assessmentID, "error", err)
+		return []string{}
+	}
+
+	finished := make(map[string]bool)
+	for _, attempt := range attempts {
+		if attempt.Status != models.AttemptInProgress {
+			finished[attempt.StudentID] = true
+		}
+	}
+
+	studentIDs := []string{}
+	for _, studentID := range s.getEnrolledStudentIDs(ctx, assessmentID) {
+		if !finished[studentID] {
+			studentIDs = append(studentIDs, studentID)
+		}
+	}
+	return studentIDs
 }
 
+// requiresManualGrading reports whether the attempt has answers only a grader can score, such as essays
 func (s *notificationEventService) requiresManualGrading(ctx context.Context, attemptID uint) bool {
-	// TODO: Check if the attempt has essay questions or other manually graded components
-	s.logger.Debug("Checking if attempt requires manual grading", "attempt_id", attemptID)
-	return false // Placeholder
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		s.logger.Error("Failed to get attempt answers", "attempt_id", attemptID, "error", err)
+		return false
+	}
+
+	for _, answer := range answers {
+		if !answer.IsGraded && !isAutoGradeableType(answer.Question.Type) {
+			return true
+		}
+	}
+	return false
 }
 
 type GradingStats struct {
@@ -401,19 +570,70 @@ type GradingStats struct {
 }
 
 func (s *notificationEventService) getGradingStats(ctx context.Context, assessmentID uint) GradingStats {
-	// TODO: Get actual grading statistics from the database
-	s.logger.Debug("Getting grading statistics", "assessment_id", assessmentID)
-	return GradingStats{} // Placeholder
+	stats := GradingStats{}
+
+	attemptStats, err := s.repo.Attempt().GetAssessmentAttemptStats(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Error("Failed to get attempt statistics", "assessment_id", assessmentID, "error", err)
+	} else {
+		stats.TotalAttempts = attemptStats.TotalAttempts
+	}
+
+	// Auto and manual counts are per answer: an attempt mixes auto-graded and essay answers
+	answerStats, err := s.repo.Answer().GetGradingStats(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Error("Failed to get grading statistics", "assessment_id", assessmentID, "error", err)
+	} else {
+		stats.AutoGradedCount = answerStats.AutoGraded
+		stats.ManualGradedCount = answerStats.ManualGraded
+	}
+
+	return stats
 }
 
+// getPendingManualGradingAttempts returns finished attempts that still wait for a grader
 func (s *notificationEventService) getPendingManualGradingAttempts(ctx context.Context, assessmentID uint) []uint {
-	// TODO: Query attempts that need manual grading
-	s.logger.Debug("Getting pending manual grading attempts", "assessment_id", assessmentID)
-	return []uint{} // Placeholder
+	attempts, _, err := s.repo.Attempt().GetByAssessment(ctx, nil, assessmentID, repositories.AttemptFilters{})
+	if err != nil {
+		s.logger.Error("Failed to get assessment attempts", "assessment_id", assessmentID, "error", err)
+		return []uint{}
+	}
+
+	attemptIDs := []uint{}
+	for _, attempt := range attempts {
+		if attempt.Status != models.AttemptInProgress && !attempt.IsGraded {
+			attemptIDs = append(attemptIDs, attempt.ID)
+		}
+	}
+	return attemptIDs
 }
 
+// getAvailableGraderIDs returns who may grade the assessment; only its creator has grading rights
 func (s *notificationEventService) getAvailableGraderIDs(ctx context.Context, assessmentID uint) []string {
-	// TODO: Get teachers/admins who can grade this assessment
-	s.logger.Debug("Getting available grader IDs", "assessment_id", assessmentID)
-	return []string{} // Placeholder
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Error("Failed to get assessment", "assessment_id", assessmentID, "error", err)
+		return []string{}
+	}
+	return []string{assessment.CreatedBy}
+}
+
+// notificationTimeout bounds a background notification, including its database reads
+const notificationTimeout = 30 * time.Second
+
+// notifyAsync sends a notification in the background so a slow or unavailable broker never delays
+// or fails the operation that triggered it. A nil notifier turns notifications off.
+func notifyAsync(notifier NotificationEventService, logger *slog.Logger, name string, send func(ctx context.Context, notifier NotificationEventService) error) {
+	if notifier == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+
+		if err := send(ctx, notifier); err != nil {
+			logger.Error("Failed to send notification", "notification", name, "error", err)
+		}
+	}()
 }
@@ -10,15 +10,32 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
 )
 
 // MockRepository for testing - minimal implementation
-type MockNotificationRepository struct{}
+type MockNotificationRepository struct {
+	inbox *mockInboxRepository
+}
+
+// mockInboxRepository records the in-app notifications stored by the event service
+type mockInboxRepository struct {
+	repositories.NotificationRepository
+	created []*models.Notification
+}
+
+func (m *mockInboxRepository) CreateBatch(ctx context.Context, tx *gorm.DB, notifications []*models.Notification) error {
+	m.created = append(m.created, notifications...)
+	return nil
+}
 
 func (m *MockNotificationRepository) Assessment() repositories.AssessmentRepository { return nil }
 func (m *MockNotificationRepository) AssessmentSettings() repositories.AssessmentSettingsRepository {
 	return nil
 }
+func (m *MockNotificationRepository) AssessmentVersion() repositories.AssessmentVersionRepository {
+	return nil
+}
 func (m *MockNotificationRepository) Question() repositories.QuestionRepository { return nil }
 func (m *MockNotificationRepository) QuestionCategory() repositories.QuestionCategoryRepository {
 	return nil
@@ -34,6 +51,30 @@ func (m *MockNotificationRepository) Answer() repositories.AnswerRepository
 func (m *MockNotificationRepository) User() repositories.UserRepository                 { return nil }
 func (m *MockNotificationRepository) QuestionBank() repositories.QuestionBankRepository { return nil }
 func (m *MockNotificationRepository) Dashboard() repositories.DashboardRepository       { return nil }
+func (m *MockNotificationRepository) ProctoringEvent() repositories.ProctoringEventRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Audit() repositories.AuditRepository {
+	return nil
+}
+func (m *MockNotificationRepository) GradingScheme() repositories.GradingSchemeRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Analytics() repositories.AnalyticsRepository {
+	return nil
+}
+func (m *MockNotificationRepository) ImportJob() repositories.ImportJobRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Notification() repositories.NotificationRepository {
+	if m.inbox == nil {
+		m.inbox = &mockInboxRepository{}
+	}
+	return m.inbox
+}
+func (m *MockNotificationRepository) Class() repositories.ClassRepository {
+	return nil
+}
 func (m *MockNotificationRepository) WithTransaction(ctx context.Context, fn func(repositories.Repository) error) error {
 	return nil
 }
@@ -85,6 +126,14 @@ func TestNotificationEventService_PublishEvents(t *testing.T) {
 
 		// Check event data - BulkNotificationEvent is stored in Data field
 		// We need to assert it properly based on the actual structure
+
+		// Verify an in-app notification was stored for every recipient
+		if len(mockRepo.inbox.created) != len(userIDs) {
+			t.Fatalf("Expected %d in-app notifications, got %d", len(userIDs), len(mockRepo.inbox.created))
+		}
+		if got := *mockRepo.inbox.created[0].RecipientID; got != "1" {
+			t.Errorf("Expected recipient '1', got '%s'", got)
+		}
 	})
 
 	t.Run("Event_Structure_Validation", func(t *testing.T) {
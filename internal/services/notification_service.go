@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type notificationService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewNotificationService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) NotificationService {
+	return &notificationService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== INBOX =====
+
+func (s *notificationService) List(ctx context.Context, filters repositories.NotificationFilters, userID string) (*NotificationListResponse, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, total, err := s.repo.Notification().ListForUser(ctx, nil, userID, role, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	unread, err := s.repo.Notification().CountUnread(ctx, nil, userID, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &NotificationListResponse{
+		Notifications: notifications,
+		Total:         total,
+		Unread:        unread,
+		Page:          (filters.Offset / max(filters.Limit, 1)) + 1,
+		Size:          filters.Limit,
+	}, nil
+}
+
+func (s *notificationService) GetUnreadCount(ctx context.Context, userID string) (*UnreadCountResponse, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.repo.Notification().CountUnread(ctx, nil, userID, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &UnreadCountResponse{Unread: unread}, nil
+}
+
+// ===== READ STATE =====
+
+func (s *notificationService) MarkRead(ctx context.Context, id uint, userID string) (*models.Notification, error) {
+	notification, err := s.getVisibleNotification(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.ReadAt != nil {
+		return notification, nil
+	}
+
+	if notification.RecipientID != nil {
+		err = s.repo.Notification().MarkRead(ctx, nil, id, userID)
+	} else {
+		err = s.repo.Notification().MarkBroadcastRead(ctx, nil, id, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	now := time.Now()
+	notification.ReadAt = &now
+	return notification, nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID string) (*MarkAllReadResponse, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Notification().MarkAllRead(ctx, nil, userID, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	s.logger.Info("Notifications marked read", "user_id", userID, "updated", updated)
+
+	return &MarkAllReadResponse{Updated: updated}, nil
+}
+
+func (s *notificationService) Delete(ctx context.Context, id uint, userID string) error {
+	notification, err := s.getVisibleNotification(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if notification.RecipientID != nil {
+		err = s.repo.Notification().Delete(ctx, nil, id)
+	} else {
+		// A broadcast is shared by everyone with the role, so only this user's copy is hidden
+		err = s.repo.Notification().DismissBroadcast(ctx, nil, id, userID)
+	}
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotificationNotFound
+		}
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func (s *notificationService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+// getVisibleNotification loads a notification from the user's inbox; notifications addressed
+// to someone else are reported as not found rather than forbidden
+func (s *notificationService) getVisibleNotification(ctx context.Context, id uint, userID string) (*models.Notification, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := s.repo.Notification().GetForUser(ctx, nil, id, userID, role)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	return notification, nil
+}
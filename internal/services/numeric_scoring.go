@@ -0,0 +1,173 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+)
+
+const (
+	defaultSignificantFiguresPenalty = 0.5
+	defaultFormulaSamplePoints       = 10
+	defaultFormulaTolerance          = 1e-6
+)
+
+// typedAnswer reads a numeric or formula answer stored as a string, or as a JSON number
+func typedAnswer(studentAnswer json.RawMessage) (string, error) {
+	var text string
+	if err := json.Unmarshal(studentAnswer, &text); err == nil {
+		return text, nil
+	}
+
+	var number float64
+	if err := json.Unmarshal(studentAnswer, &number); err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(number, 'f', -1, 64), nil
+}
+
+// scoreNumeric grades a typed number. With significant figures set, an answer rounded to that many
+// figures is accepted even outside the tolerance, and one with the right value but another number
+// of figures earns partial credit.
+func scoreNumeric(content models.NumericContent, answer string) (float64, bool) {
+	quantity, ok := parseQuantity(answer)
+	if !ok {
+		return 0.0, false
+	}
+	value, ok := quantity.convertTo(content.Unit, content.UnitRequired, content.UnitFactors)
+	if !ok {
+		return 0.0, false
+	}
+
+	correct := withinTolerance(value, content.CorrectValue, content.AbsoluteTolerance, content.RelativeTolerance)
+	if content.SignificantFigures == nil {
+		return boolScore(correct), correct
+	}
+
+	figures := *content.SignificantFigures
+	if !correct && !sameToSignificantFigures(value, content.CorrectValue, figures) {
+		return 0.0, false
+	}
+	if countSignificantFigures(quantity.Number) != figures {
+		penalty := defaultSignificantFiguresPenalty
+		if content.SignificantFiguresPenalty != nil {
+			penalty = math.Min(math.Max(*content.SignificantFiguresPenalty, 0), 1)
+		}
+		return 1 - penalty, false
+	}
+	return 1.0, true
+}
+
+func boolScore(correct bool) float64 {
+	if correct {
+		return 1.0
+	}
+	return 0.0
+}
+
+// countSignificantFigures counts the figures of a number as typed. Leading zeros never count;
+// trailing zeros only count after a decimal point, so "1200" has two and "1200." has four.
+func countSignificantFigures(number string) int {
+	number = strings.TrimLeft(strings.ToLower(number), "+-")
+	if i := strings.IndexByte(number, 'e'); i >= 0 {
+		number = number[:i]
+	}
+	number = strings.Replace(number, ",", ".", 1)
+
+	hasPoint := strings.Contains(number, ".")
+	digits := strings.TrimLeft(strings.Replace(number, ".", "", 1), "0")
+	if !hasPoint {
+		digits = strings.TrimRight(digits, "0")
+	}
+	if digits == "" {
+		// Zero itself, e.g. "0.00" has as many figures as decimals
+		if hasPoint {
+			return max(len(number)-strings.Index(number, ".")-1, 1)
+		}
+		return 1
+	}
+	return len(digits)
+}
+
+func sameToSignificantFigures(a, b float64, figures int) bool {
+	if figures < 1 {
+		return false
+	}
+	roundedA, err := strconv.ParseFloat(strconv.FormatFloat(a, 'g', figures, 64), 64)
+	if err != nil {
+		return false
+	}
+	roundedB, err := strconv.ParseFloat(strconv.FormatFloat(b, 'g', figures, 64), 64)
+	if err != nil {
+		return false
+	}
+	return roundedA == roundedB
+}
+
+// scoreFormula reports whether expression is algebraically equivalent to the correct formula,
+// judged by evaluating both at sample points. Points where the correct formula is undefined are
+// skipped; an expression that cannot be parsed or uses unknown variables is wrong.
+func scoreFormula(content models.FormulaContent, expression string) (bool, error) {
+	correct, err := utils.ParseExpression(content.CorrectFormula)
+	if err != nil {
+		return false, fmt.Errorf("invalid correct formula: %w", err)
+	}
+	answer, err := utils.ParseExpression(expression)
+	if err != nil {
+		return false, nil
+	}
+
+	tolerance := defaultFormulaTolerance
+	if content.Tolerance != nil {
+		tolerance = *content.Tolerance
+	}
+
+	compared := 0
+	for _, point := range formulaSamplePoints(content) {
+		expected, err := correct.Evaluate(point)
+		if err != nil {
+			return false, fmt.Errorf("invalid correct formula: %w", err)
+		}
+		if math.IsNaN(expected) || math.IsInf(expected, 0) {
+			continue
+		}
+
+		got, err := answer.Evaluate(point)
+		if err != nil || math.Abs(got-expected) > tolerance*math.Max(1, math.Abs(expected)) {
+			return false, nil
+		}
+		compared++
+	}
+
+	return compared > 0, nil
+}
+
+// formulaSamplePoints draws the variable values a formula is checked at. The draw is seeded by the
+// formula so regrading gives the same result.
+func formulaSamplePoints(content models.FormulaContent) []map[string]float64 {
+	count := content.SamplePoints
+	if count <= 0 {
+		count = defaultFormulaSamplePoints
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(content.CorrectFormula))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	points := make([]map[string]float64, count)
+	for i := range points {
+		point := make(map[string]float64, len(content.Variables))
+		for _, variable := range content.Variables {
+			point[variable.Name] = variable.Min + rng.Float64()*(variable.Max-variable.Min)
+		}
+		points[i] = point
+	}
+	return points
+}
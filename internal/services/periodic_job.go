@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+)
+
+// periodicJob runs a background job on every tick of its interval. With a leader lock only the
+// replica holding the lease runs it; without one every replica does.
+type periodicJob struct {
+	name     string
+	interval time.Duration
+	lock     *cache.LeaderLock
+	logger   *slog.Logger
+	run      func(ctx context.Context)
+
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+func newPeriodicJob(name string, interval time.Duration, lock *cache.LeaderLock, logger *slog.Logger, run func(ctx context.Context)) *periodicJob {
+	return &periodicJob{
+		name:     name,
+		interval: interval,
+		lock:     lock,
+		logger:   logger,
+		run:      run,
+	}
+}
+
+// Start runs the job periodically until Shutdown is called
+func (j *periodicJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.stop = cancel
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if j.lock != nil {
+					leader, err := j.lock.Acquire(ctx)
+					if err != nil {
+						j.logger.Error("Failed to acquire background job lock", "job", j.name, "error", err)
+						continue
+					}
+					if !leader {
+						continue
+					}
+				}
+				j.run(ctx)
+			}
+		}
+	}()
+}
+
+// Shutdown stops the loop, waits for a running pass and gives up the lease
+func (j *periodicJob) Shutdown(ctx context.Context) error {
+	if j.stop == nil {
+		return nil
+	}
+	j.stop()
+
+	done := make(chan struct{})
+	go func() {
+		j.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if j.lock == nil {
+		return nil
+	}
+	return j.lock.Release(ctx)
+}
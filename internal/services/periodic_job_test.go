@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+)
+
+func newCountingJob(lock *cache.LeaderLock, runs *atomic.Int32) *periodicJob {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return newPeriodicJob("test-job", 5*time.Millisecond, lock, logger, func(ctx context.Context) {
+		runs.Add(1)
+	})
+}
+
+func waitForRuns(t *testing.T, runs *atomic.Int32, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < want && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := runs.Load(); got < want {
+		t.Fatalf("job ran %d times, want at least %d", got, want)
+	}
+}
+
+func TestPeriodicJob_Runs(t *testing.T) {
+	tests := []struct {
+		name string
+		lock *cache.LeaderLock
+	}{
+		{"on every replica", nil},
+		// Without Redis the lock always elects this replica
+		{"as leader", cache.NewLeaderLock(nil, "test-job", time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs atomic.Int32
+			job := newCountingJob(tt.lock, &runs)
+			job.Start()
+			defer job.Shutdown(context.Background())
+
+			waitForRuns(t, &runs, 2)
+		})
+	}
+}
+
+func TestPeriodicJob_Shutdown(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		var runs atomic.Int32
+		if err := newCountingJob(nil, &runs).Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	})
+
+	t.Run("stops the loop", func(t *testing.T) {
+		var runs atomic.Int32
+		job := newCountingJob(cache.NewLeaderLock(nil, "test-job", time.Minute), &runs)
+		job.Start()
+		waitForRuns(t, &runs, 1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := job.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+
+		stopped := runs.Load()
+		time.Sleep(30 * time.Millisecond)
+		if got := runs.Load(); got != stopped {
+			t.Errorf("runs after shutdown = %d, want 0", got-stopped)
+		}
+	})
+
+	t.Run("waits for a running pass", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		var finished atomic.Bool
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		job := newPeriodicJob("test-job", time.Millisecond, nil, logger, func(ctx context.Context) {
+			select {
+			case started <- struct{}{}:
+				<-release
+				finished.Store(true)
+			default:
+			}
+		})
+		job.Start()
+		<-started
+
+		// A pass still running when the timeout hits is reported
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := job.Shutdown(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		close(release)
+		if err := job.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+		if !finished.Load() {
+			t.Error("Shutdown() returned before the running pass finished")
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type proctoringService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewProctoringService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) ProctoringService {
+	return &proctoringService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// defaultEventSeverity is used when the client does not report a severity (1-5, low to critical)
+var defaultEventSeverity = map[models.ProctoringEventType]int{
+	models.EventWindowBlur:       1,
+	models.EventRightClick:       1,
+	models.EventTabSwitch:        2,
+	models.EventFullscreenExit:   2,
+	models.EventCopyPaste:        3,
+	models.EventAudioDetection:   3,
+	models.EventNoFace:           3,
+	models.EventScreenshot:       4,
+	models.EventSuspiciousObject: 4,
+	models.EventMultipleFaces:    5,
+	models.EventSessionChange:    4,
+}
+
+// ===== EVENT INGESTION =====
+
+func (s *proctoringService) RecordEvents(ctx context.Context, attemptID uint, req *RecordProctoringEventsRequest, studentID string) (*RecordProctoringEventsResponse, error) {
+	s.logger.Info("Recording proctoring events",
+		"attempt_id", attemptID,
+		"student_id", studentID,
+		"count", len(req.Events))
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	if attempt.StudentID != studentID {
+		return nil, NewPermissionError(studentID, attemptID, "attempt", "record_proctoring_events", "not owned by student")
+	}
+
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+		}
+		settings = &models.AssessmentSettings{AssessmentID: attempt.AssessmentID}
+	}
+
+	response := &RecordProctoringEventsResponse{AttemptID: attemptID}
+	events := make([]*models.ProctoringEvent, 0, len(req.Events))
+
+	for _, eventReq := range req.Events {
+		if !isEventMonitored(eventReq.Type, settings) {
+			response.Ignored++
+			continue
+		}
+
+		event, err := s.buildEvent(attempt, eventReq, req.IPAddress, req.UserAgent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := s.repo.ProctoringEvent().CreateBatch(ctx, s.db, events); err != nil {
+		return nil, fmt.Errorf("failed to record proctoring events: %w", err)
+	}
+	response.Accepted = len(events)
+
+	s.logger.Info("Proctoring events recorded",
+		"attempt_id", attemptID,
+		"accepted", response.Accepted,
+		"ignored", response.Ignored)
+
+	return response, nil
+}
+
+// ===== REVIEW =====
+
+func (s *proctoringService) GetAttemptEvents(ctx context.Context, attemptID uint, filters repositories.ProctoringEventFilters, userID string) (*ProctoringEventListResponse, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canReview, err := s.canReview(ctx, attempt.AssessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canReview {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_proctoring_events", "not owner or insufficient permissions")
+	}
+
+	events, total, err := s.repo.ProctoringEvent().GetByAttempt(ctx, s.db, attemptID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proctoring events: %w", err)
+	}
+
+	return buildProctoringEventListResponse(events, total, filters), nil
+}
+
+func (s *proctoringService) GetAssessmentEvents(ctx context.Context, assessmentID uint, filters repositories.ProctoringEventFilters, userID string) (*ProctoringEventListResponse, error) {
+	canReview, err := s.canReview(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canReview {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_proctoring_events", "not owner or insufficient permissions")
+	}
+
+	events, total, err := s.repo.ProctoringEvent().GetByAssessment(ctx, s.db, assessmentID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proctoring events: %w", err)
+	}
+
+	return buildProctoringEventListResponse(events, total, filters), nil
+}
+
+func (s *proctoringService) GetAttemptSummary(ctx context.Context, attemptID uint, userID string) (*repositories.ProctoringSummary, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canReview, err := s.canReview(ctx, attempt.AssessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canReview {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_proctoring_summary", "not owner or insufficient permissions")
+	}
+
+	summary, err := s.repo.ProctoringEvent().GetAttemptSummary(ctx, s.db, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proctoring summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+func (s *proctoringService) ReviewEvent(ctx context.Context, eventID uint, req *ReviewProctoringEventRequest, reviewerID string) (*models.ProctoringEvent, error) {
+	s.logger.Info("Reviewing proctoring event",
+		"event_id", eventID,
+		"review_status", req.ReviewStatus,
+		"reviewer_id", reviewerID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	event, err := s.repo.ProctoringEvent().GetByID(ctx, s.db, eventID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrProctoringEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get proctoring event: %w", err)
+	}
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, event.AttemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canReview, err := s.canReview(ctx, attempt.AssessmentID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !canReview {
+		return nil, NewPermissionError(reviewerID, eventID, "proctoring_event", "review", "not owner or insufficient permissions")
+	}
+
+	if err := s.repo.ProctoringEvent().UpdateReview(ctx, s.db, eventID, req.ReviewStatus, req.ReviewNotes, reviewerID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrProctoringEventNotFound
+		}
+		return nil, fmt.Errorf("failed to update proctoring event review: %w", err)
+	}
+
+	updated, err := s.repo.ProctoringEvent().GetByID(ctx, s.db, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated proctoring event: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// canReview reports whether the user may see and review proctoring events of an assessment.
+// Proctors and admins can review every assessment; teachers only the ones they can access.
+func (s *proctoringService) canReview(ctx context.Context, assessmentID uint, userID string) (bool, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	switch user.Role {
+	case models.RoleAdmin, models.RoleProctor:
+		return true, nil
+	case models.RoleTeacher:
+		assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator, nil)
+		return assessmentService.CanAccess(ctx, assessmentID, userID)
+	default:
+		return false, nil
+	}
+}
+
+func (s *proctoringService) buildEvent(attempt *models.AssessmentAttempt, req ProctoringEventRequest, ipAddress, userAgent string) (*models.ProctoringEvent, error) {
+	severity := defaultEventSeverity[req.Type]
+	if req.Severity != nil {
+		severity = *req.Severity
+	}
+
+	var data datatypes.JSON
+	if req.Data != nil {
+		raw, err := json.Marshal(req.Data)
+		if err != nil {
+			return nil, NewValidationError("data", "invalid event data", req.Data)
+		}
+		data = raw
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil && req.OccurredAt.Before(occurredAt) {
+		occurredAt = *req.OccurredAt
+	}
+
+	timeOffset := 0
+	if attempt.StartedAt != nil && occurredAt.After(*attempt.StartedAt) {
+		timeOffset = int(occurredAt.Sub(*attempt.StartedAt).Seconds())
+	}
+
+	return &models.ProctoringEvent{
+		AttemptID:     attempt.ID,
+		Type:          req.Type,
+		Data:          data,
+		Severity:      severity,
+		ScreenshotURL: req.ScreenshotURL,
+		VideoURL:      req.VideoURL,
+		AudioURL:      req.AudioURL,
+		QuestionID:    req.QuestionID,
+		TimeOffset:    timeOffset,
+		UserAgent:     userAgent,
+		IPAddress:     ipAddress,
+		ReviewStatus:  models.ProctoringReviewPending,
+		CreatedAt:     occurredAt,
+	}, nil
+}
+
+// isEventMonitored checks whether the assessment settings enable the check that produces the event.
+// Events for disabled checks are dropped instead of being flagged for review.
+func isEventMonitored(eventType models.ProctoringEventType, settings *models.AssessmentSettings) bool {
+	switch eventType {
+	case models.EventTabSwitch, models.EventWindowBlur:
+		return settings.PreventTabSwitching
+	case models.EventFullscreenExit:
+		return settings.RequireFullScreen
+	case models.EventRightClick:
+		return settings.PreventRightClick
+	case models.EventCopyPaste:
+		return settings.PreventCopyPaste
+	case models.EventMultipleFaces, models.EventNoFace, models.EventSuspiciousObject, models.EventAudioDetection:
+		return settings.RequireWebcam
+	case models.EventScreenshot, models.EventSessionChange:
+		return true
+	default:
+		return false
+	}
+}
+
+func buildProctoringEventListResponse(events []*models.ProctoringEvent, total int64, filters repositories.ProctoringEventFilters) *ProctoringEventListResponse {
+	return &ProctoringEventListResponse{
+		Events: events,
+		Total:  total,
+		Page:   (filters.Offset / max(filters.Limit, 1)) + 1,
+		Size:   filters.Limit,
+	}
+}
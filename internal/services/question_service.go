@@ -15,6 +15,7 @@ import (
 
 type questionService struct {
 	repo      repositories.Repository
+	audit     AuditService
 	db        *gorm.DB
 	logger    *slog.Logger
 	validator *validator.Validator
@@ -23,6 +24,7 @@ type questionService struct {
 func NewQuestionService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) QuestionService {
 	return &questionService{
 		repo:      repo,
+		audit:     NewAuditService(repo, db, logger, validator),
 		db:        db,
 		logger:    logger,
 		validator: validator,
@@ -98,6 +100,15 @@ func (s *questionService) Create(ctx context.Context, req *CreateQuestionRequest
 
 	s.logger.Info("Question created successfully", "question_id", question.ID)
 
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditQuestionCreated,
+		UserID:      creatorID,
+		TargetType:  "question",
+		TargetID:    &question.ID,
+		Description: fmt.Sprintf("Question %d (%s) created", question.ID, question.Type),
+		After:       questionAuditSnapshot(question),
+	})
+
 	// Return response
 	return s.buildQuestionResponse(ctx, question, creatorID), nil
 }
@@ -187,6 +198,8 @@ func (s *questionService) Update(ctx context.Context, id uint, req *UpdateQuesti
 		}
 	}
 
+	before := questionAuditSnapshot(question)
+
 	// Apply updates
 	if err := s.applyQuestionUpdates(question, req); err != nil {
 		return nil, err
@@ -199,6 +212,16 @@ func (s *questionService) Update(ctx context.Context, id uint, req *UpdateQuesti
 
 	s.logger.Info("Question updated successfully", "question_id", id)
 
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditQuestionUpdated,
+		UserID:      userID,
+		TargetType:  "question",
+		TargetID:    &id,
+		Description: fmt.Sprintf("Question %d updated", id),
+		Before:      before,
+		After:       questionAuditSnapshot(question),
+	})
+
 	// Return updated question
 	return s.buildQuestionResponse(ctx, question, userID), nil
 }
@@ -215,12 +238,29 @@ func (s *questionService) Delete(ctx context.Context, id uint, userID string) er
 		return NewPermissionError(userID, id, "question", "delete", "not owner or question in use")
 	}
 
+	question, err := s.repo.Question().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
 	// Soft delete
 	if err := s.repo.Question().Delete(ctx, nil, id); err != nil {
 		return fmt.Errorf("failed to delete question: %w", err)
 	}
 
 	s.logger.Info("Question deleted successfully", "question_id", id)
+
+	recordAudit(ctx, s.audit, s.logger, &AuditEntry{
+		EventType:   models.AuditQuestionDeleted,
+		UserID:      userID,
+		TargetType:  "question",
+		TargetID:    &id,
+		Description: fmt.Sprintf("Question %d deleted", id),
+		Before:      questionAuditSnapshot(question),
+	})
 	return nil
 }
 
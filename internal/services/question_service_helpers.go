@@ -7,6 +7,7 @@ import (
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
 )
 
 // ===== STATISTICS =====
@@ -326,6 +327,17 @@ func (s *questionService) validateCategoryAccess(ctx context.Context, categoryID
 // ===== CONTENT VALIDATION =====
 
 func (s *questionService) validateQuestionContent(questionType models.QuestionType, content interface{}) error {
+	// Templated content is validated as rendered for a sample variant
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return NewValidationError("content", "invalid content format", content)
+	}
+	_, rendered, err := validator.RenderTemplateSample("", contentBytes)
+	if err != nil {
+		return NewValidationError("content.template", err.Error(), nil)
+	}
+	content = json.RawMessage(rendered)
+
 	switch questionType {
 	case models.MultipleChoice:
 		return s.validateMultipleChoiceContent(content)
@@ -341,6 +353,10 @@ func (s *questionService) validateQuestionContent(questionType models.QuestionTy
 		return s.validateOrderingContent(content)
 	case models.ShortAnswer:
 		return s.validateShortAnswerContent(content)
+	case models.Numeric:
+		return s.validateNumericContent(content)
+	case models.Formula:
+		return s.validateFormulaContent(content)
 	default:
 		return NewValidationError("type", "unsupported question type", questionType)
 	}
@@ -389,6 +405,8 @@ func (s *questionService) validateMultipleChoiceContent(content interface{}) err
 		}
 	}
 
+	errors = append(errors, validateScoringRule(models.MultipleChoice, mcContent.PartialCredit, mcContent.ScoringRule)...)
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -432,6 +450,15 @@ func (s *questionService) validateEssayContent(content interface{}) error {
 		errors = append(errors, *NewValidationError("content.max_words", "max_words must be positive", *essayContent.MaxWords))
 	}
 
+	// Validate auto-grading settings
+	if essayContent.AutoGrade && len(essayContent.KeyWords) == 0 {
+		errors = append(errors, *NewValidationError("content.key_words", "key_words are required when auto_grade is enabled", nil))
+	}
+
+	if essayContent.LengthPenalty != nil && (*essayContent.LengthPenalty < 0 || *essayContent.LengthPenalty > 1) {
+		errors = append(errors, *NewValidationError("content.length_penalty", "length_penalty must be between 0 and 1", *essayContent.LengthPenalty))
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -460,8 +487,12 @@ func (s *questionService) validateFillBlankContent(content interface{}) error {
 
 	// Validate each blank
 	for blankID, blank := range fbContent.Blanks {
-		if len(blank.AcceptedAnswers) == 0 {
-			errors = append(errors, *NewValidationError(fmt.Sprintf("content.blanks[%s].accepted_answers", blankID), "must have at least one accepted answer", nil))
+		if len(blank.AcceptedAnswers) == 0 && len(blank.AnswerRules) == 0 {
+			errors = append(errors, *NewValidationError(fmt.Sprintf("content.blanks[%s].accepted_answers", blankID), "must have at least one accepted answer or answer rule", nil))
+		}
+
+		if err := validator.ValidateAnswerRules(blank.AnswerRules, blank.FuzzyThreshold); err != nil {
+			errors = append(errors, *NewValidationError(fmt.Sprintf("content.blanks[%s].answer_rules", blankID), err.Error(), nil))
 		}
 
 		if blank.Points <= 0 {
@@ -512,6 +543,8 @@ func (s *questionService) validateMatchingContent(content interface{}) error {
 		errors = append(errors, *NewValidationError("content.correct_pairs", "must have at least one correct pair", nil))
 	}
 
+	errors = append(errors, validateScoringRule(models.Matching, matchContent.PartialCredit, matchContent.ScoringRule)...)
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -545,6 +578,8 @@ func (s *questionService) validateOrderingContent(content interface{}) error {
 		errors = append(errors, *NewValidationError("content.correct_order", "correct order must match number of items", len(orderContent.CorrectOrder)))
 	}
 
+	errors = append(errors, validateScoringRule(models.Ordering, orderContent.PartialCredit, orderContent.ScoringRule)...)
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -552,6 +587,21 @@ func (s *questionService) validateOrderingContent(content interface{}) error {
 	return nil
 }
 
+// validateScoringRule rejects rules the question type does not support, and partial credit
+// rules on questions that do not allow partial credit
+func validateScoringRule(questionType models.QuestionType, partialCredit bool, rule models.ScoringRule) ValidationErrors {
+	if rule == "" {
+		return nil
+	}
+	if !isValidScoringRule(questionType, rule) {
+		return ValidationErrors{*NewValidationError("content.scoring_rule", fmt.Sprintf("scoring rule is not supported for %s questions", questionType), rule)}
+	}
+	if !partialCredit && rule != models.ScoringAllOrNothing {
+		return ValidationErrors{*NewValidationError("content.scoring_rule", "partial_credit must be enabled to use a partial credit scoring rule", rule)}
+	}
+	return nil
+}
+
 func (s *questionService) validateShortAnswerContent(content interface{}) error {
 	var saContent models.ShortAnswerContent
 
@@ -562,8 +612,12 @@ func (s *questionService) validateShortAnswerContent(content interface{}) error
 	var errors ValidationErrors
 
 	// Validate accepted answers
-	if len(saContent.AcceptedAnswers) == 0 {
-		errors = append(errors, *NewValidationError("content.accepted_answers", "must have at least one accepted answer", nil))
+	if len(saContent.AcceptedAnswers) == 0 && len(saContent.AnswerRules) == 0 {
+		errors = append(errors, *NewValidationError("content.accepted_answers", "must have at least one accepted answer or answer rule", nil))
+	}
+
+	if err := validator.ValidateAnswerRules(saContent.AnswerRules, saContent.FuzzyThreshold); err != nil {
+		errors = append(errors, *NewValidationError("content.answer_rules", err.Error(), nil))
 	}
 
 	// Validate each accepted answer
@@ -580,6 +634,76 @@ func (s *questionService) validateShortAnswerContent(content interface{}) error
 	return nil
 }
 
+func (s *questionService) validateNumericContent(content interface{}) error {
+	var numContent models.NumericContent
+
+	if err := s.convertContent(content, &numContent); err != nil {
+		return err
+	}
+
+	var errors ValidationErrors
+
+	// Validate tolerances
+	if numContent.AbsoluteTolerance != nil && *numContent.AbsoluteTolerance < 0 {
+		errors = append(errors, *NewValidationError("content.absolute_tolerance", "tolerance cannot be negative", *numContent.AbsoluteTolerance))
+	}
+	if numContent.RelativeTolerance != nil && *numContent.RelativeTolerance < 0 {
+		errors = append(errors, *NewValidationError("content.relative_tolerance", "tolerance cannot be negative", *numContent.RelativeTolerance))
+	}
+
+	// Validate significant figures
+	if numContent.SignificantFigures != nil && (*numContent.SignificantFigures < 1 || *numContent.SignificantFigures > 15) {
+		errors = append(errors, *NewValidationError("content.significant_figures", "must be between 1 and 15", *numContent.SignificantFigures))
+	}
+	if numContent.SignificantFiguresPenalty != nil && (*numContent.SignificantFiguresPenalty < 0 || *numContent.SignificantFiguresPenalty > 1) {
+		errors = append(errors, *NewValidationError("content.significant_figures_penalty", "must be between 0 and 1", *numContent.SignificantFiguresPenalty))
+	}
+
+	// Validate units
+	if numContent.UnitRequired && numContent.Unit == "" {
+		errors = append(errors, *NewValidationError("content.unit", "unit is required when answers must include one", nil))
+	}
+	for unit, factor := range numContent.UnitFactors {
+		if factor <= 0 {
+			errors = append(errors, *NewValidationError(fmt.Sprintf("content.unit_factors[%s]", unit), "factor must be positive", factor))
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func (s *questionService) validateFormulaContent(content interface{}) error {
+	var fContent models.FormulaContent
+
+	if err := s.convertContent(content, &fContent); err != nil {
+		return err
+	}
+
+	var errors ValidationErrors
+
+	if err := validator.ValidateFormula(fContent.CorrectFormula, fContent.Variables); err != nil {
+		errors = append(errors, *NewValidationError("content.correct_formula", err.Error(), fContent.CorrectFormula))
+	}
+
+	if fContent.SamplePoints < 0 || fContent.SamplePoints > 100 {
+		errors = append(errors, *NewValidationError("content.sample_points", "must be between 1 and 100", fContent.SamplePoints))
+	}
+
+	if fContent.Tolerance != nil && *fContent.Tolerance < 0 {
+		errors = append(errors, *NewValidationError("content.tolerance", "tolerance cannot be negative", *fContent.Tolerance))
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
 // convertContent converts interface{} content to specific struct type
 func (s *questionService) convertContent(content interface{}, target interface{}) error {
 	// Convert to JSON and back to ensure proper type conversion
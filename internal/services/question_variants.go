@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultVariantPreviewCount = 5
+	maxVariantPreviewCount     = 20
+)
+
+// drawVariant picks the variable values of a templated question from a seeded source, so the same
+// seed always gives the same variant. It returns nil for questions without a template.
+func drawVariant(content []byte, seed int64) (datatypes.JSON, error) {
+	template, err := utils.ReadQuestionTemplate(content)
+	if err != nil || template == nil {
+		return nil, err
+	}
+
+	values := utils.DrawTemplateValues(template, rand.New(rand.NewSource(seed)))
+	variant, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode question variant: %w", err)
+	}
+	return variant, nil
+}
+
+// variantSeed derives the seed of one question from the seed of an attempt
+func variantSeed(attemptSeed int64, questionID uint) int64 {
+	return int64(uint64(attemptSeed)^(uint64(questionID)*0x9E3779B97F4A7C15)) & 0x7FFFFFFFFFFFFFFF
+}
+
+// renderVariant renders the text and content of a templated question with the values of a variant.
+// Questions without a template or answers without a variant are returned unchanged.
+func renderVariant(text string, content datatypes.JSON, variant datatypes.JSON) (string, datatypes.JSON, error) {
+	if len(variant) == 0 {
+		return text, content, nil
+	}
+	template, err := utils.ReadQuestionTemplate(content)
+	if err != nil || template == nil {
+		return text, content, err
+	}
+
+	var values map[string]float64
+	if err := json.Unmarshal(variant, &values); err != nil {
+		return "", nil, fmt.Errorf("invalid question variant: %w", err)
+	}
+
+	renderedText, err := utils.RenderTemplate(text, values)
+	if err != nil {
+		return "", nil, err
+	}
+	renderedContent, err := utils.RenderTemplateContent(content, template, values)
+	if err != nil {
+		return "", nil, err
+	}
+	return renderedText, renderedContent, nil
+}
+
+// variantContent is the content an answer is graded against: for templated questions, the content
+// rendered with the variant the student was given
+func variantContent(content json.RawMessage, answer *models.StudentAnswer) (json.RawMessage, error) {
+	_, rendered, err := renderVariant("", datatypes.JSON(content), answer.Variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render question variant: %w", err)
+	}
+	return json.RawMessage(rendered), nil
+}
+
+// ===== ATTEMPTS =====
+
+// drawAttemptVariants draws a variant of every templated question of a new attempt. One random
+// attempt seed, generated like the shuffle seeds, yields a deterministic variant per question.
+func (s *attemptService) drawAttemptVariants(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt) (map[uint]datatypes.JSON, error) {
+	contents := make(map[uint]datatypes.JSON)
+
+	snapshot, err := getPinnedSnapshot(ctx, s.repo, tx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		for _, sq := range snapshot.Questions {
+			contents[sq.QuestionID] = sq.Content
+		}
+	} else {
+		questions, err := s.repo.Question().GetByAssessment(ctx, tx, attempt.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+		}
+		for _, question := range questions {
+			contents[question.ID] = question.Content
+		}
+	}
+
+	attemptSeed := s.newRandomSeed(attempt.ID, "variant")
+	variants := make(map[uint]datatypes.JSON)
+	for questionID, content := range contents {
+		variant, err := drawVariant(content, variantSeed(attemptSeed, questionID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to draw variant of question %d: %w", questionID, err)
+		}
+		if variant != nil {
+			variants[questionID] = variant
+		}
+	}
+
+	return variants, nil
+}
+
+// applyAttemptVariants renders templated questions with the variants drawn for the attempt
+func (s *attemptService) applyAttemptVariants(ctx context.Context, attempt *models.AssessmentAttempt, questions []QuestionForAttempt) ([]QuestionForAttempt, error) {
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempt answers: %w", err)
+	}
+
+	variants := make(map[uint]datatypes.JSON, len(answers))
+	for _, answer := range answers {
+		if len(answer.Variant) > 0 {
+			variants[answer.QuestionID] = answer.Variant
+		}
+	}
+	if len(variants) == 0 {
+		return questions, nil
+	}
+
+	for i, q := range questions {
+		if q.Question == nil || variants[q.Question.ID] == nil {
+			continue
+		}
+		rendered := *q.Question
+		rendered.Text, rendered.Content, err = renderVariant(q.Question.Text, q.Question.Content, variants[q.Question.ID])
+		if err != nil {
+			return nil, fmt.Errorf("failed to render variant of question %d: %w", q.Question.ID, err)
+		}
+		questions[i].Question = &rendered
+	}
+
+	return questions, nil
+}
+
+// ===== PREVIEW =====
+
+func (s *questionService) PreviewVariants(ctx context.Context, questionID uint, count int, userID string) ([]QuestionVariant, error) {
+	canAccess, err := s.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "read", "not owner or insufficient permissions")
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	template, err := utils.ReadQuestionTemplate(question.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read question template: %w", err)
+	}
+	if template == nil {
+		return nil, ErrQuestionNotTemplated
+	}
+
+	if count < 1 {
+		count = defaultVariantPreviewCount
+	}
+	if count > maxVariantPreviewCount {
+		count = maxVariantPreviewCount
+	}
+
+	// Previews use fixed seeds so the same question always previews the same variants
+	variants := make([]QuestionVariant, count)
+	for i := range variants {
+		values := utils.DrawTemplateValues(template, rand.New(rand.NewSource(int64(i+1))))
+		text, err := utils.RenderTemplate(question.Text, values)
+		if err != nil {
+			return nil, ValidationErrors{*NewValidationError("text", err.Error(), nil)}
+		}
+		content, err := utils.RenderTemplateContent(question.Content, template, values)
+		if err != nil {
+			return nil, ValidationErrors{*NewValidationError("content.template", err.Error(), nil)}
+		}
+		variants[i] = QuestionVariant{Values: values, Text: text, Content: content}
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"math"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// answerMarking is the marking applied on top of the share of a question answered correctly
+type answerMarking struct {
+	NegativeMarking      models.NegativeMarkingMode
+	NegativeMarkingValue float64
+	ConfidenceMarking    bool
+}
+
+// Confidence-based marking after Gardner-Medwin: correct answers earn a third, two thirds or all of
+// the points at low, medium and high confidence; wrong answers lose nothing, two thirds or twice
+// the points
+var (
+	confidenceRewards   = map[int]float64{models.ConfidenceLow: 1.0 / 3, models.ConfidenceMedium: 2.0 / 3, models.ConfidenceHigh: 1}
+	confidencePenalties = map[int]float64{models.ConfidenceLow: 0, models.ConfidenceMedium: 2.0 / 3, models.ConfidenceHigh: 2}
+)
+
+// resolveMarking is the marking an answer is scored with. Fraction deductions are clamped to the
+// question points in case a stored value slipped past validation.
+func resolveMarking(settings *models.AssessmentSettings, assessmentQuestion *models.AssessmentQuestion) answerMarking {
+	marking := mergeMarking(settings, assessmentQuestion)
+	if marking.NegativeMarking == models.NegativeMarkingFraction {
+		marking.NegativeMarkingValue = math.Min(math.Max(marking.NegativeMarkingValue, 0), 1)
+	}
+	return marking
+}
+
+// mergeMarking applies the negative marking override of a question to the assessment settings
+func mergeMarking(settings *models.AssessmentSettings, assessmentQuestion *models.AssessmentQuestion) answerMarking {
+	marking := answerMarking{
+		NegativeMarking:      settings.NegativeMarking,
+		NegativeMarkingValue: settings.NegativeMarkingValue,
+		ConfidenceMarking:    settings.ConfidenceMarking,
+	}
+	if assessmentQuestion.NegativeMarking != nil {
+		marking.NegativeMarking = *assessmentQuestion.NegativeMarking
+	}
+	if assessmentQuestion.NegativeMarkingValue != nil {
+		marking.NegativeMarkingValue = *assessmentQuestion.NegativeMarkingValue
+	}
+	if marking.NegativeMarking == "" {
+		marking.NegativeMarking = models.NegativeMarkingNone
+	}
+	return marking
+}
+
+// markScore turns the share of a question answered correctly into points. Partially correct
+// answers are never penalized. With confidence marking, answers without a confidence count as
+// low confidence, and the confidence table replaces negative marking.
+func markScore(marking answerMarking, fraction float64, points int, confidence *int) float64 {
+	if marking.ConfidenceMarking {
+		level := models.ConfidenceLow
+		if confidence != nil && *confidence >= models.ConfidenceLow && *confidence <= models.ConfidenceHigh {
+			level = *confidence
+		}
+		if fraction > 0 {
+			return fraction * float64(points) * confidenceRewards[level]
+		}
+		return -float64(points) * confidencePenalties[level]
+	}
+
+	if fraction > 0 {
+		return fraction * float64(points)
+	}
+
+	switch marking.NegativeMarking {
+	case models.NegativeMarkingFixed:
+		return -marking.NegativeMarkingValue
+	case models.NegativeMarkingFraction:
+		return -marking.NegativeMarkingValue * float64(points)
+	default:
+		return 0.0
+	}
+}
+
+// scoringRules lists the rules each question type accepts; the first one is the default when
+// partial credit is enabled without choosing a rule
+var scoringRules = map[models.QuestionType][]models.ScoringRule{
+	models.MultipleChoice: {models.ScoringRightMinusWrong, models.ScoringProportional, models.ScoringAllOrNothing},
+	models.Matching:       {models.ScoringPerPair, models.ScoringRightMinusWrong, models.ScoringAllOrNothing},
+	models.Ordering:       {models.ScoringPositional, models.ScoringKendallTau, models.ScoringLongestSequence, models.ScoringAllOrNothing},
+}
+
+// isValidScoringRule reports whether rule can be used for questionType; empty means the default
+func isValidScoringRule(questionType models.QuestionType, rule models.ScoringRule) bool {
+	if rule == "" {
+		return true
+	}
+	for _, allowed := range scoringRules[questionType] {
+		if allowed == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveScoringRule resolves the rule a question is graded with. Without partial credit every
+// question is all-or-nothing, whatever rule is set.
+func effectiveScoringRule(questionType models.QuestionType, partialCredit bool, rule models.ScoringRule) models.ScoringRule {
+	if !partialCredit {
+		return models.ScoringAllOrNothing
+	}
+	if rule == "" || !isValidScoringRule(questionType, rule) {
+		return scoringRules[questionType][0]
+	}
+	return rule
+}
+
+// scoreChoices scores a multiple choice selection that is not an exact match
+func scoreChoices(rule models.ScoringRule, optionIDs, correctAnswers, selected []string) float64 {
+	correctSet := make(map[string]bool, len(correctAnswers))
+	for _, id := range correctAnswers {
+		correctSet[id] = true
+	}
+	selectedSet := make(map[string]bool, len(selected))
+	for _, id := range selected {
+		selectedSet[id] = true
+	}
+
+	switch rule {
+	case models.ScoringProportional:
+		if len(optionIDs) == 0 {
+			return 0.0
+		}
+		classified := 0
+		for _, id := range optionIDs {
+			if correctSet[id] == selectedSet[id] {
+				classified++
+			}
+		}
+		return float64(classified) / float64(len(optionIDs))
+
+	case models.ScoringRightMinusWrong:
+		if len(correctAnswers) == 0 {
+			return 0.0
+		}
+		correct, incorrect := 0, 0
+		for id := range selectedSet {
+			if correctSet[id] {
+				correct++
+			} else {
+				incorrect++
+			}
+		}
+		// Missed correct answers count as wrong too
+		for id := range correctSet {
+			if !selectedSet[id] {
+				incorrect++
+			}
+		}
+		return math.Max(0.0, float64(correct-incorrect)/float64(len(correctAnswers)))
+
+	default:
+		return 0.0
+	}
+}
+
+// scorePairs scores matching answers that are not all correct
+func scorePairs(rule models.ScoringRule, correctMappings, answers map[string]string) float64 {
+	if len(correctMappings) == 0 {
+		return 0.0
+	}
+
+	correct, incorrect := 0, 0
+	for left, expectedRight := range correctMappings {
+		studentRight, exists := answers[left]
+		switch {
+		case exists && studentRight == expectedRight:
+			correct++
+		case exists && studentRight != "":
+			incorrect++
+		}
+	}
+
+	switch rule {
+	case models.ScoringPerPair:
+		return float64(correct) / float64(len(correctMappings))
+	case models.ScoringRightMinusWrong:
+		return math.Max(0.0, float64(correct-incorrect)/float64(len(correctMappings)))
+	default:
+		return 0.0
+	}
+}
+
+// scoreSequence scores an ordering answer that is not exactly the expected order
+func scoreSequence(rule models.ScoringRule, expectedOrder, answer []string) float64 {
+	n := len(expectedOrder)
+	if n == 0 {
+		return 0.0
+	}
+
+	switch rule {
+	case models.ScoringPositional:
+		correct := 0
+		for i, itemID := range answer {
+			if i < n && itemID == expectedOrder[i] {
+				correct++
+			}
+		}
+		return float64(correct) / float64(n)
+
+	case models.ScoringKendallTau:
+		if n < 2 {
+			return 0.0
+		}
+		positions := answerPositions(answer)
+		concordant, discordant := 0, 0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				pi, iok := positions[expectedOrder[i]]
+				pj, jok := positions[expectedOrder[j]]
+				if iok && jok && pi < pj {
+					concordant++
+				} else {
+					// Pairs with a missing item cannot be in the right order
+					discordant++
+				}
+			}
+		}
+		tau := float64(concordant-discordant) / float64(n*(n-1)/2)
+		return math.Max(0.0, tau)
+
+	case models.ScoringLongestSequence:
+		return float64(longestOrderedRun(expectedOrder, answer)) / float64(n)
+
+	default:
+		return 0.0
+	}
+}
+
+func answerPositions(answer []string) map[string]int {
+	positions := make(map[string]int, len(answer))
+	for i, itemID := range answer {
+		if _, seen := positions[itemID]; !seen {
+			positions[itemID] = i
+		}
+	}
+	return positions
+}
+
+// longestOrderedRun is the length of the longest subsequence of answer whose items appear in
+// the same relative order as in expectedOrder (not necessarily adjacent)
+func longestOrderedRun(expectedOrder, answer []string) int {
+	expectedPositions := answerPositions(expectedOrder)
+
+	// Longest strictly increasing subsequence of expected positions, by patience sorting
+	var tails []int
+	seen := make(map[string]bool, len(answer))
+	for _, itemID := range answer {
+		position, ok := expectedPositions[itemID]
+		if !ok || seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+
+		low, high := 0, len(tails)
+		for low < high {
+			mid := (low + high) / 2
+			if tails[mid] < position {
+				low = mid + 1
+			} else {
+				high = mid
+			}
+		}
+		if low == len(tails) {
+			tails = append(tails, position)
+		} else {
+			tails[low] = position
+		}
+	}
+
+	return len(tails)
+}
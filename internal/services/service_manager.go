@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/events"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/storage"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
@@ -22,11 +24,18 @@ type ServiceManagerConfig struct {
 	LogLevel           slog.Level
 
 	// Service-specific configurations
-	Assessment   ServiceConfig
-	Question     ServiceConfig
-	QuestionBank ServiceConfig
-	Attempt      ServiceConfig
-	Grading      ServiceConfig
+	Assessment    ServiceConfig
+	Question      ServiceConfig
+	QuestionBank  ServiceConfig
+	Attempt       ServiceConfig
+	Grading       ServiceConfig
+	ImportJobs    ImportJobConfig
+	Attachments   AttachmentConfig
+	AnswerHistory AnswerHistoryConfig
+
+	// Background workers
+	TimeoutSweeper     TimeoutSweeperConfig
+	LifecycleScheduler LifecycleSchedulerConfig
 
 	// Global settings
 	DefaultTimeout    time.Duration
@@ -52,32 +61,61 @@ const (
 	ValidationFull
 )
 
+// RateLimit is a token bucket: BurstSize requests at once, refilled at RequestsPerMinute.
+// A rule with RequestsPerMinute of zero disables limiting.
 type RateLimit struct {
 	RequestsPerMinute int
 	BurstSize         int
 }
 
+// Rate limiting rule names, the keys of ServiceManagerConfig.RateLimitingRules. Each names
+// the route group it limits; buckets are per user, or per IP before authentication.
+const (
+	RateLimitAPI              = "api" // every /api/v1 request
+	RateLimitAssessmentCreate = "assessment_create"
+	RateLimitAttemptStart     = "attempt_start"
+	RateLimitAttemptAnswer    = "attempt_answer" // answer submits and autosaves
+	RateLimitQuestionSearch   = "question_search"
+	RateLimitGradingSubmit    = "grading_submit"
+)
+
 // serviceManager implements ServiceManager interface
 type serviceManager struct {
 	// Dependencies
-	db           *gorm.DB
-	repo         repositories.Repository
-	logger       *slog.Logger
-	validator    *validator.Validator
-	cacheManager *cache.CacheManager
-	config       ServiceManagerConfig
+	db             *gorm.DB
+	repo           repositories.Repository
+	logger         *slog.Logger
+	validator      *validator.Validator
+	cacheManager   *cache.CacheManager
+	storage        storage.Storage
+	eventPublisher events.EventPublisher
+	config         ServiceManagerConfig
 
 	// Service instances
-	assessmentService   AssessmentService
-	questionService     QuestionService
-	questionBankService QuestionBankService
-	attemptService      AttemptService
-	gradingService      GradingService
-	dashboardService    DashboardService
-	studentService      StudentService
-	importExportService ImportExportService
-	// notificationService NotificationService
-	//analyticsService    AnalyticsService
+	assessmentService        AssessmentService
+	questionService          QuestionService
+	questionBankService      QuestionBankService
+	attemptService           AttemptService
+	gradingService           GradingService
+	dashboardService         DashboardService
+	studentService           StudentService
+	importExportService      ImportExportService
+	proctoringService        ProctoringService
+	auditService             AuditService
+	gradingSchemeService     GradingSchemeService
+	analyticsService         AnalyticsService
+	categoryService          CategoryService
+	attachmentService        AttachmentService
+	notificationService      NotificationService
+	notificationEventService NotificationEventService
+	classService             ClassService
+
+	// Background workers
+	timeoutSweeper     *attemptTimeoutSweeper
+	lifecycleScheduler *assessmentLifecycleScheduler
+
+	// Shared by the HTTP rate limiting middleware
+	rateLimiter *cache.RateLimiter
 
 	// Utilities
 	//validationService *ValidationService
@@ -89,19 +127,22 @@ type serviceManager struct {
 }
 
 // NewServiceManager creates a new service manager with all dependencies
-func NewServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, cacheManager *cache.CacheManager, config ServiceManagerConfig) ServiceManager {
+func NewServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, cacheManager *cache.CacheManager, fileStorage storage.Storage, eventPublisher events.EventPublisher, config ServiceManagerConfig) ServiceManager {
 	return &serviceManager{
-		db:           db,
-		repo:         repo,
-		logger:       logger,
-		validator:    validator,
-		cacheManager: cacheManager,
-		config:       config,
+		db:             db,
+		repo:           repo,
+		logger:         logger,
+		validator:      validator,
+		cacheManager:   cacheManager,
+		storage:        fileStorage,
+		eventPublisher: eventPublisher,
+		config:         config,
+		rateLimiter:    cacheManager.NewRateLimiter(),
 	}
 }
 
 // NewDefaultServiceManager creates a service manager with default configuration
-func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client) ServiceManager {
+func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client, fileStorage storage.Storage, eventPublisher events.EventPublisher) ServiceManager {
 	// Create cache manager from Redis client
 	cacheManager := cache.NewCacheManager(redisClient)
 
@@ -150,14 +191,47 @@ func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger
 			AuditingEnabled: true,
 			MetricsEnabled:  true,
 		},
+		ImportJobs: ImportJobConfig{
+			MaxConcurrentJobs: 2,
+			BatchSize:         100,
+			ClaimTTL:          5 * time.Minute,
+		},
+		Attachments: AttachmentConfig{
+			MaxFileSize:     10 << 20, // 10 MB
+			MaxPerQuestion:  20,
+			ThumbnailSize:   320,
+			CleanupInterval: time.Hour,
+		},
+		AnswerHistory: AnswerHistoryConfig{
+			MaxEntries: 50,
+		},
+		TimeoutSweeper: TimeoutSweeperConfig{
+			Enabled:     true,
+			Interval:    time.Minute,
+			GracePeriod: 2 * time.Minute,
+			BatchSize:   100,
+		},
+		LifecycleScheduler: LifecycleSchedulerConfig{
+			Enabled:         true,
+			Interval:        time.Minute,
+			BatchSize:       100,
+			ReminderOffsets: []time.Duration{24 * time.Hour, time.Hour},
+		},
 
-		DefaultTimeout:    30 * time.Second,
-		MaxRetries:        3,
-		CircuitBreaker:    true,
-		RateLimitingRules: make(map[string]RateLimit),
+		DefaultTimeout: 30 * time.Second,
+		MaxRetries:     3,
+		CircuitBreaker: true,
+		RateLimitingRules: map[string]RateLimit{
+			RateLimitAPI:              {RequestsPerMinute: 600, BurstSize: 100},
+			RateLimitAssessmentCreate: {RequestsPerMinute: 30, BurstSize: 10},
+			RateLimitAttemptStart:     {RequestsPerMinute: 20, BurstSize: 5},
+			RateLimitAttemptAnswer:    {RequestsPerMinute: 60, BurstSize: 20},
+			RateLimitQuestionSearch:   {RequestsPerMinute: 30, BurstSize: 10},
+			RateLimitGradingSubmit:    {RequestsPerMinute: 200, BurstSize: 50},
+		},
 	}
 
-	return NewServiceManager(db, repo, logger, validator, cacheManager, config)
+	return NewServiceManager(db, repo, logger, validator, cacheManager, fileStorage, eventPublisher, config)
 }
 
 // Initialize sets up all services and their dependencies
@@ -190,10 +264,25 @@ func (sm *serviceManager) Initialize(ctx context.Context) error {
 func (sm *serviceManager) initializeServices(ctx context.Context) error {
 	var initErrors []error
 
+	// Initialize NotificationEventService first, the assessment, attempt and grading flows notify through it
+	if sm.eventPublisher == nil {
+		sm.logger.Warn("No event publisher configured, notification events are only logged")
+		sm.eventPublisher = events.NewMockEventPublisher(sm.logger)
+	}
+	sm.notificationEventService = NewNotificationEventService(sm.repo, sm.eventPublisher, sm.logger, sm.validator)
+	sm.logger.Info("Notification event service initialized")
+
 	// Initialize AssessmentService
 	if sm.config.Assessment.Enabled {
-		sm.assessmentService = NewAssessmentService(sm.repo, sm.db, sm.logger, sm.validator)
+		sm.assessmentService = NewAssessmentService(sm.repo, sm.db, sm.logger, sm.validator, sm.notificationEventService)
 		sm.logger.Info("Assessment service initialized")
+
+		// Publish, expire and send due-date reminders on schedule; replicas elect one scheduler through Redis
+		if sm.config.LifecycleScheduler.Enabled && sm.config.LifecycleScheduler.Interval > 0 {
+			sm.lifecycleScheduler = newAssessmentLifecycleScheduler(sm.repo, sm.db, sm.logger, sm.assessmentService, sm.cacheManager, sm.notificationEventService, sm.config.LifecycleScheduler)
+			sm.lifecycleScheduler.Start()
+			sm.logger.Info("Assessment lifecycle scheduler started", "interval", sm.config.LifecycleScheduler.Interval)
+		}
 	}
 
 	// Initialize QuestionService
@@ -210,16 +299,27 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 
 	// Initialize AttemptService
 	if sm.config.Attempt.Enabled {
-		sm.attemptService = NewAttemptService(sm.repo, sm.db, sm.logger, sm.validator, sm.cacheManager)
+		sm.attemptService = NewAttemptService(sm.repo, sm.db, sm.logger, sm.validator, sm.cacheManager, sm.notificationEventService, sm.config.AnswerHistory)
 		sm.logger.Info("Attempt service initialized")
 	}
 
 	// Initialize GradingService
 	if sm.config.Grading.Enabled {
-		sm.gradingService = NewGradingService(sm.db, sm.repo, sm.logger, sm.validator)
+		sm.gradingService = NewGradingService(sm.db, sm.repo, sm.logger, sm.validator, sm.notificationEventService)
 		sm.logger.Info("Grading service initialized")
 	}
 
+	// Close attempts abandoned past their end time and grade them; replicas elect one sweeper through Redis
+	if sm.config.Attempt.Enabled && sm.config.TimeoutSweeper.Enabled && sm.config.TimeoutSweeper.Interval > 0 {
+		gradingService := sm.gradingService
+		if gradingService == nil {
+			gradingService = NewGradingService(sm.db, sm.repo, sm.logger, sm.validator, sm.notificationEventService)
+		}
+		sm.timeoutSweeper = newAttemptTimeoutSweeper(sm.repo, sm.db, sm.logger, gradingService, sm.cacheManager, sm.notificationEventService, sm.config.TimeoutSweeper)
+		sm.timeoutSweeper.Start()
+		sm.logger.Info("Attempt timeout sweeper started", "interval", sm.config.TimeoutSweeper.Interval)
+	}
+
 	// Initialize DashboardService
 	sm.dashboardService = NewDashboardService(sm.repo, sm.db, sm.logger)
 	sm.logger.Info("Dashboard service initialized")
@@ -228,13 +328,56 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 	sm.studentService = NewStudentService(sm.repo, sm.db, sm.logger)
 	sm.logger.Info("Student service initialized")
 
+	// Uploaded imports and attachments go to the shared file storage; a local temp directory
+	// would lose them on restart and hide them from other replicas
+	if sm.storage == nil {
+		initErrors = append(initErrors, fmt.Errorf("file storage is not configured"))
+	}
+
 	// Initialize ImportExportService
-	sm.importExportService = NewImportExportService(sm.repo, sm.logger, sm.validator)
-	sm.logger.Info("ImportExport service initialized")
+	if sm.storage != nil {
+		sm.importExportService = NewImportExportService(sm.repo, sm.storage, sm.logger, sm.validator, sm.config.ImportJobs)
+		if err := sm.importExportService.ResumeImportJobs(ctx); err != nil {
+			sm.logger.Error("Failed to resume import jobs", "error", err)
+		}
+		sm.importExportService.StartRecovery()
+		sm.logger.Info("ImportExport service initialized")
+	}
+
+	// Initialize ProctoringService
+	sm.proctoringService = NewProctoringService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Proctoring service initialized")
+
+	// Initialize AuditService
+	sm.auditService = NewAuditService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Audit service initialized")
+
+	// Initialize GradingSchemeService
+	sm.gradingSchemeService = NewGradingSchemeService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Grading scheme service initialized")
+
+	// Initialize AnalyticsService
+	sm.analyticsService = NewAnalyticsService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Analytics service initialized")
+
+	// Initialize CategoryService
+	sm.categoryService = NewCategoryService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Category service initialized")
+
+	// Initialize AttachmentService
+	if sm.storage != nil {
+		sm.attachmentService = NewAttachmentService(sm.repo, sm.db, sm.storage, sm.logger, sm.validator, sm.cacheManager, sm.config.Attachments)
+		sm.attachmentService.StartCleanup()
+		sm.logger.Info("Attachment service initialized")
+	}
 
 	// Initialize NotificationService
-	//sm.notificationService = NewNotificationService(sm.repo, sm.logger, sm.validator)
-	// sm.logger.Info("Notification service initialized")
+	sm.notificationService = NewNotificationService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Notification service initialized")
+
+	// Initialize ClassService
+	sm.classService = NewClassService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Class service initialized")
 
 	if len(initErrors) > 0 {
 		return fmt.Errorf("service initialization failed with %d errors", len(initErrors))
@@ -376,20 +519,154 @@ func (sm *serviceManager) ImportExport() ImportExportService {
 	panic("import/export service not initialized")
 }
 
-//func (sm *serviceManager) Notification() NotificationService {
-//	sm.mu.RLock()
-//	defer sm.mu.RUnlock()
-//
-//	if !sm.initialized {
-//		panic("service manager not initialized")
-//	}
-//
-//	if sm.notificationService != nil {
-//		return sm.notificationService
-//	}
-//
-//	panic("notification service not initialized")
-//}
+func (sm *serviceManager) Proctoring() ProctoringService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.proctoringService != nil {
+		return sm.proctoringService
+	}
+
+	panic("proctoring service not initialized")
+}
+
+func (sm *serviceManager) Audit() AuditService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.auditService != nil {
+		return sm.auditService
+	}
+
+	panic("audit service not initialized")
+}
+
+func (sm *serviceManager) GradingScheme() GradingSchemeService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.gradingSchemeService != nil {
+		return sm.gradingSchemeService
+	}
+
+	panic("grading scheme service not initialized")
+}
+
+func (sm *serviceManager) Analytics() AnalyticsService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.analyticsService != nil {
+		return sm.analyticsService
+	}
+
+	panic("analytics service not initialized")
+}
+
+func (sm *serviceManager) Category() CategoryService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.categoryService != nil {
+		return sm.categoryService
+	}
+
+	panic("category service not initialized")
+}
+
+func (sm *serviceManager) Attachment() AttachmentService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.attachmentService != nil {
+		return sm.attachmentService
+	}
+
+	panic("attachment service not initialized")
+}
+
+func (sm *serviceManager) Notification() NotificationService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.notificationService != nil {
+		return sm.notificationService
+	}
+
+	panic("notification service not initialized")
+}
+
+func (sm *serviceManager) NotificationEvents() NotificationEventService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.notificationEventService != nil {
+		return sm.notificationEventService
+	}
+
+	panic("notification event service not initialized")
+}
+
+func (sm *serviceManager) Class() ClassService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.classService != nil {
+		return sm.classService
+	}
+
+	panic("class service not initialized")
+}
+
+// RateLimiter returns the limiter backing the HTTP rate limiting middleware
+func (sm *serviceManager) RateLimiter() *cache.RateLimiter {
+	return sm.rateLimiter
+}
+
+// RateLimitRule returns the configured limit for a route group
+func (sm *serviceManager) RateLimitRule(name string) (RateLimit, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	rule, ok := sm.config.RateLimitingRules[name]
+	return rule, ok
+}
 
 // Health and lifecycle
 func (sm *serviceManager) HealthCheck(ctx context.Context) error {
@@ -428,8 +705,36 @@ func (sm *serviceManager) Shutdown(ctx context.Context) error {
 	sm.logger.Info("Shutting down service manager")
 
 	// Graceful shutdown of services
-	// Services don't currently have explicit shutdown methods,
-	// but this is where we would call them
+	if sm.lifecycleScheduler != nil {
+		if err := sm.lifecycleScheduler.Shutdown(ctx); err != nil {
+			sm.logger.Error("Failed to shutdown assessment lifecycle scheduler", "error", err)
+		}
+	}
+
+	if sm.timeoutSweeper != nil {
+		if err := sm.timeoutSweeper.Shutdown(ctx); err != nil {
+			sm.logger.Error("Failed to shutdown attempt timeout sweeper", "error", err)
+		}
+	}
+
+	// Let running import jobs commit their current batch
+	if sm.importExportService != nil {
+		if err := sm.importExportService.Shutdown(ctx); err != nil {
+			sm.logger.Error("Failed to shutdown import jobs", "error", err)
+		}
+	}
+
+	if sm.attachmentService != nil {
+		if err := sm.attachmentService.Shutdown(ctx); err != nil {
+			sm.logger.Error("Failed to shutdown attachment cleanup", "error", err)
+		}
+	}
+
+	if sm.eventPublisher != nil {
+		if err := sm.eventPublisher.Close(); err != nil {
+			sm.logger.Error("Failed to close event publisher", "error", err)
+		}
+	}
 
 	// Shutdown repository manager
 	if repoManager, ok := sm.repo.(repositories.RepositoryManager); ok {
@@ -597,7 +902,7 @@ func (sc *ServiceConfig) validate(serviceName string) error {
 // ===== FACTORY FUNCTIONS =====
 
 // CreateProductionServiceManager creates a service manager configured for production
-func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client) ServiceManager {
+func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client, fileStorage storage.Storage) ServiceManager {
 	// Create cache manager from Redis client
 	cacheManager := cache.NewCacheManager(redisClient)
 
@@ -638,22 +943,37 @@ func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, l
 			AuditingEnabled: true,
 			MetricsEnabled:  true,
 		},
+		TimeoutSweeper: TimeoutSweeperConfig{
+			Enabled:     true,
+			Interval:    30 * time.Second,
+			GracePeriod: 2 * time.Minute,
+			BatchSize:   200,
+		},
+		LifecycleScheduler: LifecycleSchedulerConfig{
+			Enabled:         true,
+			Interval:        time.Minute,
+			BatchSize:       200,
+			ReminderOffsets: []time.Duration{24 * time.Hour, time.Hour},
+		},
 
 		DefaultTimeout: 60 * time.Second,
 		MaxRetries:     3,
 		CircuitBreaker: true,
 		RateLimitingRules: map[string]RateLimit{
-			"assessment_create": {RequestsPerMinute: 60, BurstSize: 10},
-			"attempt_start":     {RequestsPerMinute: 100, BurstSize: 20},
-			"grading_submit":    {RequestsPerMinute: 200, BurstSize: 50},
+			RateLimitAPI:              {RequestsPerMinute: 600, BurstSize: 100},
+			RateLimitAssessmentCreate: {RequestsPerMinute: 60, BurstSize: 10},
+			RateLimitAttemptStart:     {RequestsPerMinute: 100, BurstSize: 20},
+			RateLimitAttemptAnswer:    {RequestsPerMinute: 60, BurstSize: 20},
+			RateLimitQuestionSearch:   {RequestsPerMinute: 30, BurstSize: 10},
+			RateLimitGradingSubmit:    {RequestsPerMinute: 200, BurstSize: 50},
 		},
 	}
 
-	return NewServiceManager(db, repo, logger, validator, cacheManager, config)
+	return NewServiceManager(db, repo, logger, validator, cacheManager, fileStorage, nil, config)
 }
 
 // CreateDevelopmentServiceManager creates a service manager configured for development
-func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client) ServiceManager {
+func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, redisClient *redis.Client, fileStorage storage.Storage) ServiceManager {
 	// Create cache manager from Redis client
 	cacheManager := cache.NewCacheManager(redisClient)
 	config := ServiceManagerConfig{
@@ -693,6 +1013,18 @@ func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository,
 			AuditingEnabled: false,
 			MetricsEnabled:  false,
 		},
+		TimeoutSweeper: TimeoutSweeperConfig{
+			Enabled:     true,
+			Interval:    time.Minute,
+			GracePeriod: 30 * time.Second,
+			BatchSize:   50,
+		},
+		LifecycleScheduler: LifecycleSchedulerConfig{
+			Enabled:         true,
+			Interval:        time.Minute,
+			BatchSize:       50,
+			ReminderOffsets: []time.Duration{24 * time.Hour, time.Hour},
+		},
 
 		DefaultTimeout:    10 * time.Second,
 		MaxRetries:        1,
@@ -700,5 +1032,5 @@ func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository,
 		RateLimitingRules: make(map[string]RateLimit),
 	}
 
-	return NewServiceManager(db, repo, logger, validator, cacheManager, config)
+	return NewServiceManager(db, repo, logger, validator, cacheManager, fileStorage, nil, config)
 }
@@ -149,7 +149,7 @@ func NewStudentService(repo repositories.Repository, db *gorm.DB, logger *slog.L
 		repo:           repo,
 		db:             db,
 		logger:         logger,
-		attemptService: NewAttemptService(repo, db, logger, nil, nil),
+		attemptService: NewAttemptService(repo, db, logger, nil, nil, nil, AnswerHistoryConfig{}),
 	}
 }
 
@@ -161,11 +161,18 @@ func (s *studentService) GetStudentStats(ctx context.Context, studentID string)
 		return nil, fmt.Errorf("failed to get student attempt stats: %w", err)
 	}
 
+	// Only assessments assigned to the student or their classes are available
+	assignedIDs, err := s.repo.Class().GetAssignedAssessmentIDs(ctx, s.db, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned assessments: %w", err)
+	}
+
 	// Count active assessments (Active status and not expired)
 	var activeAssessmentsCount int64
 	err = s.db.WithContext(ctx).
 		Model(&models.Assessment{}).
 		Where("status = ? AND (due_date IS NULL OR due_date > ?)", models.StatusActive, time.Now()).
+		Where("id IN ?", assignedIDs).
 		Count(&activeAssessmentsCount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count active assessments: %w", err)
@@ -212,6 +219,7 @@ func (s *studentService) GetStudentStats(ctx context.Context, studentID string)
 	err = s.db.WithContext(ctx).
 		Model(&models.Assessment{}).
 		Where("status = ? AND due_date IS NOT NULL AND due_date > ?", models.StatusActive, time.Now()).
+		Where("id IN ?", assignedIDs).
 		Order("due_date ASC").
 		Limit(5).
 		Find(&upcomingAssessments).Error
@@ -282,9 +290,16 @@ func (s *studentService) GetStudentAssessments(ctx context.Context, studentID st
 
 	offset := (page - 1) * size
 
+	// Only assessments assigned to the student or their classes are listed
+	assignedIDs, err := s.repo.Class().GetAssignedAssessmentIDs(ctx, s.db, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned assessments: %w", err)
+	}
+
 	// Build query for active assessments
 	query := s.db.WithContext(ctx).Model(&models.Assessment{}).Preload("Settings").
-		Where("status = ?", models.StatusActive)
+		Where("status = ?", models.StatusActive).
+		Where("id IN ?", assignedIDs)
 
 	// Filter by due date (only show non-expired)
 	query = query.Where("due_date IS NULL OR due_date > ?", time.Now())
@@ -490,6 +505,15 @@ func (s *studentService) GetStudentAssessmentDetail(ctx context.Context, student
 		return nil, fmt.Errorf("failed to get attempt count: %w", err)
 	}
 
+	// Unassigned assessments stay hidden unless the student already has attempts to review
+	assigned, err := s.repo.Class().IsAssigned(ctx, s.db, assessmentID, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check assessment assignment: %w", err)
+	}
+	if !assigned && attemptCount == 0 {
+		return nil, ErrNotFound
+	}
+
 	// Check if has active attempt
 	hasActive, err := s.repo.Attempt().HasActiveAttempt(ctx, s.db, studentID, assessmentID)
 	if err != nil {
@@ -498,7 +522,7 @@ func (s *studentService) GetStudentAssessmentDetail(ctx context.Context, student
 
 	// Check if can start
 	validation, err := s.repo.Attempt().CanStartAttempt(ctx, s.db, studentID, assessmentID)
-	canStart := err == nil && validation != nil && validation.CanStart
+	canStart := assigned && err == nil && validation != nil && validation.CanStart
 
 	// Get attempts history
 	attempts, err := s.repo.Attempt().GetByStudentAndAssessment(ctx, s.db, studentID, assessmentID)
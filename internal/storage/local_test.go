@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage_Path(t *testing.T) {
+	base := t.TempDir()
+	local, err := NewLocalStorage(base, "/uploads")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	tests := []struct {
+		key     string
+		want    string // empty when the key is rejected
+		wantErr bool
+	}{
+		{key: "questions/12/a.png", want: filepath.Join(base, "questions", "12", "a.png")},
+		{key: "/questions/12/a.png", want: filepath.Join(base, "questions", "12", "a.png")},
+		{key: "questions//12/./a.png", want: filepath.Join(base, "questions", "12", "a.png")},
+		{key: "../outside.png", wantErr: true},
+		{key: "questions/../../outside.png", wantErr: true},
+		{key: "questions/12/..", wantErr: true},
+		{key: "", wantErr: true},
+		{key: "/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := local.path(tt.key)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("path(%q) = %q, want error", tt.key, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("path(%q) error = %v", tt.key, err)
+			}
+			if got != tt.want {
+				t.Errorf("path(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	base := t.TempDir()
+	local, err := NewLocalStorage(base, "/uploads")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if err := local.Put(ctx, "questions/1/a.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, err := local.Get(ctx, "questions/1/a.txt")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "hello" {
+		t.Errorf("Get() = %q, want %q", data, "hello")
+	}
+
+	// No temporary upload files are left next to the object
+	entries, _ := os.ReadDir(filepath.Join(base, "questions", "1"))
+	if len(entries) != 1 {
+		t.Errorf("files in directory = %d, want 1", len(entries))
+	}
+
+	if got, want := local.URL("questions/1/a.txt"), "/uploads/questions/1/a.txt"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+
+	if err := local.Delete(ctx, "questions/1/a.txt"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := local.Get(ctx, "questions/1/a.txt"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrObjectNotFound)
+	}
+	// Deleting a missing object is not an error
+	if err := local.Delete(ctx, "questions/1/a.txt"); err != nil {
+		t.Errorf("Delete() of missing object error = %v", err)
+	}
+
+	if err := local.Put(ctx, "../escape.txt", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Error("Put() outside the base directory succeeded")
+	}
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrObjectNotFound is returned when a key does not exist in the backend
+var ErrObjectNotFound = errors.New("storage object not found")
+
+// Storage stores uploaded files under slash-separated keys such as "questions/12/4f1c.png"
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the address clients use to download the object
+	URL(key string) string
+}
+
+// joinURL appends a key to a base URL without doubling or dropping the separator
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(key, "/")
+}
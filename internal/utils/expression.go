@@ -0,0 +1,394 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a parsed arithmetic expression such as "2x^2 + sin(pi*t)". It supports
+// + - * / ^, parentheses, implicit multiplication ("2x", "3(x+1)"), the constants pi and e and
+// the functions in expressionFunctions.
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+var expressionFunctions = map[string]func(float64) float64{
+	"sin":  math.Sin,
+	"cos":  math.Cos,
+	"tan":  math.Tan,
+	"asin": math.Asin,
+	"acos": math.Acos,
+	"atan": math.Atan,
+	"sqrt": math.Sqrt,
+	"abs":  math.Abs,
+	"exp":  math.Exp,
+	"ln":   math.Log,
+	"log":  math.Log10,
+}
+
+var expressionConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// ParseExpression parses source into an Expression
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset+1)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the source the expression was parsed from
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Variables lists the variable names the expression uses, sorted
+func (e *Expression) Variables() []string {
+	seen := make(map[string]bool)
+	e.root.collect(seen)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evaluate computes the expression for the given variable values. Results outside the domain of a
+// function or division by zero give NaN or Inf rather than an error.
+func (e *Expression) Evaluate(variables map[string]float64) (float64, error) {
+	return e.root.eval(variables)
+}
+
+// ===== AST =====
+
+type exprNode interface {
+	eval(variables map[string]float64) (float64, error)
+	collect(seen map[string]bool)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) (float64, error) { return float64(n), nil }
+func (n numberNode) collect(map[string]bool)                  {}
+
+type variableNode string
+
+func (n variableNode) eval(variables map[string]float64) (float64, error) {
+	if value, ok := variables[string(n)]; ok {
+		return value, nil
+	}
+	if value, ok := expressionConstants[string(n)]; ok {
+		return value, nil
+	}
+	return 0, fmt.Errorf("unknown variable '%s'", string(n))
+}
+
+func (n variableNode) collect(seen map[string]bool) {
+	if _, isConstant := expressionConstants[string(n)]; !isConstant {
+		seen[string(n)] = true
+	}
+}
+
+type negateNode struct{ operand exprNode }
+
+func (n negateNode) eval(variables map[string]float64) (float64, error) {
+	value, err := n.operand.eval(variables)
+	return -value, err
+}
+
+func (n negateNode) collect(seen map[string]bool) { n.operand.collect(seen) }
+
+type binaryNode struct {
+	op          byte
+	left, right exprNode
+}
+
+func (n binaryNode) eval(variables map[string]float64) (float64, error) {
+	left, err := n.left.eval(variables)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(variables)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	case '/':
+		return left / right, nil
+	default:
+		return math.Pow(left, right), nil
+	}
+}
+
+func (n binaryNode) collect(seen map[string]bool) {
+	n.left.collect(seen)
+	n.right.collect(seen)
+}
+
+type callNode struct {
+	name     string
+	argument exprNode
+}
+
+func (n callNode) eval(variables map[string]float64) (float64, error) {
+	value, err := n.argument.eval(variables)
+	if err != nil {
+		return 0, err
+	}
+	return expressionFunctions[n.name](value), nil
+}
+
+func (n callNode) collect(seen map[string]bool) { n.argument.collect(seen) }
+
+// ===== TOKENIZER =====
+
+type exprTokenKind int
+
+const (
+	tokenNumber exprTokenKind = iota
+	tokenIdent
+	tokenOperator
+)
+
+type exprToken struct {
+	kind   exprTokenKind
+	text   string
+	offset int
+}
+
+func tokenizeExpression(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	input := []rune(source)
+
+	for i := 0; i < len(input); {
+		r := input[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(input) && (unicode.IsDigit(input[i]) || input[i] == '.') {
+				i++
+			}
+			// Exponent only when digits follow, so "2e" stays 2 times the constant e
+			if i < len(input) && (input[i] == 'e' || input[i] == 'E') {
+				j := i + 1
+				if j < len(input) && (input[j] == '+' || input[j] == '-') {
+					j++
+				}
+				if j < len(input) && unicode.IsDigit(input[j]) {
+					for j < len(input) && unicode.IsDigit(input[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: string(input[start:i]), offset: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(input) && (unicode.IsLetter(input[i]) || unicode.IsDigit(input[i]) || input[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: string(input[start:i]), offset: start})
+
+		default:
+			op := r
+			switch r {
+			case '×', '·':
+				op = '*'
+			case '÷':
+				op = '/'
+			case '−':
+				op = '-'
+			}
+			if !strings.ContainsRune("+-*/^()", op) {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i+1)
+			}
+			// Accept ** as power
+			if op == '*' && i+1 < len(input) && input[i+1] == '*' {
+				op = '^'
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenOperator, text: string(op), offset: i})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+// ===== PARSER =====
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() *exprToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *exprParser) peekOperator(ops string) (byte, bool) {
+	token := p.peek()
+	if token == nil || token.kind != tokenOperator || !strings.Contains(ops, token.text) {
+		return 0, false
+	}
+	return token.text[0], true
+}
+
+// parseSum := product (('+' | '-') product)*
+func (p *exprParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.peekOperator("+-")
+		if !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// parseProduct := unary (('*' | '/')? unary)*; a missing operator is implicit multiplication
+func (p *exprParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.peekOperator("*/")
+		if ok {
+			p.pos++
+		} else if token := p.peek(); token != nil && (token.kind != tokenOperator || token.text == "(") {
+			op = '*'
+		} else {
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+// parseUnary := ('-' | '+') unary | power
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.peekOperator("+-"); ok {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == '-' {
+			return negateNode{operand: operand}, nil
+		}
+		return operand, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower := primary ('^' unary)?, so powers bind right to left
+func (p *exprParser) parsePower() (exprNode, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.peekOperator("^"); !ok {
+		return base, nil
+	}
+	p.pos++
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return binaryNode{op: '^', left: base, right: exponent}, nil
+}
+
+// parsePrimary := number | function '(' sum ')' | variable | '(' sum ')'
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	token := p.peek()
+	if token == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+
+	switch token.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", token.text)
+		}
+		return numberNode(value), nil
+
+	case tokenIdent:
+		if _, isFunction := expressionFunctions[token.text]; isFunction {
+			if _, ok := p.peekOperator("("); !ok {
+				return nil, fmt.Errorf("function '%s' must be followed by '('", token.text)
+			}
+			argument, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return callNode{name: token.text, argument: argument}, nil
+		}
+		return variableNode(token.text), nil
+
+	default:
+		if token.text != "(" {
+			return nil, fmt.Errorf("unexpected '%s' at position %d", token.text, token.offset+1)
+		}
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.peekOperator(")"); !ok {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", token.offset+1)
+		}
+		p.pos++
+		return inner, nil
+	}
+}
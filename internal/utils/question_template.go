@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// templatePlaceholder matches {{expression}} or {{expression:decimals}}
+var templatePlaceholder = regexp.MustCompile(`\{\{([^{}:]+)(?::(\d+))?\}\}`)
+
+// ReadQuestionTemplate returns the template declared in question content, or nil when the
+// question is not templated
+func ReadQuestionTemplate(content []byte) (*models.QuestionTemplate, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	var templated struct {
+		Template *models.QuestionTemplate `json:"template"`
+	}
+	if err := json.Unmarshal(content, &templated); err != nil {
+		return nil, fmt.Errorf("invalid question content: %w", err)
+	}
+	return templated.Template, nil
+}
+
+// DrawTemplateValues picks a value for every template variable
+func DrawTemplateValues(template *models.QuestionTemplate, rng *rand.Rand) map[string]float64 {
+	values := make(map[string]float64, len(template.Variables))
+	for _, variable := range template.Variables {
+		if len(variable.Values) > 0 {
+			values[variable.Name] = variable.Values[rng.Intn(len(variable.Values))]
+			continue
+		}
+
+		step := 1.0
+		if variable.Step != nil && *variable.Step > 0 {
+			step = *variable.Step
+		}
+		steps := int(math.Floor((variable.Max-variable.Min)/step + 1e-9))
+		value := variable.Min + float64(rng.Intn(steps+1))*step
+		// Drop floating point noise from the step arithmetic
+		values[variable.Name] = math.Round(value*1e9) / 1e9
+	}
+	return values
+}
+
+// RenderTemplate replaces the placeholders in text with their values
+func RenderTemplate(text string, values map[string]float64) (string, error) {
+	var renderErr error
+	rendered := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		parts := templatePlaceholder.FindStringSubmatch(placeholder)
+		expression, err := ParseExpression(parts[1])
+		if err != nil {
+			renderErr = fmt.Errorf("invalid placeholder %s: %w", placeholder, err)
+			return placeholder
+		}
+		value, err := expression.Evaluate(values)
+		if err != nil {
+			renderErr = fmt.Errorf("invalid placeholder %s: %w", placeholder, err)
+			return placeholder
+		}
+		if parts[2] != "" {
+			decimals, _ := strconv.Atoi(parts[2])
+			return strconv.FormatFloat(value, 'f', decimals, 64)
+		}
+		return formatTemplateValue(value)
+	})
+	return rendered, renderErr
+}
+
+// RenderTemplateContent renders every string in question content and, for numeric questions with
+// an answer formula, computes correct_value. The template itself is removed.
+func RenderTemplateContent(content []byte, template *models.QuestionTemplate, values map[string]float64) ([]byte, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, fmt.Errorf("invalid question content: %w", err)
+	}
+	delete(fields, "template")
+
+	rendered, err := renderTemplateValue(fields, values)
+	if err != nil {
+		return nil, err
+	}
+	fields = rendered.(map[string]interface{})
+
+	if template.AnswerFormula != "" {
+		expression, err := ParseExpression(template.AnswerFormula)
+		if err != nil {
+			return nil, fmt.Errorf("invalid answer formula: %w", err)
+		}
+		answer, err := expression.Evaluate(values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid answer formula: %w", err)
+		}
+		if math.IsNaN(answer) || math.IsInf(answer, 0) {
+			return nil, fmt.Errorf("answer formula is undefined for %v", values)
+		}
+		fields["correct_value"] = answer
+	}
+
+	return json.Marshal(fields)
+}
+
+func renderTemplateValue(value interface{}, values map[string]float64) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return RenderTemplate(v, values)
+	case map[string]interface{}:
+		for key, item := range v {
+			rendered, err := renderTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// formatTemplateValue prints whole numbers without decimals and drops floating point noise
+func formatTemplateValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 12, 64)
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "context"
+
+type requestInfoKey struct{}
+
+// RequestInfo carries per-request metadata from the HTTP layer down to services
+type RequestInfo struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+}
+
+// WithRequestInfo returns a copy of ctx that carries the given request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext extracts request info stored by WithRequestInfo
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
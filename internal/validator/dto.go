@@ -8,27 +8,29 @@ import (
 
 // AssessmentCreateRequest represents the request structure for creating assessments
 type AssessmentCreateRequest struct {
-	Title        string                      `json:"title" validate:"required,assessment_title"`
-	Description  *string                     `json:"description" validate:"omitempty,assessment_description"`
-	Duration     int                         `json:"duration" validate:"required,assessment_duration"`
-	PassingScore int                         `json:"passing_score" validate:"required,passing_score"`
-	MaxAttempts  int                         `json:"max_attempts" validate:"required,max_attempts"`
-	TimeWarning  *int                        `json:"time_warning" validate:"omitempty,min=60,max=1800"`
-	DueDate      *time.Time                  `json:"due_date" validate:"omitempty,future_date"`
-	Settings     *AssessmentSettingsRequest  `json:"settings"`
-	Questions    []AssessmentQuestionRequest `json:"questions"`
+	Title         string                      `json:"title" validate:"required,assessment_title"`
+	Description   *string                     `json:"description" validate:"omitempty,assessment_description"`
+	Duration      int                         `json:"duration" validate:"required,assessment_duration"`
+	PassingScore  int                         `json:"passing_score" validate:"required,passing_score"`
+	MaxAttempts   int                         `json:"max_attempts" validate:"required,max_attempts"`
+	TimeWarning   *int                        `json:"time_warning" validate:"omitempty,min=60,max=1800"`
+	DueDate       *time.Time                  `json:"due_date" validate:"omitempty,future_date"`
+	AvailableFrom *time.Time                  `json:"available_from" validate:"omitempty,future_date"`
+	Settings      *AssessmentSettingsRequest  `json:"settings"`
+	Questions     []AssessmentQuestionRequest `json:"questions"`
 }
 
 // AssessmentUpdateRequest represents the request structure for updating assessments
 type AssessmentUpdateRequest struct {
-	Title        *string                    `json:"title" validate:"omitempty,assessment_title"`
-	Description  *string                    `json:"description" validate:"omitempty,assessment_description"`
-	Duration     *int                       `json:"duration" validate:"omitempty,assessment_duration"`
-	PassingScore *int                       `json:"passing_score" validate:"omitempty,passing_score"`
-	MaxAttempts  *int                       `json:"max_attempts" validate:"omitempty,max_attempts"`
-	TimeWarning  *int                       `json:"time_warning" validate:"omitempty,min=60,max=1800"`
-	DueDate      *time.Time                 `json:"due_date" validate:"omitempty,future_date"`
-	Settings     *AssessmentSettingsRequest `json:"settings"`
+	Title         *string                    `json:"title" validate:"omitempty,assessment_title"`
+	Description   *string                    `json:"description" validate:"omitempty,assessment_description"`
+	Duration      *int                       `json:"duration" validate:"omitempty,assessment_duration"`
+	PassingScore  *int                       `json:"passing_score" validate:"omitempty,passing_score"`
+	MaxAttempts   *int                       `json:"max_attempts" validate:"omitempty,max_attempts"`
+	TimeWarning   *int                       `json:"time_warning" validate:"omitempty,min=60,max=1800"`
+	DueDate       *time.Time                 `json:"due_date" validate:"omitempty,future_date"`
+	AvailableFrom *time.Time                 `json:"available_from" validate:"omitempty,future_date"`
+	Settings      *AssessmentSettingsRequest `json:"settings"`
 }
 
 // AssessmentSettingsRequest represents assessment settings
@@ -45,6 +47,11 @@ type AssessmentSettingsRequest struct {
 	AllowScreenReader           *bool `json:"allow_screen_reader"`
 	FontSizeAdjustment          *int  `json:"font_size_adjustment" validate:"omitempty,min=-2,max=2"`
 	HighContrastMode            *bool `json:"high_contrast_mode"`
+	RequireSubmitConfirmation   *bool `json:"require_submit_confirmation"`
+
+	NegativeMarking      *models.NegativeMarkingMode `json:"negative_marking" validate:"omitempty,oneof=none fixed fraction"`
+	NegativeMarkingValue *float64                    `json:"negative_marking_value" validate:"omitempty,min=0,max=100"`
+	ConfidenceMarking    *bool                       `json:"confidence_marking"`
 }
 
 // AssessmentQuestionRequest represents adding questions to assessments
@@ -3,8 +3,14 @@ package validator
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
 )
 
 // QuestionValidator handles question-specific validation
@@ -26,6 +32,11 @@ func (v *QuestionValidator) ValidateContent(questionType models.QuestionType, co
 		return fmt.Errorf("failed to marshal content: %w", err)
 	}
 
+	// Templated content is validated as rendered for a sample variant
+	if _, contentBytes, err = RenderTemplateSample("", contentBytes); err != nil {
+		return err
+	}
+
 	switch questionType {
 	case models.MultipleChoice:
 		return v.validateMultipleChoiceContent(contentBytes)
@@ -41,6 +52,10 @@ func (v *QuestionValidator) ValidateContent(questionType models.QuestionType, co
 		return v.validateOrderingContent(contentBytes)
 	case models.ShortAnswer:
 		return v.validateShortAnswerContent(contentBytes)
+	case models.Numeric:
+		return v.validateNumericContent(contentBytes)
+	case models.Formula:
+		return v.validateFormulaContent(contentBytes)
 	default:
 		return fmt.Errorf("unsupported question type: %s", questionType)
 	}
@@ -56,6 +71,14 @@ func (v *QuestionValidator) ValidateQuestion(question *models.Question) error {
 		return fmt.Errorf("question points must be between 1 and 100")
 	}
 
+	contentBytes, err := json.Marshal(question.Content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content: %w", err)
+	}
+	if _, _, err := RenderTemplateSample(question.Text, contentBytes); err != nil {
+		return err
+	}
+
 	return v.ValidateContent(question.Type, question.Content)
 }
 
@@ -181,12 +204,15 @@ func (v *QuestionValidator) validateFillBlankContent(contentBytes []byte) error
 	}
 
 	for blankID, blankDef := range content.Blanks {
-		if len(blankDef.AcceptedAnswers) == 0 {
-			return fmt.Errorf("blank '%s' must have at least 1 accepted answer", blankID)
+		if len(blankDef.AcceptedAnswers) == 0 && len(blankDef.AnswerRules) == 0 {
+			return fmt.Errorf("blank '%s' must have at least 1 accepted answer or answer rule", blankID)
 		}
 		if blankDef.Points < 0 {
 			return fmt.Errorf("blank '%s' points cannot be negative", blankID)
 		}
+		if err := ValidateAnswerRules(blankDef.AnswerRules, blankDef.FuzzyThreshold); err != nil {
+			return fmt.Errorf("blank '%s': %w", blankID, err)
+		}
 	}
 
 	return nil
@@ -293,8 +319,8 @@ func (v *QuestionValidator) validateShortAnswerContent(contentBytes []byte) erro
 		return fmt.Errorf("invalid short answer content: %w", err)
 	}
 
-	if len(content.AcceptedAnswers) == 0 {
-		return fmt.Errorf("must have at least 1 accepted answer")
+	if len(content.AcceptedAnswers) == 0 && len(content.AnswerRules) == 0 {
+		return fmt.Errorf("must have at least 1 accepted answer or answer rule")
 	}
 
 	if content.MaxLength < 1 {
@@ -314,5 +340,222 @@ func (v *QuestionValidator) validateShortAnswerContent(contentBytes []byte) erro
 		}
 	}
 
+	return ValidateAnswerRules(content.AnswerRules, content.FuzzyThreshold)
+}
+
+func (v *QuestionValidator) validateNumericContent(contentBytes []byte) error {
+	var content models.NumericContent
+	if err := json.Unmarshal(contentBytes, &content); err != nil {
+		return fmt.Errorf("invalid numeric content: %w", err)
+	}
+
+	if math.IsNaN(content.CorrectValue) || math.IsInf(content.CorrectValue, 0) {
+		return fmt.Errorf("correct value must be a finite number")
+	}
+
+	if content.AbsoluteTolerance != nil && *content.AbsoluteTolerance < 0 {
+		return fmt.Errorf("absolute tolerance cannot be negative")
+	}
+
+	if content.RelativeTolerance != nil && *content.RelativeTolerance < 0 {
+		return fmt.Errorf("relative tolerance cannot be negative")
+	}
+
+	if content.SignificantFigures != nil && (*content.SignificantFigures < 1 || *content.SignificantFigures > 15) {
+		return fmt.Errorf("significant figures must be between 1 and 15")
+	}
+
+	if content.SignificantFiguresPenalty != nil && (*content.SignificantFiguresPenalty < 0 || *content.SignificantFiguresPenalty > 1) {
+		return fmt.Errorf("significant figures penalty must be between 0 and 1")
+	}
+
+	if content.UnitRequired && content.Unit == "" {
+		return fmt.Errorf("unit is required when answers must include one")
+	}
+
+	for unit, factor := range content.UnitFactors {
+		if factor <= 0 {
+			return fmt.Errorf("factor for unit '%s' must be positive", unit)
+		}
+	}
+
+	return nil
+}
+
+func (v *QuestionValidator) validateFormulaContent(contentBytes []byte) error {
+	var content models.FormulaContent
+	if err := json.Unmarshal(contentBytes, &content); err != nil {
+		return fmt.Errorf("invalid formula content: %w", err)
+	}
+
+	if content.SamplePoints < 0 || content.SamplePoints > 100 {
+		return fmt.Errorf("sample points must be between 1 and 100")
+	}
+
+	if content.Tolerance != nil && *content.Tolerance < 0 {
+		return fmt.Errorf("tolerance cannot be negative")
+	}
+
+	return ValidateFormula(content.CorrectFormula, content.Variables)
+}
+
+// ValidateFormula checks that formula parses, only uses the declared variables, and can be
+// evaluated within their ranges
+func ValidateFormula(formula string, variables []models.FormulaVariable) error {
+	if strings.TrimSpace(formula) == "" {
+		return fmt.Errorf("correct formula is required")
+	}
+
+	expression, err := utils.ParseExpression(formula)
+	if err != nil {
+		return fmt.Errorf("invalid formula: %w", err)
+	}
+
+	declared := make(map[string]bool, len(variables))
+	midpoint := make(map[string]float64, len(variables))
+	for i, variable := range variables {
+		if variable.Name == "" {
+			return fmt.Errorf("variable %d name is required", i+1)
+		}
+		if declared[variable.Name] {
+			return fmt.Errorf("variable '%s' is declared more than once", variable.Name)
+		}
+		if variable.Min > variable.Max {
+			return fmt.Errorf("variable '%s' minimum cannot exceed its maximum", variable.Name)
+		}
+		declared[variable.Name] = true
+		midpoint[variable.Name] = (variable.Min + variable.Max) / 2
+	}
+
+	for _, name := range expression.Variables() {
+		if !declared[name] {
+			return fmt.Errorf("formula uses undeclared variable '%s'", name)
+		}
+	}
+
+	if _, err := expression.Evaluate(midpoint); err != nil {
+		return fmt.Errorf("invalid formula: %w", err)
+	}
+
+	return nil
+}
+
+// RenderTemplateSample validates the template of templated question content and renders text and
+// content for a sample variant. Content without a template is returned unchanged.
+func RenderTemplateSample(text string, contentBytes []byte) (string, []byte, error) {
+	template, err := utils.ReadQuestionTemplate(contentBytes)
+	if err != nil || template == nil {
+		return text, contentBytes, err
+	}
+
+	if err := ValidateQuestionTemplate(template); err != nil {
+		return "", nil, err
+	}
+
+	values := utils.DrawTemplateValues(template, rand.New(rand.NewSource(1)))
+	renderedText, err := utils.RenderTemplate(text, values)
+	if err != nil {
+		return "", nil, fmt.Errorf("question text: %w", err)
+	}
+	renderedContent, err := utils.RenderTemplateContent(contentBytes, template, values)
+	if err != nil {
+		return "", nil, fmt.Errorf("template: %w", err)
+	}
+
+	return renderedText, renderedContent, nil
+}
+
+// ValidateQuestionTemplate checks template variables and the answer formula
+func ValidateQuestionTemplate(template *models.QuestionTemplate) error {
+	if len(template.Variables) == 0 {
+		return fmt.Errorf("template must declare at least 1 variable")
+	}
+
+	declared := make(map[string]bool, len(template.Variables))
+	for i, variable := range template.Variables {
+		expression, err := utils.ParseExpression(variable.Name)
+		if err != nil || len(expression.Variables()) != 1 || expression.Variables()[0] != variable.Name {
+			return fmt.Errorf("template variable %d name '%s' is not a valid identifier", i+1, variable.Name)
+		}
+		if declared[variable.Name] {
+			return fmt.Errorf("template variable '%s' is declared more than once", variable.Name)
+		}
+		declared[variable.Name] = true
+
+		if len(variable.Values) > 0 {
+			continue
+		}
+		if variable.Min > variable.Max {
+			return fmt.Errorf("template variable '%s' minimum cannot exceed its maximum", variable.Name)
+		}
+		if variable.Step != nil && *variable.Step <= 0 {
+			return fmt.Errorf("template variable '%s' step must be positive", variable.Name)
+		}
+	}
+
+	if template.AnswerFormula != "" {
+		expression, err := utils.ParseExpression(template.AnswerFormula)
+		if err != nil {
+			return fmt.Errorf("invalid answer formula: %w", err)
+		}
+		for _, name := range expression.Variables() {
+			if !declared[name] {
+				return fmt.Errorf("answer formula uses undeclared variable '%s'", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateAnswerRules checks the accepted-answer rules of a short answer question or blank, so
+// that invalid patterns and numbers are rejected when the question is authored
+func ValidateAnswerRules(rules []models.AnswerRule, fuzzyThreshold *float64) error {
+	if fuzzyThreshold != nil && (*fuzzyThreshold <= 0 || *fuzzyThreshold > 1) {
+		return fmt.Errorf("fuzzy threshold must be greater than 0 and at most 1")
+	}
+
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Value) == "" {
+			return fmt.Errorf("answer rule %d value cannot be empty", i+1)
+		}
+
+		switch rule.Type {
+		case models.AnswerMatchText, "":
+			for j, synonym := range rule.Synonyms {
+				if strings.TrimSpace(synonym) == "" {
+					return fmt.Errorf("answer rule %d synonym %d cannot be empty", i+1, j+1)
+				}
+			}
+
+		case models.AnswerMatchRegex:
+			if _, err := regexp.Compile(`^(?:` + rule.Value + `)$`); err != nil {
+				return fmt.Errorf("answer rule %d has an invalid regular expression: %w", i+1, err)
+			}
+
+		case models.AnswerMatchNumeric:
+			if _, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(rule.Value), ",", ".", 1), 64); err != nil {
+				return fmt.Errorf("answer rule %d value must be a number", i+1)
+			}
+			if rule.AbsoluteTolerance != nil && *rule.AbsoluteTolerance < 0 {
+				return fmt.Errorf("answer rule %d absolute tolerance cannot be negative", i+1)
+			}
+			if rule.RelativeTolerance != nil && *rule.RelativeTolerance < 0 {
+				return fmt.Errorf("answer rule %d relative tolerance cannot be negative", i+1)
+			}
+			if rule.UnitRequired && rule.Unit == "" {
+				return fmt.Errorf("answer rule %d requires a unit but has none", i+1)
+			}
+			for unit, factor := range rule.UnitFactors {
+				if factor <= 0 {
+					return fmt.Errorf("answer rule %d factor for unit '%s' must be positive", i+1, unit)
+				}
+			}
+
+		default:
+			return fmt.Errorf("answer rule %d has unknown type '%s'", i+1, rule.Type)
+		}
+	}
+
 	return nil
 }
@@ -110,6 +110,8 @@ func validateQuestionType(fl validator.FieldLevel) bool {
 		models.Matching,
 		models.Ordering,
 		models.ShortAnswer,
+		models.Numeric,
+		models.Formula,
 	}
 
 	value := fl.Field().String()
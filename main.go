@@ -43,6 +43,19 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
+	// Schema migrations: "assessment-service migrate up|down [N]|status"
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(context.Background(), db, slogLogger, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+	if cfg.MigrateOnStartup {
+		if err := migrateOnStartup(context.Background(), db, slogLogger); err != nil {
+			log.Fatalf("Failed to apply database migrations: %v", err)
+		}
+	}
+
 	// Initialize Redis (if configured)
 	var redisClient *redis.Client
 	if cfg.RedisURL != "" {
@@ -73,8 +86,21 @@ func main() {
 	// Initialize validator
 	validator := validator.New()
 
+	// Initialize file storage for question attachments
+	fileStorage, err := cfg.Storage.CreateStorage(slogLogger)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+
+	// Initialize event publisher for notification events
+	eventPublisher, err := cfg.Events.CreateEventPublisher(slogLogger)
+	if err != nil {
+		slogLogger.Warn("Failed to create event publisher, falling back to mock publisher", "error", err)
+		eventPublisher = nil
+	}
+
 	// Initialize services
-	serviceManager := services.NewDefaultServiceManager(db, repoManager.GetRepository(), slogLogger, validator, redisClient)
+	serviceManager := services.NewDefaultServiceManager(db, repoManager.GetRepository(), slogLogger, validator, redisClient, fileStorage, eventPublisher)
 	if err := serviceManager.Initialize(context.Background()); err != nil {
 		log.Fatalf("Failed to initialize services: %v", err)
 	}
@@ -97,6 +123,11 @@ func main() {
 	// Setup routes
 	handlerManager.SetupRoutes(router)
 
+	// Serve locally stored attachments; the S3 backend serves them from the bucket
+	if cfg.Storage.ServesLocalFiles() {
+		router.Static(cfg.Storage.PublicURL, cfg.Storage.LocalDir)
+	}
+
 	// Create HTTP server
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.Port),
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/SAP-F-2025/assessment-service/internal/migrations"
+)
+
+const migrateUsage = `usage: assessment-service migrate <command>
+
+commands:
+  up         apply all pending migrations
+  down [N]   revert the last N applied migrations (default 1)
+  status     list migrations and whether they are applied`
+
+// runMigrateCommand handles "assessment-service migrate ..."
+func runMigrateCommand(ctx context.Context, db *gorm.DB, logger *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	migrator, err := migrations.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migration(s)\n", reverted)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\t")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Modified {
+				appliedAt += " (modified)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+
+	return nil
+}
+
+// migrateOnStartup applies pending migrations before the services start
+func migrateOnStartup(ctx context.Context, db *gorm.DB, logger *slog.Logger) error {
+	migrator, err := migrations.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("Database migrations applied", "count", applied)
+	return nil
+}
@@ -24,13 +24,8 @@ func InitDatabase(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	//err = db.AutoMigrate(&models.Question{}, &models.QuestionBank{},
-	//	&models.Assessment{}, &models.AssessmentQuestion{}, &models.QuestionBankShare{}, &models.AssessmentSettings{},
-	//	&models.AssessmentAttempt{}, &models.StudentAnswer{}, &models.QuestionCategory{}, &models.QuestionAttachment{},
-	//	&models.ImportJob{})
-	//if err != nil {
-	//	return nil, err
-	//}
+	// The schema is managed by the versioned migrations in internal/migrations,
+	// applied with "assessment-service migrate up" or MIGRATE_ON_STARTUP=true
 
 	return db, nil
 }